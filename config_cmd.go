@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coltonmosier/api-v1/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// configCommand runs the "config" subcommand and returns the exit code
+// "config print [flags]" prints the effective configuration as yaml with secrets redacted
+func configCommand(args []string) int {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, "usage: api config print [flags]")
+		return 2
+	}
+
+	cfg, err := config.Load(args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid configuration:", err)
+		return 1
+	}
+
+	out, err := yaml.Marshal(cfg.Redacted())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to encode configuration:", err)
+		return 1
+	}
+	os.Stdout.Write(out)
+	return 0
+}
@@ -16,9 +16,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/device": {
+        "/assignee": {
             "get": {
-                "description": "get all device types from the database",
+                "description": "get all people and teams equipment can be checked out to",
                 "consumes": [
                     "application/json"
                 ],
@@ -26,9 +26,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "device"
+                    "assignee"
                 ],
-                "summary": "get all device types",
+                "summary": "get all assignees",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -43,7 +43,7 @@ const docTemplate = `{
                                         "MSG": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.DeviceType"
+                                                "$ref": "#/definitions/models.Assignee"
                                             }
                                         }
                                     }
@@ -55,20 +55,12 @@ const docTemplate = `{
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/device"
-                            }
                         }
                     }
-                },
-                "x-order": 1
+                }
             },
             "post": {
-                "description": "create device type for the database",
+                "description": "create a person or team equipment can be checked out to",
                 "consumes": [
                     "application/json"
                 ],
@@ -76,65 +68,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "device"
+                    "assignee"
                 ],
-                "summary": "create device type",
+                "summary": "create assignee",
                 "parameters": [
                     {
+                        "maxLength": 100,
                         "type": "string",
-                        "description": "Device Name",
+                        "description": "person or team name",
                         "name": "name",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "person",
+                            "team"
+                        ],
+                        "type": "string",
+                        "description": "person or team, defaults to person",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "contact email",
+                        "name": "email",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "deivce created with name of {name}",
-                                "Action": "none"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Assignee"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "device type name already exists",
-                                "Action": "POST /api/v1/device?name={newName}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "failed to create device",
-                                "Action": "POST /api/v1/device?name={newName}"
-                            }
                         }
                     }
-                },
-                "x-order": 4
+                }
             }
         },
-        "/device/{id}": {
+        "/assignee/{id}": {
             "get": {
-                "description": "get device type by ID from the database",
+                "description": "get an assignee by ID from the database",
                 "consumes": [
                     "application/json"
                 ],
@@ -142,14 +142,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "device"
+                    "assignee"
                 ],
-                "summary": "get device type by ID",
+                "summary": "get an assignee by ID",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Device ID",
+                        "description": "assignee id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -167,7 +167,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "MSG": {
-                                            "$ref": "#/definitions/models.DeviceType"
+                                            "$ref": "#/definitions/models.Assignee"
                                         }
                                     }
                                 }
@@ -178,35 +178,20 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "device id is not a number",
-                                "Action": "GET /api/v1/device/{id}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/device/{id}"
-                            }
                         }
                     }
-                },
-                "x-order": 2
+                }
             }
         },
-        "/device/{id}/name": {
-            "patch": {
-                "description": "update device type by name ID from the database",
+        "/assignee/{id}/assignments": {
+            "get": {
+                "description": "get every check out made to an assignee, oldest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -214,73 +199,143 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "device"
+                    "assignee"
                 ],
-                "summary": "update device type by name ID",
+                "summary": "get the assignment history of an assignee",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Device ID",
+                        "description": "assignee id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Assignment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/assignee/{id}/equipment": {
+            "get": {
+                "description": "get the equipment currently checked out to an assignee",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assignee"
+                ],
+                "summary": "get the equipment an assignee holds",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device Name",
-                        "name": "name",
-                        "in": "query",
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "assignee id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "device type {id} updated with a nof of {newName}",
-                                "Action": "none"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "device name is missing",
-                                "Action": "PATCH /api/v1/device/{id}/name?name={newName}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "something went wrong + sql.Err()",
-                                "Action": "PATCH /api/v1/device/{id}/name?name={newName}"
-                            }
                         }
                     }
-                },
-                "x-order": 3
+                }
             }
         },
-        "/device/{id}/status": {
+        "/assignee/{id}/status": {
             "patch": {
-                "description": "update device type by status ID from the database",
+                "description": "update assignee status, inactive assignees cannot check out equipment",
                 "consumes": [
                     "application/json"
                 ],
@@ -288,14 +343,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "device"
+                    "assignee"
                 ],
-                "summary": "update device type by status ID",
+                "summary": "update assignee status",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Device ID",
+                        "description": "assignee id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -306,7 +361,7 @@ const docTemplate = `{
                             "inactive"
                         ],
                         "type": "string",
-                        "description": "Device Status",
+                        "description": "assignee status",
                         "name": "status",
                         "in": "query",
                         "required": true
@@ -317,48 +372,26 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "device type {id} updated with status of {newStatus}",
-                                "Action": "none"
-                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "status must be either active or inactive",
-                                "Action": "PATCH /api/v1/device/{id}/status?status={newStatus}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "something went wrong + sql.Err()",
-                                "Action": "PATCH /api/v1/device/{id}/status?status={newStatus}"
-                            }
                         }
                     }
-                },
-                "x-order": 3
+                }
             }
         },
-        "/equipment": {
+        "/assignment/equipment/{id}": {
             "get": {
-                "description": "get all equipment with manufacturers from the database",
+                "description": "get every check out of equipment, oldest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -366,15 +399,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "assignment"
                 ],
-                "summary": "get all equipments with",
+                "summary": "get the assignment history of equipment",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
-                        "name": "all",
-                        "in": "query",
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -392,7 +426,7 @@ const docTemplate = `{
                                         "MSG": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Equipment"
+                                                "$ref": "#/definitions/models.Assignment"
                                             }
                                         }
                                     }
@@ -400,23 +434,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment?all=true"
-                            }
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "create equipment in the database",
+            }
+        },
+        "/attachment/equipment/{id}": {
+            "get": {
+                "description": "get the files attached to equipment, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -424,31 +459,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "attachment"
                 ],
-                "summary": "create equipment",
+                "summary": "get the files attached to equipment",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "manufacturer id",
-                        "name": "manufacturer",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "device id",
-                        "name": "device",
-                        "in": "query",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -456,46 +476,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
+                            "allOf": [
+                                {
                                     "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "equipment created",
-                                "Action": "none"
-                            }
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Attachment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "cannot create, manufacturer is inactive",
-                                "Action": "GET /api/v1/manufacturer/{id}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "failed to decode response from /api/v1/manufacturer",
-                                "Action": "GET /api/v1/manufacturer/{id}"
-                            }
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "update equipment in the database",
+            }
+        },
+        "/attachment/manufacturer/{id}": {
+            "get": {
+                "description": "get the files attached to a manufacturer, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -503,39 +519,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "attachment"
                 ],
-                "summary": "update equipment",
+                "summary": "get the files attached to a manufacturer",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "equipment id",
+                        "description": "manufacturer id",
                         "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "manufacturer id",
-                        "name": "manufacturer_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "device id",
-                        "name": "device_id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -543,137 +536,88 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                                "$ref": "#/definitions/models.JsonResponse"
-                            },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "equipment updated",
-                                "Action": "none"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Attachment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "id is not a number",
-                                "Action": "PATCH /api/v1/equipment?id={id}&sn={sn}&manufacturer_id={manufacturer_id}&device_id={device_id}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "PATCH /api/v1/equipment?id={id}&sn={sn}&manufacturer_id={manufacturer_id}&device_id={device_id}"
-                            }
                         }
                     }
                 }
             }
         },
-        "/equipment/device/{device_id}/manufacturer/{manufacturer_id}": {
+        "/attachment/{id}": {
             "get": {
-                "description": "get equipment by device id and manufacturer id from the database",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "download an attached file with the name it was uploaded with. The ETag is the SHA-256 checksum of the file and range requests are supported",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "equipment"
+                    "attachment"
                 ],
-                "summary": "get equipment by device id and manufacturer id",
+                "summary": "download an attached file",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "device id",
-                        "name": "device_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "manufacturer id",
-                        "name": "manufacturer_id",
+                        "description": "attachment id",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
-                        "name": "all",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.JsonResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "MSG": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Equipment"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "device id is not a number",
-                                "Action": "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}"
-                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}"
-                            }
                         }
                     }
                 }
-            }
-        },
-        "/equipment/device/{id}": {
-            "get": {
-                "description": "get equipment by device id from the database",
+            },
+            "delete": {
+                "description": "delete an attached file from the database and from storage",
                 "consumes": [
                     "application/json"
                 ],
@@ -681,80 +625,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "attachment"
                 ],
-                "summary": "get equipment by device id",
+                "summary": "delete an attached file",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "device id",
+                        "description": "attachment id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
-                        "name": "all",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.JsonResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "MSG": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Equipment"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.JsonResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "device type does not exist",
-                                "Action": "GET /api/v1/equipment/device/{device_id}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/device/{device_id}"
-                            }
                         }
                     }
                 }
             }
         },
-        "/equipment/id": {
+        "/attributes/equipment/{id}": {
             "get": {
-                "description": "get equipment by auto_id from the database",
+                "description": "get the values equipment has for the attributes of its device type",
                 "consumes": [
                     "application/json"
                 ],
@@ -764,14 +672,14 @@ const docTemplate = `{
                 "tags": [
                     "equipment"
                 ],
-                "summary": "get equipment by auto ID",
+                "summary": "get the attribute values of equipment",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "auto_id",
+                        "description": "equipment id",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -787,7 +695,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "MSG": {
-                                            "$ref": "#/definitions/models.Equipment"
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
                                         }
                                     }
                                 }
@@ -798,34 +709,20 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "id is not a number",
-                                "Action": "GET /api/v1/equipment/id?id={id}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/id?id={id}"
-                            }
                         }
                     }
                 }
             }
         },
-        "/equipment/manufacturer/{id}": {
+        "/count": {
             "get": {
-                "description": "get equipment by manufacturer id from the database",
+                "description": "get every physical inventory count, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -833,26 +730,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
-                ],
-                "summary": "get equipment by manufacturer id",
-                "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "manufacturer id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
-                        "name": "all",
-                        "in": "query",
-                        "required": true
-                    }
+                    "count"
                 ],
+                "summary": "get all count sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -867,7 +747,7 @@ const docTemplate = `{
                                         "MSG": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Equipment"
+                                                "$ref": "#/definitions/models.CountSession"
                                             }
                                         }
                                     }
@@ -875,38 +755,16 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "manufacturer does not exist",
-                                "Action": "GET /api/v1/manufacturer"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/manufacturer/{id}?all=true"
-                            }
                         }
                     }
                 }
-            }
-        },
-        "/equipment/sn": {
-            "get": {
-                "description": "get equipment by serial number from the database",
+            },
+            "post": {
+                "description": "open a physical inventory count of a location, sub locations included, a device type or both. Leave both out to count everything",
                 "consumes": [
                     "application/json"
                 ],
@@ -914,16 +772,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "count"
                 ],
-                "summary": "get equipment by serial number",
+                "summary": "open a count session",
                 "parameters": [
                     {
+                        "maxLength": 100,
                         "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
+                        "description": "what is being counted",
+                        "name": "name",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id to count",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id to count",
+                        "name": "device",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -938,7 +811,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "MSG": {
-                                            "$ref": "#/definitions/models.Equipment"
+                                            "$ref": "#/definitions/models.CountSession"
                                         }
                                     }
                                 }
@@ -949,32 +822,20 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "serial number must start with SN-",
-                                "Action": "GET /api/v1/equipment/sn?sn={sn}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/sn?sn={sn}"
-                            }
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "update equipment serial number in the database",
+            }
+        },
+        "/count/{id}": {
+            "get": {
+                "description": "get a physical inventory count by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -982,23 +843,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "count"
                 ],
-                "summary": "update equipment serial number",
+                "summary": "get a count session",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "equipment id",
+                        "description": "count session id",
                         "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -1006,48 +860,39 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "serial number updated",
-                                "Action": "none"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.CountSession"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "serial number does not exist",
-                                "Action": "GET /api/v1/equipment/sn-like/{sn}?all=true"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "PATCH /api/v1/equipment/sn?sn={newsn}&id={id}"
-                            }
                         }
                     }
                 }
             }
         },
-        "/equipment/sn-like/{sn}": {
-            "get": {
-                "description": "get equipment like serial number from the database",
+        "/count/{id}/apply": {
+            "post": {
+                "description": "apply the reconciliation of an open count and close it. With mark_lost missing equipment moves to lost, equipment the lifecycle doesn't allow to be lost is skipped. With move equipment found elsewhere moves to where it was scanned",
                 "consumes": [
                     "application/json"
                 ],
@@ -1055,23 +900,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "count"
                 ],
-                "summary": "get equipment like serial number",
+                "summary": "apply the results of a count session",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "count session id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "boolean",
-                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
-                        "name": "all",
-                        "in": "query",
-                        "required": true
+                        "description": "move missing equipment to lost",
+                        "name": "mark_lost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "move equipment found elsewhere to where it was scanned",
+                        "name": "move",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1086,10 +937,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "MSG": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Equipment"
-                                            }
+                                            "$ref": "#/definitions/models.CountApplyResult"
                                         }
                                     }
                                 }
@@ -1100,34 +948,26 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "no equipment found",
-                                "Action": "GET /api/v1/equipment/sn-like/{sn}?all=true"
-                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/sn-like/{sn}?all=true"
-                            }
                         }
                     }
                 }
             }
         },
-        "/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}": {
-            "get": {
-                "description": "get equipment by manufacturer id like serial number and device id from the database",
+        "/count/{id}/close": {
+            "post": {
+                "description": "close an open count without changing any equipment, its reconciliation can still be read",
                 "consumes": [
                     "application/json"
                 ],
@@ -1135,95 +975,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "count"
                 ],
-                "summary": "get equipment by manufacturer id like serial number and device id",
+                "summary": "close a count session",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "manufacturer id",
-                        "name": "manufacturer_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "device id",
-                        "name": "device_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
+                        "description": "count session id",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
-                        "name": "all",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.JsonResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "MSG": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Equipment"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.JsonResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "device id does not exist",
-                                "Action": "GET /api/v1/device"
-                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}?all=true"
-                            }
                         }
                     }
                 }
             }
         },
-        "/equipment/sn/{sn}/device/{device_id}": {
+        "/count/{id}/reconciliation": {
             "get": {
-                "description": "get equipment by device id and serial number from the database",
+                "description": "compare what a count scanned with what it expected: expected equipment that was not scanned is missing, codes that match no equipment, equipment of another device type and lost, retired or disposed equipment are unexpected, and equipment scanned away from its recorded location is elsewhere. Equipment is compared as it is now",
                 "consumes": [
                     "application/json"
                 ],
@@ -1231,22 +1026,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "count"
                 ],
-                "summary": "get equipment by device id and serial number",
+                "summary": "get the reconciliation of a count session",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "device id",
-                        "name": "device_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
+                        "description": "count session id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1263,7 +1051,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "MSG": {
-                                            "$ref": "#/definitions/models.Equipment"
+                                            "$ref": "#/definitions/models.CountReconciliation"
                                         }
                                     }
                                 }
@@ -1274,34 +1062,20 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "equipment does not exist",
-                                "Action": "GET /api/v1/equipment"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/sn/{sn}/device/{device_id}"
-                            }
                         }
                     }
                 }
             }
         },
-        "/equipment/sn/{sn}/manufacturer/{manufacturer_id}": {
+        "/count/{id}/scans": {
             "get": {
-                "description": "get equipment by manufacturer id and serial number from the database",
+                "description": "get every distinct code scanned in a count, in the order they were first scanned",
                 "consumes": [
                     "application/json"
                 ],
@@ -1309,22 +1083,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "count"
                 ],
-                "summary": "get equipment by manufacturer id and serial number",
+                "summary": "get the codes scanned in a count session",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "manufacturer id",
-                        "name": "manufacturer_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
+                        "description": "count session id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1341,7 +1108,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "MSG": {
-                                            "$ref": "#/definitions/models.Equipment"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CountScan"
+                                            }
                                         }
                                     }
                                 }
@@ -1352,34 +1122,18 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "manufacturer id is not a number",
-                                "Action": "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}"
-                            }
                         }
                     }
                 }
-            }
-        },
-        "/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}": {
-            "get": {
-                "description": "get equipment by manufacturer id and serial number and device id from the database",
+            },
+            "post": {
+                "description": "record codes scanned in an open count, resolved like POST /scan. Codes that match no equipment are kept and reported as unexpected, scanning equipment again updates where it was scanned. Scanned equipment is marked seen",
                 "consumes": [
                     "application/json"
                 ],
@@ -1387,32 +1141,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "count"
                 ],
-                "summary": "get equipment by manufacturer id and serial number and device id",
+                "summary": "record codes scanned in a count session",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "manufacturer id",
-                        "name": "manufacturer_id",
+                        "description": "count session id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "device id",
-                        "name": "device_id",
-                        "in": "path",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "scanned codes",
+                        "name": "code",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "serial number",
-                        "name": "sn",
-                        "in": "path",
-                        "required": true
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id the codes were scanned at, defaults to the location of the count",
+                        "name": "location",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1427,7 +1184,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "MSG": {
-                                            "$ref": "#/definitions/models.Equipment"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CountScan"
+                                            }
                                         }
                                     }
                                 }
@@ -1438,34 +1198,26 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "device id is not a number",
-                                "Action": "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}"
-                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}"
-                            }
                         }
                     }
                 }
             }
         },
-        "/equipment/{id}/status": {
-            "patch": {
-                "description": "update equipment status in the database",
+        "/device": {
+            "get": {
+                "description": "get device types from the database, filtered by status and name and paged with limit and offset. X-Total-Count holds how many device types match across all pages",
                 "consumes": [
                     "application/json"
                 ],
@@ -1473,86 +1225,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "device"
                 ],
-                "summary": "update equipment status",
+                "summary": "get all device types",
                 "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "equipment id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "enum": [
-                            "\"active\"",
-                            "\"inactive\""
+                            "active",
+                            "inactive"
                         ],
                         "type": "string",
-                        "description": "equipment status",
+                        "description": "device type status",
                         "name": "status",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "equipment status updated to {newStatus}",
-                                "Action": "none"
-                            }
-                        }
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "status must be either active or inactive",
-                                "Action": "PATCH /api/v1/equipment/{id}/status?status={status}"
-                            }
-                        }
+                    {
+                        "type": "string",
+                        "description": "part of the device type name",
+                        "name": "name",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "PATCH /api/v1/equipment/{id}/status?status={status}"
-                            }
-                        }
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "most device types to return, every one when left out",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "device types to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include how much equipment of every device type is active and inactive",
+                        "name": "counts",
+                        "in": "query"
                     }
-                }
-            }
-        },
-        "/manufacturer": {
-            "get": {
-                "description": "get all manufacturers from the database",
-                "consumes": [
-                    "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "manufacturer"
-                ],
-                "summary": "get all manufacturers",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1567,31 +1281,37 @@ const docTemplate = `{
                                         "MSG": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Manufacturer"
+                                                "$ref": "#/definitions/models.DeviceType"
                                             }
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "device types matching the filters"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/manufacturer"
-                            }
                         }
                     }
-                }
+                },
+                "x-order": 1
             },
             "post": {
-                "description": "create manufacturer for the database",
+                "description": "create device type for the database",
                 "consumes": [
                     "application/json"
                 ],
@@ -1599,13 +1319,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "manufacturer"
+                    "device"
                 ],
-                "summary": "create manufacturer",
+                "summary": "create device type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Manufacturer Name",
+                        "description": "Device Name",
                         "name": "name",
                         "in": "query",
                         "required": true
@@ -1616,48 +1336,27 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "manufacturer created with name of {name}",
-                                "Action": "none"
-                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "manufacturer type already exists",
-                                "Action": "GET /api/v1/manufacturer"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "POST /api/v1/manufacturer?name={name}"
-                            }
                         }
                     }
                 },
                 "x-order": 4
             }
         },
-        "/manufacturer/{id}": {
+        "/device/{id}": {
             "get": {
-                "description": "get a manufacturer by ID from the database",
+                "description": "get device type by ID from the database",
                 "consumes": [
                     "application/json"
                 ],
@@ -1665,14 +1364,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "manufacturer"
+                    "device"
                 ],
-                "summary": "get a manufacturer by ID",
+                "summary": "get device type by ID",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Manufacturer ID",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1690,7 +1389,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "MSG": {
-                                            "$ref": "#/definitions/models.Manufacturer"
+                                            "$ref": "#/definitions/models.DeviceType"
                                         }
                                     }
                                 }
@@ -1701,34 +1400,21 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "id is not a number",
-                                "Action": "GET /api/v1/manufacturer/{id}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "GET /api/v1/manufacturer/{id}"
-                            }
                         }
                     }
-                }
+                },
+                "x-order": 2
             }
         },
-        "/manufacturer/{id}/name": {
-            "patch": {
-                "description": "update a manufacturer name by ID from the database",
+        "/device/{id}/attributes": {
+            "get": {
+                "description": "get the attributes equipment of a device type carries",
                 "consumes": [
                     "application/json"
                 ],
@@ -1736,72 +1422,155 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "manufacturer"
+                    "device"
                 ],
-                "summary": "update a manufacturer name by ID",
+                "summary": "get the attribute schema of a device type",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Manufacturer ID",
+                        "description": "device id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AttributeDefinition"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "add an attribute to the schema of a device type. Required attributes are enforced when equipment is created or imported and whenever the attributes of equipment are written, existing equipment without a value keeps it until then",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device"
+                ],
+                "summary": "add an attribute to a device type",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
+                        "maxLength": 64,
                         "type": "string",
-                        "description": "Manufacturer Name",
+                        "description": "attribute name, lowercase letters, digits and underscores",
                         "name": "name",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "string",
+                            "int",
+                            "float",
+                            "bool",
+                            "enum"
+                        ],
+                        "type": "string",
+                        "description": "attribute type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "whether equipment needs a value",
+                        "name": "required",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated allowed values of an enum attribute",
+                        "name": "enum",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "regular expression string values have to match",
+                        "name": "pattern",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "manufacturer with id: {id} updated name to {name}",
-                                "Action": "none"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.AttributeDefinition"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "name cannot be empty",
-                                "Action": "PATCH /api/v1/manufacturer/{id}/name?name={name}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "could not connect to database",
-                                "Action": "PATCH /api/v1/manufacturer/{id}/name?name={name}"
-                            }
                         }
                     }
                 }
             }
         },
-        "/manufacturer/{id}/status": {
-            "patch": {
-                "description": "update a manufacturer status by ID from the database",
+        "/device/{id}/attributes/{name}": {
+            "delete": {
+                "description": "remove an attribute from the schema of a device type along with every value equipment has for it",
                 "consumes": [
                     "application/json"
                 ],
@@ -1809,27 +1578,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "manufacturer"
+                    "device"
                 ],
-                "summary": "update a manufacturer status by ID",
+                "summary": "remove an attribute from a device type",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Manufacturer ID",
+                        "description": "device id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "active",
-                            "inactive"
-                        ],
                         "type": "string",
-                        "description": "Manufacturer Status",
-                        "name": "status",
-                        "in": "query",
+                        "description": "attribute name",
+                        "name": "name",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -1838,122 +1603,7232 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "SUCCESS",
-                                "MSG": "manufacturer with id: {id} updated status to {status}",
-                                "Action": "none"
-                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "status must be either active or inactive",
-                                "Action": "PATCH /api/v1/manufacturer/{id}/status?status={status}"
-                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.JsonResponse"
-                        },
-                        "examples": {
-                            "application/json": {
-                                "Status": "ERROR",
-                                "MSG": "cannot connect to database",
-                                "Action": "PATCH /api/v1/manufacturer/{id}/status?status={status}"
-                            }
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.DeviceType": {
-            "description": "DeviceType is a struct for device type",
-            "type": "object",
-            "properties": {
-                "id": {
-                    "description": "ID is an int32 for device type id",
-                    "type": "integer",
-                    "example": 1
-                },
-                "name": {
-                    "description": "Name is a string for device type name",
-                    "type": "string",
-                    "example": "computer"
-                },
-                "status": {
-                    "description": "Status is a string for device type status either active or inactive",
-                    "type": "string",
-                    "example": "active"
-                }
-            }
-        },
-        "models.Equipment": {
-            "description": "Equipment is a struct for equipment",
-            "type": "object",
-            "properties": {
-                "auto_id": {
-                    "description": "AutoID is an int32 for equipment auto id",
-                    "type": "integer",
-                    "example": 1
-                },
-                "device_type_id": {
-                    "description": "DeviceTypeID is an int32 for device id",
-                    "type": "integer",
-                    "example": 1
-                },
-                "manufacturer_id": {
-                    "description": "ManufacturerID is an int32 for manufacturer id",
-                    "type": "integer",
-                    "example": 1
-                },
-                "serial_number": {
-                    "description": "SerialNumber is a string for equipment serial number",
-                    "type": "string",
-                    "example": "SN-0123456abcdef"
-                },
-                "status": {
-                    "description": "Status is a string for equipment status either active or inactive",
-                    "type": "string",
-                    "example": "active"
-                }
-            }
         },
-        "models.JsonResponse": {
-            "description": "JsonResponse is a struct for response JSON message",
-            "type": "object",
-            "properties": {
-                "Status": {
-                    "description": "Status is a string for response status",
-                    "type": "string",
-                    "example": "SUCCESS"
-                },
-                "MSG": {
-                    "description": "Message is an interface for response message can be string, models.DeviceType, models.Manufacturer, models.Equipment"
-                },
-                "Action": {
-                    "description": "Action is a string for response action",
-                    "type": "string",
-                    "example": "none"
+        "/device/{id}/depreciation": {
+            "get": {
+                "description": "get how equipment of a device type loses value, device types without a schedule keep their cost",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device"
+                ],
+                "summary": "get the depreciation schedule of a device type",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.DepreciationSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
                 }
-            }
-        },
-        "models.Manufacturer": {
-            "description": "Manufacturer is a struct for manufacturer",
-            "type": "object",
-            "properties": {
-                "id": {
+            },
+            "delete": {
+                "description": "delete the depreciation schedule of a device type, its equipment keeps its cost from then on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device"
+                ],
+                "summary": "delete the depreciation schedule of a device type",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "change how equipment of a device type loses value, only the given fields change. method and life_months are needed when the device type has no schedule yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device"
+                ],
+                "summary": "update the depreciation schedule of a device type",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "straight_line",
+                            "declining_balance"
+                        ],
+                        "type": "string",
+                        "description": "how the value goes down",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 600,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "months the equipment is used",
+                        "name": "life_months",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "share of the cost left at the end of its life",
+                        "name": "salvage_percent",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "share of the remaining value lost every year, needed by declining_balance",
+                        "name": "rate_percent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.DepreciationSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/device/{id}/name": {
+            "patch": {
+                "description": "update device type by name ID from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device"
+                ],
+                "summary": "update device type by name ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device Name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                },
+                "x-order": 3
+            }
+        },
+        "/device/{id}/status": {
+            "patch": {
+                "description": "update device type by status ID from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device"
+                ],
+                "summary": "update device type by status ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "inactive"
+                        ],
+                        "type": "string",
+                        "description": "Device Status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                },
+                "x-order": 3
+            }
+        },
+        "/equipment": {
+            "get": {
+                "description": "get all equipment with manufacturers from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get all equipments with",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
+                        "name": "all",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "only equipment carrying all these tags, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create equipment in the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "create equipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "product model id, has to match the manufacturer and device",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "attribute value, for example attr.imei=356938035643809, every required attribute of the device type has to be given",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update equipment in the database. Moving equipment to another device type replaces its attribute values with the attr.{name} values given, every required attribute of the new device type has to be among them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "update equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "attribute value for the new device type, only used when device_id changes",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/device/{device_id}/manufacturer/{manufacturer_id}": {
+            "get": {
+                "description": "get equipment by device id and manufacturer id from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by device id and manufacturer id",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
+                        "name": "all",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/device/{id}": {
+            "get": {
+                "description": "get equipment by device id from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by device id",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
+                        "name": "all",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/export": {
+            "get": {
+                "description": "export every equipment matching the search filters as csv, with the purchase, warranty and book value columns and a column per attribute of the device types it can be",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "export equipment as csv",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id, only equipment directly at it",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "product model id",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "inactive"
+                        ],
+                        "type": "string",
+                        "description": "equipment status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "received",
+                            "in_stock",
+                            "deployed",
+                            "in_repair",
+                            "lost",
+                            "retired",
+                            "disposed"
+                        ],
+                        "type": "string",
+                        "description": "lifecycle state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "part of the serial number, as typed or in canonical form",
+                        "name": "sn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "attribute value, for example attr.imei=356938035643809",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "tags equipment has to carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/fuzzy": {
+            "get": {
+                "description": "find equipment whose serial number is close to sn, allowing typos, missing or extra characters and swapped neighbours. Serial numbers are compared in canonical form and ranked by edit distance, closest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "fuzzy search equipment by serial number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "serial number as typed",
+                        "name": "sn",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 5,
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "largest edit distance to match, defaults to 1 to 3 depending on the length of sn",
+                        "name": "max_distance",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "most matches to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set to true to include inactive equipment",
+                        "name": "all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out. distance and score are always kept",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.EquipmentMatch"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/id": {
+            "get": {
+                "description": "get equipment by auto_id from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by auto ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "auto_id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/import": {
+            "post": {
+                "description": "create equipment from a csv with a header row and the columns serial_number, device_type_id, manufacturer_id and optionally product_model_id, purchase_date, vendor, purchase_order, cost, warranty_start and warranty_end, and an attr.{name} column per attribute, which every required attribute of the device type of a row needs. Every row is checked like POST /equipment, including the serial number rules, and nothing is created unless every row is valid",
+                "consumes": [
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "import equipment from csv",
+                "parameters": [
+                    {
+                        "description": "csv of the equipment to create",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ImportRowError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/location/{id}": {
+            "get": {
+                "description": "get equipment at a location or any location below it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment under a location",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
+                        "name": "all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/manufacturer/{id}": {
+            "get": {
+                "description": "get equipment by manufacturer id from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by manufacturer id",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
+                        "name": "all",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/search": {
+            "get": {
+                "description": "search equipment by any combination of filters, attribute values are filtered with attr.{name}={value}, normalized by the attribute definition so attr.weight=1.0 matches a float attribute stored as 1. Results are ordered by id and paged with limit and after_id, X-Next-After-Id holds the after_id of the next page while there may be more",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "search equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id, only equipment directly at it",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "product model id",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "inactive"
+                        ],
+                        "type": "string",
+                        "description": "equipment status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "received",
+                            "in_stock",
+                            "deployed",
+                            "in_repair",
+                            "lost",
+                            "retired",
+                            "disposed"
+                        ],
+                        "type": "string",
+                        "description": "lifecycle state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "part of the serial number, as typed or in canonical form",
+                        "name": "sn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "attribute value, for example attr.imei=356938035643809",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "tags equipment has to carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1000,
+                        "description": "equipment per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "only equipment with a greater id, the X-Next-After-Id of the previous page",
+                        "name": "after_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Next-After-Id": {
+                                "type": "integer",
+                                "description": "after_id of the next page, left out on the last page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/sn": {
+            "get": {
+                "description": "get equipment by serial number from the database, the serial number is matched in its canonical form so case and separators don't matter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by serial number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update equipment serial number in the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "update equipment serial number",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/sn-like/{sn}": {
+            "get": {
+                "description": "get equipment like serial number from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment like serial number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
+                        "name": "all",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}": {
+            "get": {
+                "description": "get equipment by manufacturer id like serial number and device id from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by manufacturer id like serial number and device id",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set to true to get all equipment, otherwise only active equipment is returned",
+                        "name": "all",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/sn/{sn}/device/{device_id}": {
+            "get": {
+                "description": "get equipment by device id and serial number from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by device id and serial number",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/sn/{sn}/manufacturer/{manufacturer_id}": {
+            "get": {
+                "description": "get equipment by manufacturer id and serial number from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by manufacturer id and serial number",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}": {
+            "get": {
+                "description": "get equipment by manufacturer id and serial number and device id from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "get equipment by manufacturer id and serial number and device id",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/tags": {
+            "post": {
+                "description": "add every tag to every equipment, tags that don't exist yet are created. Nothing changes unless every equipment exists",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "tag many equipment",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment ids, repeated or comma separated",
+                        "name": "equipment",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "tag names, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "take every tag off every equipment. Nothing changes unless every equipment and tag exists",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "untag many equipment",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment ids, repeated or comma separated",
+                        "name": "equipment",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "tag names, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/attachments": {
+            "post": {
+                "description": "upload a file like an invoice, a photo of damage or a warranty certificate for equipment. The file is sent in the file field of a multipart/form-data body, its type is sniffed from the contents and must be one of the allowed types",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "attach a file to equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "invoice",
+                            "photo",
+                            "warranty",
+                            "other"
+                        ],
+                        "type": "string",
+                        "default": "other",
+                        "description": "what the file is",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "the file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Attachment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/attributes": {
+            "patch": {
+                "description": "set attribute values of equipment from a JSON object of name to value, a null value clears the attribute and attributes left out keep their value. The result is validated against the device type's schema",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "set attribute values of equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "attribute values by name",
+                        "name": "attributes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/checkin": {
+            "post": {
+                "description": "close the open assignment of equipment, deployed equipment moves back to in_stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assignment"
+                ],
+                "summary": "check in equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "check in note",
+                        "name": "note",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/checkout": {
+            "post": {
+                "description": "check out equipment to an assignee and move it to deployed, inactive equipment or equipment with an inactive device or manufacturer is rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assignment"
+                ],
+                "summary": "check out equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "assignee id",
+                        "name": "assignee",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "check out note",
+                        "name": "note",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/lifecycle": {
+            "patch": {
+                "description": "move equipment to another lifecycle state, moves the lifecycle does not allow are rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lifecycle"
+                ],
+                "summary": "move equipment to another lifecycle state",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "received",
+                            "in_stock",
+                            "deployed",
+                            "in_repair",
+                            "lost",
+                            "retired",
+                            "disposed"
+                        ],
+                        "type": "string",
+                        "description": "lifecycle state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "why the equipment moved",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/location": {
+            "patch": {
+                "description": "move equipment to a location and record the move",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "move equipment to a location",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id",
+                        "name": "location",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "why the equipment moved",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/maintenance": {
+            "post": {
+                "description": "open a maintenance ticket for equipment and move it to in_repair. Equipment can only have one open ticket",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "open a maintenance ticket for equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "what is wrong with the equipment",
+                        "name": "issue",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "who is doing the repair",
+                        "name": "vendor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day the ticket was opened like 2024-05-01, defaults to today",
+                        "name": "opened_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "what the repair costs, like 149.00",
+                        "name": "cost",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.MaintenanceTicket"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/product-model": {
+            "patch": {
+                "description": "set the product model of equipment, the model has to match the equipment's manufacturer and device type. Leave model out to clear it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product model"
+                ],
+                "summary": "set the product model of equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "product model id",
+                        "name": "model",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/purchase": {
+            "patch": {
+                "description": "set the purchase and warranty information of equipment. Only the fields given are changed, give a field with an empty value to clear it. Dates are written like 2024-03-18",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "set the purchase and warranty information of equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "date the equipment was bought",
+                        "name": "purchase_date",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "who the equipment was bought from",
+                        "name": "vendor",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "description": "purchase order number",
+                        "name": "purchase_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "what the equipment cost, like 1299.99",
+                        "name": "cost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day of the warranty",
+                        "name": "warranty_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day of the warranty",
+                        "name": "warranty_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Equipment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/status": {
+            "patch": {
+                "description": "update equipment status in the database, active moves the equipment to in_stock and inactive to retired. Moves the lifecycle does not allow are rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "update equipment status",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "\"active\"",
+                            "\"inactive\""
+                        ],
+                        "type": "string",
+                        "description": "equipment status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/tags": {
+            "post": {
+                "description": "add tags to equipment, tags that don't exist yet are created. Tags the equipment already has are left alone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "tag equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "tag names, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{id}/tags/{tag}": {
+            "delete": {
+                "description": "take a tag off equipment, the tag itself is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "untag equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag name",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "lists each dependency with its status and how long the check took",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "detailed health report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.HealthReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.HealthReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/label/equipment/{id}": {
+            "get": {
+                "description": "render a Code128 barcode or a QR code of the serial number, auto_id or lookup url of equipment as png or svg, or a full label as ZPL for 2 x 1 inch stock on a 203 dpi thermal printer",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml",
+                    "application/zpl"
+                ],
+                "tags": [
+                    "label"
+                ],
+                "summary": "get the barcode or QR code of equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "code128",
+                            "qr"
+                        ],
+                        "type": "string",
+                        "default": "code128",
+                        "description": "kind of code",
+                        "name": "symbology",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "sn",
+                            "id",
+                            "url"
+                        ],
+                        "type": "string",
+                        "default": "sn",
+                        "description": "what the code holds",
+                        "name": "content",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg",
+                            "zpl"
+                        ],
+                        "type": "string",
+                        "default": "png",
+                        "description": "output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 4,
+                        "description": "pixels per module",
+                        "name": "scale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/label/sheet": {
+            "get": {
+                "description": "render labels for the equipment matching the search filters, as a PDF laid out for sheets of label stock or as ZPL for 2 x 1 inch stock on a 203 dpi thermal printer. Each label has the code and the serial number, manufacturer, device type and id of the equipment. The letter layout fits Avery 5160 stock and the a4 layout Avery L7160 stock. At most 1000 equipment can match the filters",
+                "produces": [
+                    "application/pdf",
+                    "application/zpl"
+                ],
+                "tags": [
+                    "label"
+                ],
+                "summary": "get printable labels for equipment",
+                "parameters": [
+                    {
+                        "enum": [
+                            "code128",
+                            "qr"
+                        ],
+                        "type": "string",
+                        "default": "code128",
+                        "description": "kind of code",
+                        "name": "symbology",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "sn",
+                            "id",
+                            "url"
+                        ],
+                        "type": "string",
+                        "default": "sn",
+                        "description": "what the code holds",
+                        "name": "content",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pdf",
+                            "zpl"
+                        ],
+                        "type": "string",
+                        "default": "pdf",
+                        "description": "output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "letter",
+                            "a4"
+                        ],
+                        "type": "string",
+                        "default": "letter",
+                        "description": "label stock the pdf is laid out for",
+                        "name": "layout",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "number of labels already used on the first sheet",
+                        "name": "skip",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id, only equipment directly at it",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "product model id",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "inactive"
+                        ],
+                        "type": "string",
+                        "description": "equipment status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "received",
+                            "in_stock",
+                            "deployed",
+                            "in_repair",
+                            "lost",
+                            "retired",
+                            "disposed"
+                        ],
+                        "type": "string",
+                        "description": "lifecycle state",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "part of the serial number, as typed or in canonical form",
+                        "name": "sn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "attribute value, for example attr.imei=356938035643809",
+                        "name": "attr.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "tags equipment has to carry, repeated or comma separated",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lifecycle": {
+            "get": {
+                "description": "get every lifecycle state and the transitions allowed between them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lifecycle"
+                ],
+                "summary": "get the equipment lifecycle",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Lifecycle"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/lifecycle/equipment": {
+            "get": {
+                "description": "get equipment in a lifecycle state from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lifecycle"
+                ],
+                "summary": "get equipment by lifecycle state",
+                "parameters": [
+                    {
+                        "enum": [
+                            "received",
+                            "in_stock",
+                            "deployed",
+                            "in_repair",
+                            "lost",
+                            "retired",
+                            "disposed"
+                        ],
+                        "type": "string",
+                        "description": "lifecycle state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "device_type",
+                                "manufacturer"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "related resources to embed, repeated or comma separated",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "equipment fields to keep, repeated or comma separated, every field when left out",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Equipment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lifecycle/equipment/{id}": {
+            "get": {
+                "description": "get every lifecycle transition of equipment, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lifecycle"
+                ],
+                "summary": "get the lifecycle transitions of equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LifecycleTransition"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location": {
+            "get": {
+                "description": "get every location, parent_id links them into a tree",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "get all locations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Location"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a location, sites have no parent, buildings go in sites, rooms in buildings, racks in rooms and shelves in rooms or racks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "create location",
+                "parameters": [
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "location name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "site",
+                            "building",
+                            "room",
+                            "rack",
+                            "shelf"
+                        ],
+                        "type": "string",
+                        "description": "location kind",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "parent location id, required for everything but sites",
+                        "name": "parent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Location"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/counts": {
+            "get": {
+                "description": "get the equipment at each location directly and rolled up with every location below it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "get equipment counts per location",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LocationCount"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/equipment/{id}": {
+            "get": {
+                "description": "get every location move of equipment, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "get the location history of equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LocationMove"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/location/{id}": {
+            "get": {
+                "description": "get a location by ID from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "get a location by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Location"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance": {
+            "get": {
+                "description": "get all maintenance tickets, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "get all maintenance tickets",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "set to true to only get open tickets",
+                        "name": "open",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.MaintenanceTicket"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/equipment/{id}": {
+            "get": {
+                "description": "get every maintenance ticket of equipment, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "get the maintenance history of equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.MaintenanceTicket"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/report": {
+            "get": {
+                "description": "get the number of maintenance tickets opened between from and to per manufacturer or device type, with the number of equipment each has, what the repairs cost and how long they took. The most repaired come first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "get how often equipment is repaired",
+                "parameters": [
+                    {
+                        "enum": [
+                            "manufacturer",
+                            "device"
+                        ],
+                        "type": "string",
+                        "default": "manufacturer",
+                        "description": "what to group the tickets by",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day tickets were opened on like 2024-01-01, defaults to a year before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day tickets were opened on like 2024-12-31, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.MaintenanceReport"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/{id}": {
+            "get": {
+                "description": "get maintenance ticket by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "get maintenance ticket by id",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "maintenance ticket id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.MaintenanceTicket"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update the issue, vendor or cost of a maintenance ticket. Only the fields given are changed, give cost with an empty value to clear it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "update a maintenance ticket",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "maintenance ticket id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "what is wrong with the equipment",
+                        "name": "issue",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "who is doing the repair",
+                        "name": "vendor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "what the repair costs, like 149.00",
+                        "name": "cost",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.MaintenanceTicket"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/{id}/close": {
+            "post": {
+                "description": "close a maintenance ticket. Equipment still in_repair moves back to the state it was in before the ticket opened when it was repaired, and is retired when it was replaced or unrepairable",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "maintenance"
+                ],
+                "summary": "close a maintenance ticket",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "maintenance ticket id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "repaired",
+                            "replaced",
+                            "unrepairable"
+                        ],
+                        "type": "string",
+                        "description": "how the ticket ended",
+                        "name": "outcome",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "day the ticket was closed like 2024-05-09, defaults to today",
+                        "name": "closed_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "what the repair cost, like 149.00, leave out to keep the cost on the ticket",
+                        "name": "cost",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.MaintenanceTicket"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/manufacturer": {
+            "get": {
+                "description": "get manufacturers from the database, filtered by status and name and paged with limit and offset. X-Total-Count holds how many manufacturers match across all pages",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "manufacturer"
+                ],
+                "summary": "get all manufacturers",
+                "parameters": [
+                    {
+                        "enum": [
+                            "active",
+                            "inactive"
+                        ],
+                        "type": "string",
+                        "description": "manufacturer status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "part of the manufacturer name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "most manufacturers to return, every one when left out",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "manufacturers to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include how much equipment of every manufacturer is active and inactive",
+                        "name": "counts",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Manufacturer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "manufacturers matching the filters"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create manufacturer for the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "manufacturer"
+                ],
+                "summary": "create manufacturer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manufacturer Name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                },
+                "x-order": 4
+            }
+        },
+        "/manufacturer/{id}": {
+            "get": {
+                "description": "get a manufacturer by ID from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "manufacturer"
+                ],
+                "summary": "get a manufacturer by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Manufacturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Manufacturer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/manufacturer/{id}/attachments": {
+            "post": {
+                "description": "upload a file like a warranty certificate or a support contract for a manufacturer. The file is sent in the file field of a multipart/form-data body, its type is sniffed from the contents and must be one of the allowed types",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "attach a file to a manufacturer",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "invoice",
+                            "photo",
+                            "warranty",
+                            "other"
+                        ],
+                        "type": "string",
+                        "default": "other",
+                        "description": "what the file is",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "the file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Attachment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/manufacturer/{id}/name": {
+            "patch": {
+                "description": "update a manufacturer name by ID from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "manufacturer"
+                ],
+                "summary": "update a manufacturer name by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Manufacturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Manufacturer Name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/manufacturer/{id}/serial-normalization": {
+            "get": {
+                "description": "get how serial numbers of a manufacturer are made canonical for lookups and uniqueness checks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "manufacturer"
+                ],
+                "summary": "get serial number normalization of a manufacturer",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.SerialNormalization"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "change how serial numbers of a manufacturer are made canonical, only the given fields change. The canonical serial number of every equipment of the manufacturer is recomputed and the change is rejected with the ids of the equipment when two serial numbers would become the same",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "manufacturer"
+                ],
+                "summary": "update serial number normalization of a manufacturer",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "remove leading and trailing whitespace",
+                        "name": "trim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "upper case letters",
+                        "name": "case_fold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "characters to remove from anywhere in the serial number",
+                        "name": "separators",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.SerialNormalization"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/manufacturer/{id}/status": {
+            "patch": {
+                "description": "update a manufacturer status by ID from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "manufacturer"
+                ],
+                "summary": "update a manufacturer status by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Manufacturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "inactive"
+                        ],
+                        "type": "string",
+                        "description": "Manufacturer Status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Manufacturer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product-model": {
+            "get": {
+                "description": "get all product models, optionally only those of a manufacturer or device type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product model"
+                ],
+                "summary": "get all product models",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ProductModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a product model of an active manufacturer and device type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product model"
+                ],
+                "summary": "create product model",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "model number, unique per manufacturer",
+                        "name": "model_number",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "marketing name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "specifications as a JSON object",
+                        "name": "specs",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.ProductModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product-model/{id}": {
+            "get": {
+                "description": "get a product model by ID from the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product model"
+                ],
+                "summary": "get a product model by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "product model id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.ProductModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete a product model no equipment references",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product model"
+                ],
+                "summary": "delete product model",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "product model id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update the model number, name or specs of a product model, parameters left out keep their value. The manufacturer and device type cannot change since equipment is matched against them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product model"
+                ],
+                "summary": "update product model",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "product model id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "model number, unique per manufacturer",
+                        "name": "model_number",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "description": "marketing name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "specifications as a JSON object",
+                        "name": "specs",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.ProductModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scan": {
+            "post": {
+                "description": "resolve the raw string a barcode reader sends, a serial number, an auto_id or the url of a QR label, to the equipment with its device type and manufacturer, and record when it was last seen. An action can be performed in the same call: checkin closes the open assignment, seen marks the equipment seen at location and moves it there, status moves it to the lifecycle state in state",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scan"
+                ],
+                "summary": "resolve a scanned code to equipment",
+                "parameters": [
+                    {
+                        "maxLength": 2048,
+                        "type": "string",
+                        "description": "the scanned code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "checkin",
+                            "seen",
+                            "status"
+                        ],
+                        "type": "string",
+                        "description": "action to perform on the equipment",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "location id the equipment is seen at, required by seen",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "received",
+                            "in_stock",
+                            "deployed",
+                            "in_repair",
+                            "lost",
+                            "retired",
+                            "disposed"
+                        ],
+                        "type": "string",
+                        "description": "lifecycle state to move to, required by status",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "description": "check in note or reason recorded for the move",
+                        "name": "note",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.ScanResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/serial-rule": {
+            "get": {
+                "description": "get every serial number rule, a rule without a manufacturer or device applies to all of them. A serial number is only checked against the most specific rules in scope, rules for its manufacturer and device override rules for its manufacturer, which override rules for its device, which override rules for everything",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "serial rule"
+                ],
+                "summary": "get all serial number rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SerialNumberRule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a serial number rule, equipment created or updated afterwards has to follow it unless a more specific rule is in scope, existing serial numbers are not checked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "serial rule"
+                ],
+                "summary": "create serial number rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "rule name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "only apply to this manufacturer",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "only apply to this device",
+                        "name": "device",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "regular expression the whole serial number has to match",
+                        "name": "pattern",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "shortest serial number allowed",
+                        "name": "min_length",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 68,
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "longest serial number allowed",
+                        "name": "max_length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "prefix serial numbers have to start with",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "luhn"
+                        ],
+                        "type": "string",
+                        "description": "check digit scheme, computed over the serial number after the prefix",
+                        "name": "checksum",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.SerialNumberRule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/serial-rule/validate": {
+            "get": {
+                "description": "check a serial number against the most specific rules for a manufacturer and device without creating anything, reports every rule it fails and the canonical form it would be stored with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "serial rule"
+                ],
+                "summary": "validate serial number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "serial number",
+                        "name": "sn",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "manufacturer id",
+                        "name": "manufacturer",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "device id",
+                        "name": "device",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.SerialNumberValidation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/serial-rule/{id}": {
+            "delete": {
+                "description": "delete a serial number rule, serial numbers no longer have to follow it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "serial rule"
+                ],
+                "summary": "delete serial number rule",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "rule id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "get equipment totals by status, device type, manufacturer and device type and manufacturer pair, and how much equipment was added in every day, week or month from from to to. Periods are whole, so growth starts on the first day of the period from falls in. Equipment that existed before creation times were recorded counts as added on the day recording started",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "get inventory statistics",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "length of the growth periods",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "first day of growth, defaults to a year before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "last day of growth, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.InventoryStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag": {
+            "get": {
+                "description": "get all tags with the number of equipment carrying each, by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "get all tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.TagUsage"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a tag, tags are also created when first added to equipment. Names are lower cased",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "create tag",
+                "parameters": [
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "description": "tag name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag/equipment/{id}": {
+            "get": {
+                "description": "get the tags of equipment, by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "get the tags of equipment",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "equipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Tag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag/{id}": {
+            "delete": {
+                "description": "delete a tag and take it off every equipment carrying it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "delete tag",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "tag id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "rename a tag, the equipment carrying it keeps it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "rename tag",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "tag id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "description": "new tag name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "$ref": "#/definitions/models.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/valuation": {
+            "get": {
+                "description": "get what the equipment bought by as_of cost and was worth on as_of after depreciation, per device type, manufacturer or location. Disposed equipment and equipment without a purchase date are left out, equipment without a cost is counted as unpriced. Equipment is grouped by its current location",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "valuation"
+                ],
+                "summary": "get the book value of equipment",
+                "parameters": [
+                    {
+                        "enum": [
+                            "device",
+                            "manufacturer",
+                            "location"
+                        ],
+                        "type": "string",
+                        "default": "device",
+                        "description": "what to group the equipment by",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day to value the equipment on like 2024-12-31, defaults to today",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ValuationReport"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/warranty/expiring": {
+            "get": {
+                "description": "get equipment whose warranty ends between today and days from now, grouped by manufacturer with the soonest expiring equipment first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warranty"
+                ],
+                "summary": "get equipment whose warranty expires soon",
+                "parameters": [
+                    {
+                        "maximum": 3650,
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "number of days ahead to look",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "set to true to include inactive equipment",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.JsonResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "MSG": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.WarrantyExpiryGroup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.JsonResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Assignee": {
+            "description": "Assignee is a person or team equipment can be checked out to",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email is a string for the contact email, may be empty",
+                    "type": "string",
+                    "example": "jane@example.com"
+                },
+                "id": {
+                    "description": "ID is an int32 for assignee id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "description": "Kind is either person or team",
+                    "type": "string",
+                    "example": "person"
+                },
+                "name": {
+                    "description": "Name is a string for the person or team name",
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
+                "status": {
+                    "description": "Status is a string for assignee status either active or inactive",
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "models.Assignment": {
+            "description": "Assignment is a check out of equipment to an assignee",
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "description": "AssigneeID is the id of the assignee holding the equipment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "checked_in_at": {
+                    "description": "CheckedInAt is when the equipment was checked back in, null while it is held",
+                    "type": "string",
+                    "example": "2024-06-01T15:04:05Z"
+                },
+                "checked_out_at": {
+                    "description": "CheckedOutAt is when the equipment was checked out",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "checkin_note": {
+                    "description": "CheckinNote is a string for the check in note",
+                    "type": "string",
+                    "example": "returned with charger"
+                },
+                "equipment_id": {
+                    "description": "EquipmentID is the auto_id of the checked out equipment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "ID is an int32 for assignment id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "description": "Note is a string for the check out note",
+                    "type": "string",
+                    "example": "loaner while laptop is repaired"
+                }
+            }
+        },
+        "models.Attachment": {
+            "description": "Attachment is a file stored with equipment or a manufacturer, like an invoice or a warranty certificate",
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "description": "ContentType is the MIME type sniffed from the file",
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "description": "CreatedAt is when the file was uploaded",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "equipment_id": {
+                    "description": "EquipmentID is the auto_id of the equipment the file belongs to, null for manufacturer files",
+                    "type": "integer",
+                    "example": 1
+                },
+                "filename": {
+                    "description": "Filename is the name the file was uploaded with",
+                    "type": "string",
+                    "example": "invoice-2024-118.pdf"
+                },
+                "id": {
+                    "description": "ID is an int32 for attachment id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "description": "Kind is what the file is",
+                    "type": "string",
+                    "enum": [
+                        "invoice",
+                        "photo",
+                        "warranty",
+                        "other"
+                    ],
+                    "example": "invoice"
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID is the id of the manufacturer the file belongs to, null for equipment files",
+                    "type": "integer",
+                    "example": 2
+                },
+                "sha256": {
+                    "description": "Sha256 is the hex encoded SHA-256 checksum of the file",
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "size": {
+                    "description": "Size is the size of the file in bytes",
+                    "type": "integer",
+                    "example": 48213
+                }
+            }
+        },
+        "models.AttributeDefinition": {
+            "description": "AttributeDefinition is an attribute a device type declares for its equipment",
+            "type": "object",
+            "properties": {
+                "device_type_id": {
+                    "description": "DeviceTypeID is the device type declaring the attribute",
+                    "type": "integer",
+                    "example": 2
+                },
+                "enum": {
+                    "description": "Enum lists the allowed values of an enum attribute",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "64GB",
+                        "128GB"
+                    ]
+                },
+                "id": {
+                    "description": "ID is an int32 for attribute id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name is the attribute name, lowercase letters, digits and underscores",
+                    "type": "string",
+                    "example": "imei"
+                },
+                "pattern": {
+                    "description": "Pattern is a regular expression string values have to match",
+                    "type": "string",
+                    "example": "^[0-9]{15}$"
+                },
+                "required": {
+                    "description": "Required is whether every equipment of the device type needs a value",
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "description": "Type is one of string, int, float, bool or enum",
+                    "type": "string",
+                    "example": "string"
+                }
+            }
+        },
+        "models.CountApplyResult": {
+            "description": "CountApplyResult is what applying a count changed",
+            "type": "object",
+            "properties": {
+                "marked_lost": {
+                    "description": "MarkedLost is the auto_ids of missing equipment moved to lost",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        9
+                    ]
+                },
+                "moved": {
+                    "description": "Moved is the auto_ids of equipment moved to where they were scanned",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12
+                    ]
+                },
+                "skipped": {
+                    "description": "Skipped is equipment that could not be changed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CountSkip"
+                    }
+                }
+            }
+        },
+        "models.CountItem": {
+            "description": "CountItem is something scanned that the count did not expect where it was found",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the scanned code",
+                    "type": "string",
+                    "example": "SN-123456"
+                },
+                "equipment": {
+                    "description": "Equipment is what the code resolved to, null when it matches no equipment",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Equipment"
+                        }
+                    ]
+                },
+                "reason": {
+                    "description": "Reason is why the item was not expected",
+                    "type": "string",
+                    "enum": [
+                        "unknown_code",
+                        "other_device_type",
+                        "lifecycle_state",
+                        "other_location"
+                    ],
+                    "example": "other_location"
+                },
+                "scanned_location_id": {
+                    "description": "ScannedLocationID is where the code was scanned, null when unknown",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.CountReconciliation": {
+            "description": "CountReconciliation compares what a count found with what was expected",
+            "type": "object",
+            "properties": {
+                "elsewhere": {
+                    "description": "Elsewhere is equipment scanned somewhere other than its recorded location",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CountItem"
+                    }
+                },
+                "expected": {
+                    "description": "Expected is the number of equipment the count expected to find",
+                    "type": "integer",
+                    "example": 120
+                },
+                "found": {
+                    "description": "Found is the number of equipment scanned where they were expected",
+                    "type": "integer",
+                    "example": 110
+                },
+                "missing": {
+                    "description": "Missing is expected equipment that was not scanned",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Equipment"
+                    }
+                },
+                "session": {
+                    "$ref": "#/definitions/models.CountSession"
+                },
+                "unexpected": {
+                    "description": "Unexpected is scanned codes that match no equipment or equipment the count doesn't cover",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CountItem"
+                    }
+                }
+            }
+        },
+        "models.CountScan": {
+            "description": "CountScan is a code scanned during a count",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the scanned code",
+                    "type": "string",
+                    "example": "SN-123456"
+                },
+                "equipment_id": {
+                    "description": "EquipmentID is the equipment the code resolved to, null when it matches no equipment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "ID is an int32 for count scan id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "location_id": {
+                    "description": "LocationID is where the code was scanned, null when the count has no location and none was given",
+                    "type": "integer",
+                    "example": 4
+                },
+                "scanned_at": {
+                    "description": "ScannedAt is when the code was last scanned",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "session_id": {
+                    "description": "SessionID is the count the code was scanned in",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CountSession": {
+            "description": "CountSession is a physical inventory count of a location, a device type or both",
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "description": "AppliedAt is when the results were applied to the equipment, null when they were not",
+                    "type": "string",
+                    "example": "2024-05-02T11:00:00Z"
+                },
+                "closed_at": {
+                    "description": "ClosedAt is when the count was closed, null while it is open",
+                    "type": "string",
+                    "example": "2024-05-02T11:00:00Z"
+                },
+                "created_at": {
+                    "description": "CreatedAt is when the count was opened",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "device_type_id": {
+                    "description": "DeviceTypeID is the device type being counted, null to count every device type",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "ID is an int32 for count session id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "location_id": {
+                    "description": "LocationID is the location being counted, sub locations included, null to count every location",
+                    "type": "integer",
+                    "example": 4
+                },
+                "name": {
+                    "description": "Name describes the count",
+                    "type": "string",
+                    "example": "HQ spring audit"
+                },
+                "scans": {
+                    "description": "Scans is the number of distinct codes scanned",
+                    "type": "integer",
+                    "example": 112
+                },
+                "status": {
+                    "description": "Status is open while codes are being scanned",
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "closed"
+                    ],
+                    "example": "open"
+                }
+            }
+        },
+        "models.CountSkip": {
+            "description": "CountSkip is equipment a count could not change",
+            "type": "object",
+            "properties": {
+                "equipment_id": {
+                    "description": "EquipmentID is the auto_id of the equipment",
+                    "type": "integer",
+                    "example": 7
+                },
+                "reason": {
+                    "description": "Reason is why it was skipped",
+                    "type": "string",
+                    "example": "illegal lifecycle transition: in_repair can only move to [in_stock deployed retired]"
+                }
+            }
+        },
+        "models.DepreciationSchedule": {
+            "description": "DepreciationSchedule is how equipment of a device type loses value",
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "Default is true when the device type has no schedule and its equipment does not depreciate",
+                    "type": "boolean",
+                    "example": false
+                },
+                "device_type_id": {
+                    "description": "DeviceTypeID is an int32 for device type id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "life_months": {
+                    "description": "LifeMonths is how long the equipment is used, after that it is worth its salvage value",
+                    "type": "integer",
+                    "example": 36
+                },
+                "method": {
+                    "description": "Method is how the value goes down, straight_line loses the same amount every month, declining_balance loses a share of the remaining value every year and none keeps the cost",
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "straight_line",
+                        "declining_balance"
+                    ],
+                    "example": "straight_line"
+                },
+                "rate_percent": {
+                    "description": "RatePercent is the share of the remaining value lost every year by declining_balance",
+                    "type": "integer",
+                    "example": 40
+                },
+                "salvage_percent": {
+                    "description": "SalvagePercent is the share of the cost the equipment is worth at the end of its life",
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "models.DeviceManufacturerCount": {
+            "description": "DeviceManufacturerCount is the number of equipment of a device type made by a manufacturer",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active is the number of active equipment",
+                    "type": "integer",
+                    "example": 20
+                },
+                "device_type": {
+                    "description": "DeviceType is the device type name",
+                    "type": "string",
+                    "example": "computer"
+                },
+                "device_type_id": {
+                    "description": "DeviceTypeID is the device type id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "inactive": {
+                    "description": "Inactive is the number of inactive equipment",
+                    "type": "integer",
+                    "example": 1
+                },
+                "manufacturer": {
+                    "description": "Manufacturer is the manufacturer name",
+                    "type": "string",
+                    "example": "Apple"
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID is the manufacturer id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "description": "Total is the number of equipment",
+                    "type": "integer",
+                    "example": 21
+                }
+            }
+        },
+        "models.DeviceType": {
+            "description": "DeviceType is a struct for device type",
+            "type": "object",
+            "properties": {
+                "equipment": {
+                    "description": "Equipment counts the equipment of the device type, only included with counts=true",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EquipmentCounts"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "ID is an int32 for device type id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name is a string for device type name",
+                    "type": "string",
+                    "example": "computer"
+                },
+                "status": {
+                    "description": "Status is a string for device type status either active or inactive",
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "models.Equipment": {
+            "description": "Equipment is a struct for equipment",
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "Attributes are the device type attribute values, only included by search",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "auto_id": {
+                    "description": "AutoID is an int32 for equipment auto id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "book_value": {
+                    "description": "BookValue is what the equipment is worth today after depreciation, null when its cost or purchase date is unknown",
+                    "type": "number",
+                    "example": 866.66
+                },
+                "cost": {
+                    "description": "Cost is what the equipment was bought for, null when unknown",
+                    "type": "number",
+                    "example": 1299.99
+                },
+                "created_at": {
+                    "description": "CreatedAt is when the equipment was added",
+                    "type": "string",
+                    "example": "2024-03-18T15:04:05Z"
+                },
+                "device_type": {
+                    "description": "DeviceType is the device type of the equipment, only included with expand=device_type",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ]
+                },
+                "device_type_id": {
+                    "description": "DeviceTypeID is an int32 for device id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt is when the equipment was last scanned, null when it never was",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "last_seen_location_id": {
+                    "description": "LastSeenLocationID is the location the equipment was last scanned at, null when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "lifecycle_state": {
+                    "description": "LifecycleState is where the equipment is in its lifecycle, Status is derived from it",
+                    "type": "string",
+                    "example": "in_stock"
+                },
+                "location_id": {
+                    "description": "LocationID is the location the equipment is at, null when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "manufacturer": {
+                    "description": "Manufacturer is the manufacturer of the equipment, only included with expand=manufacturer",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Manufacturer"
+                        }
+                    ]
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID is an int32 for manufacturer id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_model_id": {
+                    "description": "ProductModelID is the product model of the equipment, null when unknown",
+                    "type": "integer",
+                    "example": 2
+                },
+                "purchase_date": {
+                    "description": "PurchaseDate is the date the equipment was bought, null when unknown",
+                    "type": "string",
+                    "example": "2024-03-18"
+                },
+                "purchase_order": {
+                    "description": "PurchaseOrder is the purchase order number the equipment was bought on",
+                    "type": "string",
+                    "example": "PO-10442"
+                },
+                "serial_number": {
+                    "description": "SerialNumber is a string for equipment serial number",
+                    "type": "string",
+                    "example": "SN-123456"
+                },
+                "status": {
+                    "description": "Status is a string for equipment status either active or inactive",
+                    "type": "string",
+                    "example": "active"
+                },
+                "vendor": {
+                    "description": "Vendor is who the equipment was bought from",
+                    "type": "string",
+                    "example": "CDW"
+                },
+                "warranty_end": {
+                    "description": "WarrantyEnd is the last day of the warranty, null when unknown",
+                    "type": "string",
+                    "example": "2027-03-17"
+                },
+                "warranty_start": {
+                    "description": "WarrantyStart is the first day of the warranty, null when unknown",
+                    "type": "string",
+                    "example": "2024-03-18"
+                }
+            }
+        },
+        "models.EquipmentCounts": {
+            "description": "EquipmentCounts is how much equipment of a device type or manufacturer is active and inactive",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active is the number of active equipment",
+                    "type": "integer",
+                    "example": 42
+                },
+                "inactive": {
+                    "description": "Inactive is the number of inactive equipment",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.EquipmentMatch": {
+            "description": "EquipmentMatch is equipment found by a fuzzy serial number search",
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "Attributes are the device type attribute values, only included by search",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "auto_id": {
+                    "description": "AutoID is an int32 for equipment auto id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "book_value": {
+                    "description": "BookValue is what the equipment is worth today after depreciation, null when its cost or purchase date is unknown",
+                    "type": "number",
+                    "example": 866.66
+                },
+                "cost": {
+                    "description": "Cost is what the equipment was bought for, null when unknown",
+                    "type": "number",
+                    "example": 1299.99
+                },
+                "created_at": {
+                    "description": "CreatedAt is when the equipment was added",
+                    "type": "string",
+                    "example": "2024-03-18T15:04:05Z"
+                },
+                "device_type": {
+                    "description": "DeviceType is the device type of the equipment, only included with expand=device_type",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ]
+                },
+                "device_type_id": {
+                    "description": "DeviceTypeID is an int32 for device id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "distance": {
+                    "description": "Distance is the number of characters inserted, deleted, changed or swapped to get from the search to the serial number",
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt is when the equipment was last scanned, null when it never was",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "last_seen_location_id": {
+                    "description": "LastSeenLocationID is the location the equipment was last scanned at, null when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "lifecycle_state": {
+                    "description": "LifecycleState is where the equipment is in its lifecycle, Status is derived from it",
+                    "type": "string",
+                    "example": "in_stock"
+                },
+                "location_id": {
+                    "description": "LocationID is the location the equipment is at, null when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "manufacturer": {
+                    "description": "Manufacturer is the manufacturer of the equipment, only included with expand=manufacturer",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Manufacturer"
+                        }
+                    ]
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID is an int32 for manufacturer id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_model_id": {
+                    "description": "ProductModelID is the product model of the equipment, null when unknown",
+                    "type": "integer",
+                    "example": 2
+                },
+                "purchase_date": {
+                    "description": "PurchaseDate is the date the equipment was bought, null when unknown",
+                    "type": "string",
+                    "example": "2024-03-18"
+                },
+                "purchase_order": {
+                    "description": "PurchaseOrder is the purchase order number the equipment was bought on",
+                    "type": "string",
+                    "example": "PO-10442"
+                },
+                "score": {
+                    "description": "Score is 1 for an exact match down towards 0 as the distance grows relative to the serial number length",
+                    "type": "number",
+                    "example": 0.9
+                },
+                "serial_number": {
+                    "description": "SerialNumber is a string for equipment serial number",
+                    "type": "string",
+                    "example": "SN-123456"
+                },
+                "status": {
+                    "description": "Status is a string for equipment status either active or inactive",
+                    "type": "string",
+                    "example": "active"
+                },
+                "vendor": {
+                    "description": "Vendor is who the equipment was bought from",
+                    "type": "string",
+                    "example": "CDW"
+                },
+                "warranty_end": {
+                    "description": "WarrantyEnd is the last day of the warranty, null when unknown",
+                    "type": "string",
+                    "example": "2027-03-17"
+                },
+                "warranty_start": {
+                    "description": "WarrantyStart is the first day of the warranty, null when unknown",
+                    "type": "string",
+                    "example": "2024-03-18"
+                }
+            }
+        },
+        "models.GroupCount": {
+            "description": "GroupCount is the number of equipment of a device type or manufacturer",
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active is the number of active equipment",
+                    "type": "integer",
+                    "example": 40
+                },
+                "id": {
+                    "description": "ID is the device type or manufacturer id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "inactive": {
+                    "description": "Inactive is the number of inactive equipment",
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "description": "Name is the device type or manufacturer name",
+                    "type": "string",
+                    "example": "computer"
+                },
+                "total": {
+                    "description": "Total is the number of equipment",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.GrowthPeriod": {
+            "description": "GrowthPeriod is the equipment added in a period",
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Added is the number of equipment added in the period",
+                    "type": "integer",
+                    "example": 8
+                },
+                "start": {
+                    "description": "Start is the first day of the period",
+                    "type": "string",
+                    "example": "2024-03-01"
+                },
+                "total": {
+                    "description": "Total is the number of equipment added up to the end of the period that still exists",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "models.HealthCheck": {
+            "description": "HealthCheck is the result of checking a single dependency",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is why the check failed, empty when the dependency is up",
+                    "type": "string"
+                },
+                "latency": {
+                    "description": "Latency is how long the check took",
+                    "type": "string",
+                    "example": "1.2ms"
+                },
+                "name": {
+                    "description": "Name is the dependency that was checked",
+                    "type": "string",
+                    "example": "equipment_database"
+                },
+                "status": {
+                    "description": "Status is either up or down",
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "models.HealthReport": {
+            "description": "HealthReport is a struct for the detailed health of the API",
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "Checks is the result for each dependency",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HealthCheck"
+                    }
+                },
+                "status": {
+                    "description": "Status is up when every check is up, otherwise down",
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "models.ImportRowError": {
+            "description": "ImportRowError is why a row of an equipment import was rejected",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is why the row was rejected",
+                    "type": "string",
+                    "example": "rule default: serial number must start with SN-"
+                },
+                "row": {
+                    "description": "Row is the line of the csv the error is on, the header is row 1",
+                    "type": "integer",
+                    "example": 3
+                },
+                "serial_number": {
+                    "description": "SerialNumber is the serial number on the row",
+                    "type": "string",
+                    "example": "SN-123456"
+                }
+            }
+        },
+        "models.InventoryStats": {
+            "description": "InventoryStats are equipment totals for a dashboard",
+            "type": "object",
+            "properties": {
+                "by_device_type": {
+                    "description": "ByDeviceType totals equipment by device type, device types without equipment are left out",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupCount"
+                    }
+                },
+                "by_device_type_manufacturer": {
+                    "description": "ByDeviceTypeManufacturer totals equipment by every device type and manufacturer pair that has equipment",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeviceManufacturerCount"
+                    }
+                },
+                "by_manufacturer": {
+                    "description": "ByManufacturer totals equipment by manufacturer, manufacturers without equipment are left out",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupCount"
+                    }
+                },
+                "by_status": {
+                    "description": "ByStatus totals equipment by status",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StatusCount"
+                    }
+                },
+                "growth": {
+                    "description": "Growth is the equipment added in every period from from to to, empty periods included",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GrowthPeriod"
+                    }
+                },
+                "interval": {
+                    "description": "Interval is the length of the growth periods, day, week or month",
+                    "type": "string",
+                    "example": "month"
+                },
+                "total": {
+                    "description": "Total is the number of equipment",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "models.JsonResponse": {
+            "description": "JsonResponse is a struct for response JSON message",
+            "type": "object",
+            "properties": {
+                "Action": {
+                    "description": "Action is a string for response action",
+                    "type": "string",
+                    "example": "none"
+                },
+                "MSG": {
+                    "description": "Message is an interface for response message can be string, models.DeviceType, models.Manufacturer, models.Equipment"
+                },
+                "RequestID": {
+                    "description": "RequestID is the X-Request-ID of the request, only set on errors",
+                    "type": "string",
+                    "example": "4f6c1e0a9b2d4c8e8f0a1b2c3d4e5f60"
+                },
+                "Status": {
+                    "description": "Status is a string for response status",
+                    "type": "string",
+                    "example": "SUCCESS"
+                }
+            }
+        },
+        "models.Lifecycle": {
+            "description": "Lifecycle is the lifecycle states and the transitions allowed between them",
+            "type": "object",
+            "properties": {
+                "states": {
+                    "description": "States is every lifecycle state",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "received",
+                        "in_stock",
+                        "deployed",
+                        "in_repair",
+                        "lost",
+                        "retired",
+                        "disposed"
+                    ]
+                },
+                "transitions": {
+                    "description": "Transitions maps a state to the states equipment in it may move to",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "models.LifecycleTransition": {
+            "description": "LifecycleTransition is a recorded move of equipment between lifecycle states",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt is when the equipment moved",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "equipment_id": {
+                    "description": "EquipmentID is the auto_id of the equipment that moved",
+                    "type": "integer",
+                    "example": 1
+                },
+                "from": {
+                    "description": "From is the state the equipment left",
+                    "type": "string",
+                    "example": "in_stock"
+                },
+                "id": {
+                    "description": "ID is an int32 for the transition id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "description": "Reason is why the equipment moved",
+                    "type": "string",
+                    "example": "issued to new hire"
+                },
+                "to": {
+                    "description": "To is the state the equipment moved to",
+                    "type": "string",
+                    "example": "deployed"
+                }
+            }
+        },
+        "models.Location": {
+            "description": "Location is a site, building, room, rack or shelf equipment can be at",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID is an int32 for location id",
+                    "type": "integer",
+                    "example": 4
+                },
+                "kind": {
+                    "description": "Kind is one of site, building, room, rack or shelf",
+                    "type": "string",
+                    "example": "rack"
+                },
+                "name": {
+                    "description": "Name is a string for location name",
+                    "type": "string",
+                    "example": "Rack A1"
+                },
+                "parent_id": {
+                    "description": "ParentID is the location this one is in, null for sites",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.LocationCount": {
+            "description": "LocationCount is the number of equipment at a location",
+            "type": "object",
+            "properties": {
+                "direct": {
+                    "description": "Direct is the equipment at the location itself",
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "description": "ID is an int32 for location id",
+                    "type": "integer",
+                    "example": 4
+                },
+                "kind": {
+                    "description": "Kind is one of site, building, room, rack or shelf",
+                    "type": "string",
+                    "example": "rack"
+                },
+                "name": {
+                    "description": "Name is a string for location name",
+                    "type": "string",
+                    "example": "Rack A1"
+                },
+                "parent_id": {
+                    "description": "ParentID is the location this one is in, null for sites",
+                    "type": "integer",
+                    "example": 3
+                },
+                "total": {
+                    "description": "Total is the equipment at the location and every location below it",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.LocationMove": {
+            "description": "LocationMove is a recorded move of equipment between locations",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt is when the equipment moved",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "equipment_id": {
+                    "description": "EquipmentID is the auto_id of the equipment that moved",
+                    "type": "integer",
+                    "example": 1
+                },
+                "from": {
+                    "description": "From is the location the equipment left, null when it had none",
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "description": "ID is an int32 for the move id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "description": "Reason is why the equipment moved",
+                    "type": "string",
+                    "example": "moved to new office"
+                },
+                "to": {
+                    "description": "To is the location the equipment moved to, null when it was removed from one",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.MaintenanceReport": {
+            "description": "MaintenanceReport is how often the equipment of a manufacturer or device type is repaired",
+            "type": "object",
+            "properties": {
+                "average_repair_days": {
+                    "description": "AverageRepairDays is the average number of days closed tickets were open, null when none are closed",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "equipment": {
+                    "description": "Equipment is the number of equipment of the manufacturer or device type",
+                    "type": "integer",
+                    "example": 40
+                },
+                "id": {
+                    "description": "ID is the id of the manufacturer or device type",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name is the name of the manufacturer or device type",
+                    "type": "string",
+                    "example": "Dell"
+                },
+                "open_tickets": {
+                    "description": "OpenTickets is the number of those tickets still open",
+                    "type": "integer",
+                    "example": 1
+                },
+                "repaired_equipment": {
+                    "description": "RepairedEquipment is the number of different equipment the tickets are for",
+                    "type": "integer",
+                    "example": 5
+                },
+                "tickets": {
+                    "description": "Tickets is the number of tickets opened in the report period",
+                    "type": "integer",
+                    "example": 6
+                },
+                "tickets_per_equipment": {
+                    "description": "TicketsPerEquipment is Tickets divided by Equipment",
+                    "type": "number",
+                    "example": 0.15
+                },
+                "total_cost": {
+                    "description": "TotalCost is the sum of the cost of the tickets",
+                    "type": "number",
+                    "example": 894
+                }
+            }
+        },
+        "models.MaintenanceTicket": {
+            "description": "MaintenanceTicket is a repair or maintenance of equipment",
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "description": "ClosedAt is the day the ticket was closed, null while it is open",
+                    "type": "string",
+                    "example": "2024-05-09"
+                },
+                "cost": {
+                    "description": "Cost is what the repair cost, null when unknown",
+                    "type": "number",
+                    "example": 149
+                },
+                "created_at": {
+                    "description": "CreatedAt is when the ticket was recorded",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "equipment_id": {
+                    "description": "EquipmentID is the auto_id of the equipment being repaired",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "ID is an int32 for maintenance ticket id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "issue": {
+                    "description": "Issue is what is wrong with the equipment",
+                    "type": "string",
+                    "example": "cracked screen"
+                },
+                "opened_at": {
+                    "description": "OpenedAt is the day the ticket was opened",
+                    "type": "string",
+                    "example": "2024-05-01"
+                },
+                "outcome": {
+                    "description": "Outcome is how the ticket ended, null while it is open",
+                    "type": "string",
+                    "enum": [
+                        "repaired",
+                        "replaced",
+                        "unrepairable"
+                    ],
+                    "example": "repaired"
+                },
+                "return_state": {
+                    "description": "ReturnState is the lifecycle state the equipment goes back to when it is repaired",
+                    "type": "string",
+                    "example": "deployed"
+                },
+                "vendor": {
+                    "description": "Vendor is who is doing the repair",
+                    "type": "string",
+                    "example": "Dell ProSupport"
+                }
+            }
+        },
+        "models.Manufacturer": {
+            "description": "Manufacturer is a struct for manufacturer",
+            "type": "object",
+            "properties": {
+                "equipment": {
+                    "description": "Equipment counts the equipment of the manufacturer, only included with counts=true",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EquipmentCounts"
+                        }
+                    ]
+                },
+                "id": {
                     "description": "ID is an int32 for manufacturer id",
                     "type": "integer",
                     "example": 1
@@ -1969,6 +8844,458 @@ const docTemplate = `{
                     "example": "active"
                 }
             }
+        },
+        "models.ProductModel": {
+            "description": "ProductModel is a specific model a manufacturer makes of a device type",
+            "type": "object",
+            "properties": {
+                "device_type_id": {
+                    "description": "DeviceTypeID is the device type of the model",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "ID is an int32 for product model id",
+                    "type": "integer",
+                    "example": 2
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID is the manufacturer that makes the model",
+                    "type": "integer",
+                    "example": 1
+                },
+                "model_number": {
+                    "description": "ModelNumber is the manufacturer's model number, unique per manufacturer",
+                    "type": "string",
+                    "example": "LAT-5440"
+                },
+                "name": {
+                    "description": "Name is a string for the marketing name of the model",
+                    "type": "string",
+                    "example": "Latitude 5440"
+                },
+                "specs": {
+                    "description": "Specs is a free form JSON object of the model's specifications",
+                    "type": "object"
+                }
+            }
+        },
+        "models.ScanResult": {
+            "description": "ScanResult is the equipment a scanned code resolved to",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is the action performed on the equipment, empty when none was asked for",
+                    "type": "string",
+                    "enum": [
+                        "checkin",
+                        "seen",
+                        "status"
+                    ],
+                    "example": "seen"
+                },
+                "code": {
+                    "description": "Code is the scanned code once scanner prefixes, whitespace and control characters are removed",
+                    "type": "string",
+                    "example": "SN-123456"
+                },
+                "device_type": {
+                    "$ref": "#/definitions/models.DeviceType"
+                },
+                "equipment": {
+                    "$ref": "#/definitions/models.Equipment"
+                },
+                "manufacturer": {
+                    "$ref": "#/definitions/models.Manufacturer"
+                },
+                "matched_by": {
+                    "description": "MatchedBy is how the code was resolved to the equipment",
+                    "type": "string",
+                    "enum": [
+                        "url",
+                        "serial_number",
+                        "auto_id"
+                    ],
+                    "example": "serial_number"
+                }
+            }
+        },
+        "models.SerialNormalization": {
+            "description": "SerialNormalization is how serial numbers of a manufacturer are made canonical",
+            "type": "object",
+            "properties": {
+                "case_fold": {
+                    "description": "CaseFold upper cases letters",
+                    "type": "boolean",
+                    "example": true
+                },
+                "default": {
+                    "description": "Default is true when the manufacturer has no normalization of its own",
+                    "type": "boolean",
+                    "example": false
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID is the manufacturer the normalization is for",
+                    "type": "integer",
+                    "example": 1
+                },
+                "separators": {
+                    "description": "Separators are characters removed from anywhere in the serial number",
+                    "type": "string",
+                    "example": " -_./:"
+                },
+                "trim": {
+                    "description": "Trim removes leading and trailing whitespace",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.SerialNumberRule": {
+            "description": "SerialNumberRule is a format serial numbers of a manufacturer and/or device type have to follow",
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "Checksum is either none or luhn",
+                    "type": "string",
+                    "example": "luhn"
+                },
+                "device_type_id": {
+                    "description": "DeviceTypeID limits the rule to a device type, null applies it to every device type",
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
+                    "description": "ID is an int32 for rule id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID limits the rule to a manufacturer, null applies it to every manufacturer",
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_length": {
+                    "description": "MaxLength is the longest serial number allowed, 0 is no maximum",
+                    "type": "integer",
+                    "example": 15
+                },
+                "min_length": {
+                    "description": "MinLength is the shortest serial number allowed, 0 is no minimum",
+                    "type": "integer",
+                    "example": 15
+                },
+                "name": {
+                    "description": "Name is a string describing the rule",
+                    "type": "string",
+                    "example": "apple imei"
+                },
+                "pattern": {
+                    "description": "Pattern is a regular expression the whole serial number has to match",
+                    "type": "string",
+                    "example": "[0-9]{15}"
+                },
+                "prefix": {
+                    "description": "Prefix is what the serial number has to start with",
+                    "type": "string",
+                    "example": "SN-"
+                }
+            }
+        },
+        "models.SerialNumberRuleFailure": {
+            "description": "SerialNumberRuleFailure is a rule a serial number does not follow",
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Reason is why the serial number fails the rule",
+                    "type": "string",
+                    "example": "serial number must start with SN-"
+                },
+                "rule": {
+                    "description": "Rule is the name of the failed rule",
+                    "type": "string",
+                    "example": "default"
+                },
+                "rule_id": {
+                    "description": "RuleID is the id of the failed rule, 0 for the built in length limit",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.SerialNumberValidation": {
+            "description": "SerialNumberValidation is the result of checking a serial number against the rules",
+            "type": "object",
+            "properties": {
+                "canonical": {
+                    "description": "Canonical is the form the serial number is stored and looked up with",
+                    "type": "string",
+                    "example": "SN123456"
+                },
+                "failures": {
+                    "description": "Failures is every rule the serial number does not follow",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SerialNumberRuleFailure"
+                    }
+                },
+                "serial_number": {
+                    "description": "SerialNumber is the serial number that was checked",
+                    "type": "string",
+                    "example": "SN-123456"
+                },
+                "valid": {
+                    "description": "Valid is true when the serial number follows every rule in scope",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.StatusCount": {
+            "description": "StatusCount is the number of equipment with a status",
+            "type": "object",
+            "properties": {
+                "status": {
+                    "description": "Status is active or inactive",
+                    "type": "string",
+                    "example": "active"
+                },
+                "total": {
+                    "description": "Total is the number of equipment with the status",
+                    "type": "integer",
+                    "example": 112
+                }
+            }
+        },
+        "models.Tag": {
+            "description": "Tag is a free-form label grouping equipment",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID is an int32 for tag id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name is the tag, lower case letters, digits, dots, dashes and underscores",
+                    "type": "string",
+                    "example": "loaner-pool"
+                }
+            }
+        },
+        "models.TagUsage": {
+            "description": "TagUsage is a tag with the number of equipment carrying it",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of equipment with the tag",
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "description": "ID is an int32 for tag id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name is the tag, lower case letters, digits, dots, dashes and underscores",
+                    "type": "string",
+                    "example": "loaner-pool"
+                }
+            }
+        },
+        "models.ValuationReport": {
+            "description": "ValuationReport is the value of the equipment of a device type, manufacturer or location",
+            "type": "object",
+            "properties": {
+                "book_value": {
+                    "description": "BookValue is what the valued equipment is worth on the report date",
+                    "type": "number",
+                    "example": 31200
+                },
+                "cost": {
+                    "description": "Cost is what the valued equipment was bought for",
+                    "type": "number",
+                    "example": 52000
+                },
+                "depreciation": {
+                    "description": "Depreciation is Cost minus BookValue",
+                    "type": "number",
+                    "example": 20800
+                },
+                "equipment": {
+                    "description": "Equipment is the number of equipment valued",
+                    "type": "integer",
+                    "example": 40
+                },
+                "id": {
+                    "description": "ID is the id of the device type, manufacturer or location, null for equipment without a location",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name is the name of the device type, manufacturer or location",
+                    "type": "string",
+                    "example": "laptop"
+                },
+                "unpriced": {
+                    "description": "Unpriced is the number of equipment bought by the report date without a cost, they are not valued",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.WarrantyExpiry": {
+            "description": "WarrantyExpiry is equipment whose warranty is about to expire",
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "Attributes are the device type attribute values, only included by search",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "auto_id": {
+                    "description": "AutoID is an int32 for equipment auto id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "book_value": {
+                    "description": "BookValue is what the equipment is worth today after depreciation, null when its cost or purchase date is unknown",
+                    "type": "number",
+                    "example": 866.66
+                },
+                "cost": {
+                    "description": "Cost is what the equipment was bought for, null when unknown",
+                    "type": "number",
+                    "example": 1299.99
+                },
+                "created_at": {
+                    "description": "CreatedAt is when the equipment was added",
+                    "type": "string",
+                    "example": "2024-03-18T15:04:05Z"
+                },
+                "days_left": {
+                    "description": "DaysLeft is the number of days from today until the warranty ends",
+                    "type": "integer",
+                    "example": 12
+                },
+                "device_type": {
+                    "description": "DeviceType is the device type of the equipment, only included with expand=device_type",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DeviceType"
+                        }
+                    ]
+                },
+                "device_type_id": {
+                    "description": "DeviceTypeID is an int32 for device id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt is when the equipment was last scanned, null when it never was",
+                    "type": "string",
+                    "example": "2024-05-01T15:04:05Z"
+                },
+                "last_seen_location_id": {
+                    "description": "LastSeenLocationID is the location the equipment was last scanned at, null when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "lifecycle_state": {
+                    "description": "LifecycleState is where the equipment is in its lifecycle, Status is derived from it",
+                    "type": "string",
+                    "example": "in_stock"
+                },
+                "location_id": {
+                    "description": "LocationID is the location the equipment is at, null when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "manufacturer": {
+                    "description": "Manufacturer is the manufacturer of the equipment, only included with expand=manufacturer",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Manufacturer"
+                        }
+                    ]
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID is an int32 for manufacturer id",
+                    "type": "integer",
+                    "example": 1
+                },
+                "product_model_id": {
+                    "description": "ProductModelID is the product model of the equipment, null when unknown",
+                    "type": "integer",
+                    "example": 2
+                },
+                "purchase_date": {
+                    "description": "PurchaseDate is the date the equipment was bought, null when unknown",
+                    "type": "string",
+                    "example": "2024-03-18"
+                },
+                "purchase_order": {
+                    "description": "PurchaseOrder is the purchase order number the equipment was bought on",
+                    "type": "string",
+                    "example": "PO-10442"
+                },
+                "serial_number": {
+                    "description": "SerialNumber is a string for equipment serial number",
+                    "type": "string",
+                    "example": "SN-123456"
+                },
+                "status": {
+                    "description": "Status is a string for equipment status either active or inactive",
+                    "type": "string",
+                    "example": "active"
+                },
+                "vendor": {
+                    "description": "Vendor is who the equipment was bought from",
+                    "type": "string",
+                    "example": "CDW"
+                },
+                "warranty_end": {
+                    "description": "WarrantyEnd is the last day of the warranty, null when unknown",
+                    "type": "string",
+                    "example": "2027-03-17"
+                },
+                "warranty_start": {
+                    "description": "WarrantyStart is the first day of the warranty, null when unknown",
+                    "type": "string",
+                    "example": "2024-03-18"
+                }
+            }
+        },
+        "models.WarrantyExpiryGroup": {
+            "description": "WarrantyExpiryGroup is the equipment of one manufacturer whose warranty is about to expire",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of equipment in the group",
+                    "type": "integer",
+                    "example": 3
+                },
+                "equipment": {
+                    "description": "Equipment is the equipment of the manufacturer, soonest expiring first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WarrantyExpiry"
+                    }
+                },
+                "manufacturer": {
+                    "description": "Manufacturer is the name of the manufacturer",
+                    "type": "string",
+                    "example": "Dell"
+                },
+                "manufacturer_id": {
+                    "description": "ManufacturerID is an int32 for manufacturer id",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,141 @@
+package attributes
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Type is the kind of value an attribute holds, values are stored as strings
+type Type string
+
+const (
+	String Type = "string"
+	Int    Type = "int"
+	Float  Type = "float"
+	Bool   Type = "bool"
+	Enum   Type = "enum"
+)
+
+// MaxValueLength is the size of equipment_attributes.value
+const MaxValueLength = 255
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// Definition is one attribute a device type declares for its equipment
+type Definition struct {
+	Name     string
+	Type     Type
+	Required bool
+	// Enum lists the allowed values of an enum attribute
+	Enum []string
+	// Pattern is a regular expression string values have to match, empty matches anything
+	Pattern string
+}
+
+// Check returns an error when d can't be used to validate values
+func (d Definition) Check() error {
+	if !validName.MatchString(d.Name) {
+		return fmt.Errorf("attribute name %q must be lowercase letters, digits and underscores starting with a letter", d.Name)
+	}
+	switch d.Type {
+	case String, Int, Float, Bool:
+		if len(d.Enum) > 0 {
+			return fmt.Errorf("attribute %s: only enum attributes take enum values", d.Name)
+		}
+	case Enum:
+		if len(d.Enum) == 0 {
+			return fmt.Errorf("attribute %s: enum attributes need at least one enum value", d.Name)
+		}
+	default:
+		return fmt.Errorf("attribute %s: type %q must be string, int, float, bool or enum", d.Name, d.Type)
+	}
+	if d.Pattern != "" {
+		if d.Type != String {
+			return fmt.Errorf("attribute %s: only string attributes take a pattern", d.Name)
+		}
+		if _, err := regexp.Compile(d.Pattern); err != nil {
+			return fmt.Errorf("attribute %s: invalid pattern: %w", d.Name, err)
+		}
+	}
+	return nil
+}
+
+// Normalize returns v in the canonical form it is stored and filtered in, or an error
+// when v is not a valid value for d
+func (d Definition) Normalize(v string) (string, error) {
+	switch d.Type {
+	case Int:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s must be an integer", d.Name)
+		}
+		return strconv.FormatInt(n, 10), nil
+	case Float:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return "", fmt.Errorf("%s must be a number", d.Name)
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return "", fmt.Errorf("%s must be true or false", d.Name)
+		}
+		return strconv.FormatBool(b), nil
+	case Enum:
+		if !slices.Contains(d.Enum, v) {
+			return "", fmt.Errorf("%s must be one of %s", d.Name, strings.Join(d.Enum, ", "))
+		}
+	case String:
+		if len(v) > MaxValueLength {
+			return "", fmt.Errorf("%s cannot be longer than %d characters", d.Name, MaxValueLength)
+		}
+		if d.Pattern != "" && !regexp.MustCompile(d.Pattern).MatchString(v) {
+			return "", fmt.Errorf("%s does not match %s", d.Name, d.Pattern)
+		}
+	}
+	return v, nil
+}
+
+// Validate checks values against the definitions of a device type and returns them
+// normalized, listing every unknown, missing or invalid attribute in the error
+func Validate(defs []Definition, values map[string]string) (map[string]string, error) {
+	var errs []error
+	out := make(map[string]string, len(values))
+	byName := make(map[string]Definition, len(defs))
+	for _, d := range defs {
+		byName[d.Name] = d
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		d, ok := byName[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s is not an attribute of the device type", name))
+			continue
+		}
+		v, err := d.Normalize(values[name])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out[name] = v
+	}
+	for _, d := range defs {
+		if _, ok := values[d.Name]; d.Required && !ok {
+			errs = append(errs, fmt.Errorf("%s is required", d.Name))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return out, nil
+}
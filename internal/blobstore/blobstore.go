@@ -0,0 +1,51 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/config"
+)
+
+// ErrNotFound is returned by Get when no blob is stored under the key
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps the contents of attachments by key
+//
+// Keys are slash separated paths like equipment/12/9f86d081. The methods follow S3
+// PutObject, GetObject and DeleteObject so an S3 compatible bucket can be added as
+// another backend without changing the handlers.
+type Store interface {
+	// Put stores everything read from r under key, replacing any blob already there
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// Get opens the blob stored under key, the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key, deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the store selected by cfg.Backend
+func New(cfg config.Attachments) (Store, error) {
+	switch cfg.Backend {
+	case "local":
+		return NewLocal(cfg.Dir)
+	default:
+		return nil, fmt.Errorf("unknown attachment backend %q", cfg.Backend)
+	}
+}
+
+// checkKey returns an error when key could escape the store or isn't a clean path
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.ContainsAny(key, "\\\x00") {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,384 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the typed configuration for the API
+//
+// Values are resolved with the following precedence, highest first:
+// command-line flags, environment variables (including a .env file),
+// the yaml or toml config file, then the defaults from Default.
+type Config struct {
+	Server      Server      `yaml:"server" toml:"server"`
+	Database    Database    `yaml:"database" toml:"database"`
+	Cors        Cors        `yaml:"cors" toml:"cors"`
+	Log         Log         `yaml:"log" toml:"log"`
+	Tracing     Tracing     `yaml:"tracing" toml:"tracing"`
+	Lifecycle   Lifecycle   `yaml:"lifecycle" toml:"lifecycle"`
+	Attachments Attachments `yaml:"attachments" toml:"attachments"`
+}
+
+// Server holds the http server settings
+type Server struct {
+	// Addr is the address the server listens on
+	Addr string `yaml:"addr" toml:"addr"`
+	// BaseURL is the url the API can reach itself on, handlers use it for internal lookups
+	BaseURL string `yaml:"base_url" toml:"base_url"`
+	// SwaggerURL is the url of doc.json served to the swagger ui, defaults to one under BaseURL
+	SwaggerURL   string   `yaml:"swagger_url" toml:"swagger_url"`
+	ReadTimeout  Duration `yaml:"read_timeout" toml:"read_timeout"`
+	WriteTimeout Duration `yaml:"write_timeout" toml:"write_timeout"`
+	// ShutdownTimeout is how long in-flight requests get to finish after SIGTERM
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+}
+
+// Database holds the mysql connection settings
+type Database struct {
+	User        string `yaml:"user" toml:"user"`
+	Password    string `yaml:"password" toml:"password"`
+	Host        string `yaml:"host" toml:"host"`
+	EquipmentDB string `yaml:"equipment_db" toml:"equipment_db"`
+	LogDB       string `yaml:"log_db" toml:"log_db"`
+	// MaxOpenConns limits the pool size, zero is unlimited
+	MaxOpenConns    int      `yaml:"max_open_conns" toml:"max_open_conns"`
+	MaxIdleConns    int      `yaml:"max_idle_conns" toml:"max_idle_conns"`
+	ConnMaxLifetime Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`
+	// MigrateOnStart applies pending migrations when the server starts
+	MigrateOnStart bool `yaml:"migrate_on_start" toml:"migrate_on_start"`
+}
+
+// Cors holds the cross origin policy, see middleware.CorsOptions
+type Cors struct {
+	AllowedOrigins   []string `yaml:"allowed_origins" toml:"allowed_origins"`
+	AllowedMethods   []string `yaml:"allowed_methods" toml:"allowed_methods"`
+	AllowedHeaders   []string `yaml:"allowed_headers" toml:"allowed_headers"`
+	AllowCredentials bool     `yaml:"allow_credentials" toml:"allow_credentials"`
+	MaxAge           Duration `yaml:"max_age" toml:"max_age"`
+}
+
+// Log holds the logger settings
+type Log struct {
+	// Level is one of debug, info, warn or error
+	Level string `yaml:"level" toml:"level"`
+	// Format is either json or text
+	Format string `yaml:"format" toml:"format"`
+	// File is appended to instead of stdout when set
+	File string `yaml:"file" toml:"file"`
+}
+
+// Tracing holds the OpenTelemetry exporter settings
+type Tracing struct {
+	// Exporter is none, stdout or otlp
+	Exporter string `yaml:"exporter" toml:"exporter"`
+	// Endpoint is the base URL of the OTLP/HTTP collector, traces are sent to its
+	// /v1/traces path. A host:port without a scheme is also accepted
+	Endpoint string `yaml:"endpoint" toml:"endpoint"`
+	// Insecure sends to a host:port endpoint over plain http, a URL uses its scheme
+	Insecure    bool    `yaml:"insecure" toml:"insecure"`
+	ServiceName string  `yaml:"service_name" toml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+}
+
+// Lifecycle holds the equipment lifecycle, see lifecycle.New
+type Lifecycle struct {
+	// Transitions maps a state to the states equipment in it may move to, states left
+	// out of the config file keep their default transitions
+	Transitions map[string][]string `yaml:"transitions" toml:"transitions"`
+}
+
+// Attachments holds where attachment files are stored and what can be uploaded
+type Attachments struct {
+	// Backend is where files are stored, only local is available for now
+	Backend string `yaml:"backend" toml:"backend"`
+	// Dir is the directory the local backend stores files under
+	Dir string `yaml:"dir" toml:"dir"`
+	// MaxSize is the largest file in bytes that can be uploaded
+	MaxSize int `yaml:"max_size" toml:"max_size"`
+	// AllowedTypes are the MIME types that can be uploaded, the type is sniffed from
+	// the file contents rather than taken from the client
+	AllowedTypes []string `yaml:"allowed_types" toml:"allowed_types"`
+}
+
+// Duration is a time.Duration that reads and writes as a string like "1m30s"
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Default returns the configuration used when nothing else is set
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Addr:            ":8081",
+			BaseURL:         "http://localhost:8081",
+			ReadTimeout:     Duration(time.Minute),
+			WriteTimeout:    Duration(time.Minute),
+			ShutdownTimeout: Duration(30 * time.Second),
+		},
+		Database: Database{
+			Host:            "localhost",
+			EquipmentDB:     "devices",
+			LogDB:           "logs",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: Duration(5 * time.Minute),
+			MigrateOnStart:  true,
+		},
+		Cors: Cors{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET", "POST", "PATCH", "DELETE"},
+			AllowedHeaders: []string{"Content-Type"},
+		},
+		Log: Log{
+			Level:  "info",
+			Format: "text",
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			Endpoint:    "http://localhost:4318",
+			Insecure:    true,
+			ServiceName: "equipment-api",
+			SampleRatio: 1,
+		},
+		Lifecycle: Lifecycle{
+			Transitions: map[string][]string{
+				"received":  {"in_stock", "lost"},
+				"in_stock":  {"deployed", "in_repair", "lost", "retired"},
+				"deployed":  {"in_stock", "in_repair", "lost", "retired"},
+				"in_repair": {"in_stock", "deployed", "retired"},
+				"lost":      {"in_stock", "retired"},
+				"retired":   {"in_stock", "disposed"},
+				"disposed":  {},
+			},
+		},
+		Attachments: Attachments{
+			Backend: "local",
+			Dir:     "data/attachments",
+			MaxSize: 10 << 20,
+			AllowedTypes: []string{
+				"application/pdf",
+				"image/jpeg",
+				"image/png",
+				"image/gif",
+				"image/webp",
+				"text/plain",
+			},
+		},
+	}
+}
+
+// Load returns the effective configuration
+// Load takes in the command-line arguments (without the program name), reads the
+// config file named by -config or API_CONFIG, the environment and the flags, and
+// validates the result.
+func Load(args []string) (*Config, error) {
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	path := fs.String("config", os.Getenv("API_CONFIG"), "path to a yaml or toml config file")
+	envFile := fs.String("env-file", ".env", "path to a .env file, ignored if it does not exist")
+
+	// NOTE: flags are parsed into a scratch config first so they can be applied
+	// last, after the file and the environment
+	scratch := Default()
+	for _, f := range scratch.fields() {
+		fs.Var(f.value, f.flag, f.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments %v", fs.Args())
+	}
+	set := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = f.Value.String()
+	})
+
+	if _, err := os.Stat(*envFile); err == nil {
+		if err := godotenv.Load(*envFile); err != nil {
+			return nil, fmt.Errorf("loading %s: %w", *envFile, err)
+		}
+	}
+
+	cfg := Default()
+	if *path != "" {
+		if err := cfg.loadFile(*path); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range cfg.fields() {
+		if v, ok := os.LookupEnv(f.env); ok {
+			if err := f.value.Set(v); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.env, err)
+			}
+		}
+		if v, ok := set[f.flag]; ok {
+			if err := f.value.Set(v); err != nil {
+				return nil, fmt.Errorf("-%s: %w", f.flag, err)
+			}
+		}
+	}
+
+	if cfg.Server.SwaggerURL == "" {
+		cfg.Server.SwaggerURL = strings.TrimSuffix(cfg.Server.BaseURL, "/") + "/api/v1/swagger/doc.json"
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(strings.NewReader(string(b)))
+		dec.KnownFields(true)
+		err = dec.Decode(c)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.Decode(string(b), c)
+		if err == nil && len(md.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown keys %v", md.Undecoded())
+		}
+	default:
+		return fmt.Errorf("config file %s must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate returns an error listing every invalid setting
+func (c *Config) Validate() error {
+	var errs []error
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("server.addr %q is not host:port", c.Server.Addr))
+	}
+	for _, v := range [][2]string{{"server.base_url", c.Server.BaseURL}, {"server.swagger_url", c.Server.SwaggerURL}} {
+		u, err := url.Parse(v[1])
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("%s %q is not an http(s) url", v[0], v[1]))
+		}
+	}
+	if c.Server.ReadTimeout <= 0 {
+		errs = append(errs, errors.New("server.read_timeout must be positive"))
+	}
+	if c.Server.WriteTimeout <= 0 {
+		errs = append(errs, errors.New("server.write_timeout must be positive"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("database pool sizes cannot be negative"))
+	}
+	if c.Database.User == "" {
+		errs = append(errs, errors.New("database.user is required"))
+	}
+	if c.Database.Host == "" {
+		errs = append(errs, errors.New("database.host is required"))
+	}
+	if c.Database.EquipmentDB == "" {
+		errs = append(errs, errors.New("database.equipment_db is required"))
+	}
+	if len(c.Cors.AllowedOrigins) == 0 {
+		errs = append(errs, errors.New("cors.allowed_origins cannot be empty"))
+	}
+	if c.Cors.AllowCredentials && slices.Contains(c.Cors.AllowedOrigins, "*") {
+		errs = append(errs, errors.New("cors.allow_credentials cannot be used with a \"*\" origin, list the allowed origins"))
+	}
+	for _, m := range c.Cors.AllowedMethods {
+		switch m {
+		case "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE":
+		default:
+			errs = append(errs, fmt.Errorf("cors.allowed_methods has unknown method %q", m))
+		}
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		errs = append(errs, fmt.Errorf("log.level %q must be debug, info, warn or error", c.Log.Level))
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		errs = append(errs, fmt.Errorf("log.format %q must be json or text", c.Log.Format))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Tracing.Endpoint == "" {
+			errs = append(errs, errors.New("tracing.endpoint is required for the otlp exporter"))
+		} else if strings.Contains(c.Tracing.Endpoint, "://") {
+			u, err := url.Parse(c.Tracing.Endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Errorf("tracing.endpoint %q must be an http or https URL like http://localhost:4318", c.Tracing.Endpoint))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter %q must be none, stdout or otlp", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sample_ratio must be between 0 and 1"))
+	}
+	if c.Cors.MaxAge < 0 {
+		errs = append(errs, errors.New("cors.max_age cannot be negative"))
+	}
+	if _, err := lifecycle.New(c.Lifecycle.Transitions); err != nil {
+		errs = append(errs, fmt.Errorf("lifecycle.transitions: %w", err))
+	}
+	switch c.Attachments.Backend {
+	case "local":
+		if c.Attachments.Dir == "" {
+			errs = append(errs, errors.New("attachments.dir is required for the local backend"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("attachments.backend %q must be local", c.Attachments.Backend))
+	}
+	if c.Attachments.MaxSize <= 0 {
+		errs = append(errs, errors.New("attachments.max_size must be positive"))
+	}
+	if len(c.Attachments.AllowedTypes) == 0 {
+		errs = append(errs, errors.New("attachments.allowed_types cannot be empty"))
+	}
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of the config that is safe to print
+func (c *Config) Redacted() *Config {
+	out := *c
+	for _, f := range out.fields() {
+		if f.secret && f.value.String() != "" {
+			f.value.Set("********")
+		}
+	}
+	return &out
+}
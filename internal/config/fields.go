@@ -0,0 +1,130 @@
+package config
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field ties a setting to its flag name and environment variable
+type field struct {
+	flag   string
+	env    string
+	usage  string
+	secret bool
+	value  flag.Value
+}
+
+// fields returns every setting that can be overridden from the environment or a flag
+func (c *Config) fields() []field {
+	return []field{
+		{flag: "addr", env: "API_ADDR", usage: "address to listen on", value: (*stringValue)(&c.Server.Addr)},
+		{flag: "base-url", env: "API_BASE_URL", usage: "url the api can reach itself on", value: (*stringValue)(&c.Server.BaseURL)},
+		{flag: "swagger-url", env: "API_SWAGGER_URL", usage: "url of the swagger doc.json", value: (*stringValue)(&c.Server.SwaggerURL)},
+		{flag: "read-timeout", env: "API_READ_TIMEOUT", usage: "server read timeout", value: (*durationValue)(&c.Server.ReadTimeout)},
+		{flag: "write-timeout", env: "API_WRITE_TIMEOUT", usage: "server write timeout", value: (*durationValue)(&c.Server.WriteTimeout)},
+		{flag: "shutdown-timeout", env: "API_SHUTDOWN_TIMEOUT", usage: "how long to drain requests on shutdown", value: (*durationValue)(&c.Server.ShutdownTimeout)},
+
+		{flag: "db-user", env: "MYSQL_USER", usage: "mysql user", value: (*stringValue)(&c.Database.User)},
+		{flag: "db-password", env: "MYSQL_PASSWORD", usage: "mysql password", secret: true, value: (*stringValue)(&c.Database.Password)},
+		{flag: "db-host", env: "MYSQL_HOST", usage: "mysql host[:port]", value: (*stringValue)(&c.Database.Host)},
+		{flag: "db-equipment", env: "MYSQL_EQUIPMENT_DB", usage: "equipment database name", value: (*stringValue)(&c.Database.EquipmentDB)},
+		{flag: "db-log", env: "MYSQL_LOG_DB", usage: "log database name", value: (*stringValue)(&c.Database.LogDB)},
+		{flag: "db-max-open", env: "MYSQL_MAX_OPEN_CONNS", usage: "max open connections, 0 is unlimited", value: (*intValue)(&c.Database.MaxOpenConns)},
+		{flag: "db-max-idle", env: "MYSQL_MAX_IDLE_CONNS", usage: "max idle connections", value: (*intValue)(&c.Database.MaxIdleConns)},
+		{flag: "db-conn-lifetime", env: "MYSQL_CONN_MAX_LIFETIME", usage: "max lifetime of a connection", value: (*durationValue)(&c.Database.ConnMaxLifetime)},
+		{flag: "migrate", env: "MYSQL_MIGRATE_ON_START", usage: "apply pending migrations on start", value: (*boolValue)(&c.Database.MigrateOnStart)},
+
+		{flag: "log-level", env: "LOG_LEVEL", usage: "debug, info, warn or error", value: (*stringValue)(&c.Log.Level)},
+		{flag: "log-format", env: "LOG_FORMAT", usage: "json or text", value: (*stringValue)(&c.Log.Format)},
+		{flag: "log-file", env: "LOG_FILE", usage: "file to append logs to instead of stdout", value: (*stringValue)(&c.Log.File)},
+
+		{flag: "trace-exporter", env: "OTEL_TRACES_EXPORTER", usage: "none, stdout or otlp", value: (*stringValue)(&c.Tracing.Exporter)},
+		{flag: "trace-endpoint", env: "OTEL_EXPORTER_OTLP_ENDPOINT", usage: "base URL of the OTLP/HTTP collector, like http://localhost:4318", value: (*stringValue)(&c.Tracing.Endpoint)},
+		{flag: "trace-insecure", env: "OTEL_EXPORTER_OTLP_INSECURE", usage: "send traces over plain http when the endpoint is a host:port", value: (*boolValue)(&c.Tracing.Insecure)},
+		{flag: "trace-service", env: "OTEL_SERVICE_NAME", usage: "service name on every span", value: (*stringValue)(&c.Tracing.ServiceName)},
+		{flag: "trace-sample-ratio", env: "OTEL_TRACES_SAMPLER_ARG", usage: "fraction of new traces to sample", value: (*floatValue)(&c.Tracing.SampleRatio)},
+
+		{flag: "cors-origins", env: "CORS_ALLOWED_ORIGINS", usage: "comma separated allowed origins", value: (*listValue)(&c.Cors.AllowedOrigins)},
+		{flag: "cors-methods", env: "CORS_ALLOWED_METHODS", usage: "comma separated allowed methods", value: (*listValue)(&c.Cors.AllowedMethods)},
+		{flag: "cors-headers", env: "CORS_ALLOWED_HEADERS", usage: "comma separated allowed request headers", value: (*listValue)(&c.Cors.AllowedHeaders)},
+		{flag: "cors-credentials", env: "CORS_ALLOW_CREDENTIALS", usage: "allow credentials on cross origin requests", value: (*boolValue)(&c.Cors.AllowCredentials)},
+		{flag: "cors-max-age", env: "CORS_MAX_AGE", usage: "how long browsers may cache a preflight", value: (*durationValue)(&c.Cors.MaxAge)},
+
+		{flag: "attachments-backend", env: "ATTACHMENTS_BACKEND", usage: "where attachment files are stored", value: (*stringValue)(&c.Attachments.Backend)},
+		{flag: "attachments-dir", env: "ATTACHMENTS_DIR", usage: "directory attachment files are stored under", value: (*stringValue)(&c.Attachments.Dir)},
+		{flag: "attachments-max-size", env: "ATTACHMENTS_MAX_SIZE", usage: "largest attachment in bytes", value: (*intValue)(&c.Attachments.MaxSize)},
+		{flag: "attachments-types", env: "ATTACHMENTS_ALLOWED_TYPES", usage: "comma separated MIME types that can be uploaded", value: (*listValue)(&c.Attachments.AllowedTypes)},
+	}
+}
+
+type stringValue string
+
+func (s *stringValue) String() string     { return string(*s) }
+func (s *stringValue) Set(v string) error { *s = stringValue(v); return nil }
+
+type intValue int
+
+func (i *intValue) String() string { return strconv.Itoa(int(*i)) }
+func (i *intValue) Set(v string) error {
+	p, err := strconv.Atoi(v)
+	if err != nil {
+		return err
+	}
+	*i = intValue(p)
+	return nil
+}
+
+type floatValue float64
+
+func (f *floatValue) String() string { return strconv.FormatFloat(float64(*f), 'g', -1, 64) }
+func (f *floatValue) Set(v string) error {
+	p, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return err
+	}
+	*f = floatValue(p)
+	return nil
+}
+
+type boolValue bool
+
+func (b *boolValue) String() string { return strconv.FormatBool(bool(*b)) }
+func (b *boolValue) Set(v string) error {
+	p, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	*b = boolValue(p)
+	return nil
+}
+
+// IsBoolFlag lets the flag be passed without a value
+func (b *boolValue) IsBoolFlag() bool { return true }
+
+type durationValue Duration
+
+func (d *durationValue) String() string { return time.Duration(*d).String() }
+func (d *durationValue) Set(v string) error {
+	p, err := time.ParseDuration(v)
+	if err != nil {
+		return err
+	}
+	*d = durationValue(p)
+	return nil
+}
+
+type listValue []string
+
+func (l *listValue) String() string { return strings.Join(*l, ",") }
+func (l *listValue) Set(v string) error {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	*l = out
+	return nil
+}
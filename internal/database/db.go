@@ -2,66 +2,123 @@ package database
 
 import (
 	"database/sql"
-	"log"
-	"os"
+	"log/slog"
+	"sync"
+	"time"
 
+	"github.com/coltonmosier/api-v1/internal/config"
+	"github.com/coltonmosier/api-v1/internal/metrics"
 	"github.com/coltonmosier/api-v1/internal/sqlc"
 	"github.com/go-sql-driver/mysql"
 )
 
 var (
+	settings config.Database
+
+	// NOTE: the pools are opened once and shared by every request
+	mu       sync.Mutex
+	edb      *sql.DB
+	ldb      *sql.DB
 	equeries *sqlc.Queries
 	lqueries *sqlc.Queries
 )
 
+// Configure sets the connection settings used by the Init functions
+func Configure(cfg config.Database) {
+	settings = cfg
+}
+
+// InitEquipmentDatabase returns the queries for the equipment database,
+// connecting on first use
 func InitEquipmentDatabase() (*sqlc.Queries, error) {
-	cfg := mysql.Config{
-		User:                 os.Getenv("MYSQL_USER"),
-		Passwd:               os.Getenv("MYSQL_PASSWORD"),
-		Net:                  "tcp",
-		Addr:                 os.Getenv("MYSQL_HOST"),
-		DBName:               os.Getenv("MYSQL_EQUIPMENT_DB"),
-		AllowNativePasswords: true,
+	if _, err := EquipmentDB(); err != nil {
+		return nil, err
 	}
+	return equeries, nil
+}
 
-	db, err := sql.Open("mysql", cfg.FormatDSN())
+// EquipmentDB returns the connection pool for the equipment database,
+// connecting on first use
+func EquipmentDB() (*sql.DB, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if edb != nil {
+		return edb, nil
+	}
+
+	db, err := open(settings.EquipmentDB)
 	if err != nil {
 		return nil, err
 	}
 
-	err = db.Ping()
+	edb = db
+	equeries = Queries(db)
+	metrics.RegisterDB(settings.EquipmentDB, db)
+
+	return edb, nil
+}
+
+func InitLoggingDatabase() (*sqlc.Queries, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if lqueries != nil {
+		return lqueries, nil
+	}
+
+	db, err := open(settings.LogDB)
 	if err != nil {
-		log.Println("Error pinging database")
 		return nil, err
 	}
 
-	equeries = sqlc.New(db)
+	ldb = db
+	lqueries = Queries(db)
+	metrics.RegisterDB(settings.LogDB, db)
 
-	return equeries, nil
+	return lqueries, nil
 }
 
-func InitLoggingDatabase() (*sqlc.Queries, error) {
+// Close closes any open connection pools
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	var err error
+	for _, db := range []*sql.DB{edb, ldb} {
+		if db == nil {
+			continue
+		}
+		if cerr := db.Close(); cerr != nil {
+			err = cerr
+		}
+	}
+	edb, ldb, equeries, lqueries = nil, nil, nil, nil
+	return err
+}
+
+func open(name string) (*sql.DB, error) {
 	cfg := mysql.Config{
-		User:                 os.Getenv("MYSQL_USER"),
-		Passwd:               os.Getenv("MYSQL_PASSWORD"),
+		User:                 settings.User,
+		Passwd:               settings.Password,
 		Net:                  "tcp",
-		Addr:                 os.Getenv("MYSQL_HOST"),
-		DBName:               os.Getenv("MYSQL_LOG_DB"),
+		Addr:                 settings.Host,
+		DBName:               name,
 		AllowNativePasswords: true,
+		ParseTime:            true,
 	}
 
 	db, err := sql.Open("mysql", cfg.FormatDSN())
 	if err != nil {
 		return nil, err
 	}
+	db.SetMaxOpenConns(settings.MaxOpenConns)
+	db.SetMaxIdleConns(settings.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(settings.ConnMaxLifetime))
 
 	err = db.Ping()
 	if err != nil {
-		log.Println("Error pinging database")
+		slog.Error("Error pinging database", slog.String("database", name), slog.String("error", err.Error()))
+		db.Close()
 		return nil, err
 	}
 
-	lqueries = sqlc.New(db)
-
-	return lqueries, nil
+	return db, nil
 }
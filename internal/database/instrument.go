@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/coltonmosier/api-v1/internal/logging"
+	"github.com/coltonmosier/api-v1/internal/metrics"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+	"github.com/coltonmosier/api-v1/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumented wraps a sqlc.DBTX so every query the generated code runs is timed,
+// traced and logged with the request scoped logger from its context
+type instrumented struct {
+	db sqlc.DBTX
+}
+
+// Queries returns sqlc queries bound to db or tx that go through the same
+// instrumentation as InitEquipmentDatabase
+func Queries(db sqlc.DBTX) *sqlc.Queries {
+	return sqlc.New(instrumented{db: db})
+}
+
+func (i instrumented) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span, start := begin(ctx, query)
+	res, err := i.db.ExecContext(ctx, query, args...)
+	observe(ctx, span, query, start, err)
+	return res, err
+}
+
+func (i instrumented) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return i.db.PrepareContext(ctx, query)
+}
+
+func (i instrumented) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span, start := begin(ctx, query)
+	rows, err := i.db.QueryContext(ctx, query, args...)
+	observe(ctx, span, query, start, err)
+	return rows, err
+}
+
+func (i instrumented) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span, start := begin(ctx, query)
+	row := i.db.QueryRowContext(ctx, query, args...)
+	observe(ctx, span, query, start, row.Err())
+	return row
+}
+
+// begin starts the client span for query, named after the sqlc query
+func begin(ctx context.Context, query string) (context.Context, trace.Span, time.Time) {
+	ctx, span := tracing.Tracer().Start(ctx, QueryName(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "mysql"),
+			attribute.String("db.operation", QueryName(query)),
+			attribute.String("db.statement", query),
+		),
+	)
+	return ctx, span, time.Now()
+}
+
+func observe(ctx context.Context, span trace.Span, query string, start time.Time, err error) {
+	name := QueryName(query)
+	metrics.ObserveQuery(name, time.Since(start), err)
+
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	logger := logging.FromContext(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		logger.LogAttrs(ctx, slog.LevelError, "query failed",
+			slog.String("query", name),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "query",
+		slog.String("query", name),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
+
+// QueryName returns the sqlc query name from the "-- name: X :kind" header sqlc puts on
+// every generated query, or "unknown" for hand written sql
+func QueryName(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "unknown"
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name
+}
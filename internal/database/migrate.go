@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// NOTE: migrations are plain sql files named NNNN_description.sql, applied in order.
+// sqlc reads the same directory as its schema, so every schema change goes here.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration is a single schema change
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version int NOT NULL,
+  name varchar(255) NOT NULL,
+  applied_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (version)
+)`
+
+// Migrations returns the embedded migrations sorted by version
+func Migrations() ([]Migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Migration
+	for _, e := range entries {
+		name := e.Name()
+		v, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s is not named NNNN_description.sql", name)
+		}
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s does not start with a version number", name)
+		}
+		b, err := migrationFiles.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Migration{Version: version, Name: name, SQL: string(b)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+
+	return out, nil
+}
+
+// PendingMigrations returns the names of migrations not yet applied to the equipment database
+func PendingMigrations(ctx context.Context) ([]string, error) {
+	db, err := EquipmentDB()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	all, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, m := range all {
+		if !applied[m.Version] {
+			out = append(out, m.Name)
+		}
+	}
+	return out, nil
+}
+
+// Migrate applies every pending migration to the equipment database and
+// returns the names of the ones it applied
+func Migrate(ctx context.Context) ([]string, error) {
+	db, err := EquipmentDB()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	all, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []string
+	for _, m := range all {
+		if applied[m.Version] {
+			continue
+		}
+		// NOTE: mysql commits ddl implicitly so a failed migration can be left half applied
+		for _, stmt := range splitStatements(m.SQL) {
+			if _, err := db.ExecContext(ctx, stmt); err != nil {
+				return done, fmt.Errorf("migration %s: %w", m.Name, err)
+			}
+		}
+		if _, err := db.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)", m.Version, m.Name); err != nil {
+			return done, fmt.Errorf("recording migration %s: %w", m.Name, err)
+		}
+		slog.Info("applied migration", slog.String("migration", m.Name))
+		done = append(done, m.Name)
+	}
+	return done, nil
+}
+
+func appliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	out := map[int]bool{}
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		// NOTE: 1146 is table doesn't exist, nothing has been applied yet
+		var merr *mysql.MySQLError
+		if errors.As(err, &merr) && merr.Number == 1146 {
+			return out, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		out[v] = true
+	}
+	return out, rows.Err()
+}
+
+// splitStatements splits a migration into single statements on ";" at the end of a line
+// so migrations must not put a ";" at the end of a line inside a string literal
+func splitStatements(sql string) []string {
+	var out []string
+	var cur strings.Builder
+	for _, line := range strings.Split(sql, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		cur.WriteString(line)
+		cur.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			out = append(out, strings.TrimSuffix(strings.TrimSpace(cur.String()), ";"))
+			cur.Reset()
+		}
+	}
+	if s := strings.TrimSpace(cur.String()); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
@@ -1,25 +1,27 @@
-CREATE DATABASE `devices`;
+-- NOTE: the database itself is created by hand, e.g. CREATE DATABASE `devices`;
+-- IF NOT EXISTS lets databases created before migrations existed adopt this one.
 
-CREATE TABLE `device_type` (
+CREATE TABLE IF NOT EXISTS `device_type` (
   `id` int NOT NULL AUTO_INCREMENT,
   `name` varchar(13) NOT NULL,
   `status` enum('active','inactive') NOT NULL DEFAULT 'active',
   PRIMARY KEY (`id`)
 );
 
-CREATE TABLE `manufacturer` (
+CREATE TABLE IF NOT EXISTS `manufacturer` (
   `id` int NOT NULL AUTO_INCREMENT,
   `name` varchar(10) NOT NULL,
   `status` enum('active','inactive') NOT NULL DEFAULT 'active',
   PRIMARY KEY (`id`)
 );
 
-CREATE TABLE `serial_numbers` (
+CREATE TABLE IF NOT EXISTS `serial_numbers` (
   `auto_id` int NOT NULL AUTO_INCREMENT,
   `device_type_id` int NOT NULL,
   `manufacturer_id` int NOT NULL,
   `serial_number` varchar(68) NOT NULL,
   `status` enum('active','inactive') NOT NULL DEFAULT 'active',
+  PRIMARY KEY (`auto_id`),
   UNIQUE KEY `serial_number` (`serial_number`),
   KEY `device_type_id` (`device_type_id`),
   KEY `manufacturer_id` (`manufacturer_id`),
@@ -0,0 +1,21 @@
+-- NOTE: status stays for older clients, it is kept in sync from lifecycle_state,
+-- see lifecycle.State.Legacy
+
+ALTER TABLE `serial_numbers`
+  ADD COLUMN `lifecycle_state` enum('received','in_stock','deployed','in_repair','lost','retired','disposed') NOT NULL DEFAULT 'in_stock';
+
+UPDATE `serial_numbers` SET `lifecycle_state` = 'retired' WHERE `status` = 'inactive';
+
+CREATE INDEX `lifecycle_state` ON `serial_numbers` (`lifecycle_state`);
+
+CREATE TABLE IF NOT EXISTS `lifecycle_transitions` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `equipment_id` int NOT NULL,
+  `from_state` varchar(16) NOT NULL,
+  `to_state` varchar(16) NOT NULL,
+  `reason` varchar(255) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `equipment_id` (`equipment_id`),
+  CONSTRAINT `fk_transition_to_equipment` FOREIGN KEY (`equipment_id`) REFERENCES `serial_numbers` (`auto_id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
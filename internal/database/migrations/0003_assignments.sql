@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS `assignees` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `kind` enum('person','team') NOT NULL DEFAULT 'person',
+  `email` varchar(255) NOT NULL DEFAULT '',
+  `status` enum('active','inactive') NOT NULL DEFAULT 'active',
+  PRIMARY KEY (`id`)
+);
+
+-- NOTE: an assignment is open until checked_in_at is set, equipment has at most one
+-- open assignment, that is enforced by the check out handler under a row lock
+CREATE TABLE IF NOT EXISTS `equipment_assignments` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `equipment_id` int NOT NULL,
+  `assignee_id` int NOT NULL,
+  `checked_out_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `checked_in_at` timestamp NULL DEFAULT NULL,
+  `note` varchar(255) NOT NULL DEFAULT '',
+  `checkin_note` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  KEY `equipment_id` (`equipment_id`),
+  KEY `assignee_id` (`assignee_id`),
+  CONSTRAINT `fk_assignment_to_equipment` FOREIGN KEY (`equipment_id`) REFERENCES `serial_numbers` (`auto_id`) ON DELETE CASCADE ON UPDATE RESTRICT,
+  CONSTRAINT `fk_assignment_to_assignee` FOREIGN KEY (`assignee_id`) REFERENCES `assignees` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT
+);
@@ -0,0 +1,31 @@
+-- NOTE: locations form a tree, site > building > room > rack/shelf, which kinds may
+-- contain which is checked by the location package rather than the database
+CREATE TABLE IF NOT EXISTS `locations` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `parent_id` int NULL DEFAULT NULL,
+  `name` varchar(100) NOT NULL,
+  `kind` enum('site','building','room','rack','shelf') NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `parent_id` (`parent_id`),
+  CONSTRAINT `fk_location_to_parent` FOREIGN KEY (`parent_id`) REFERENCES `locations` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT
+);
+
+ALTER TABLE `serial_numbers`
+  ADD COLUMN `location_id` int NULL DEFAULT NULL;
+
+CREATE INDEX `location_id` ON `serial_numbers` (`location_id`);
+
+ALTER TABLE `serial_numbers`
+  ADD CONSTRAINT `fk_to_location` FOREIGN KEY (`location_id`) REFERENCES `locations` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+CREATE TABLE IF NOT EXISTS `location_moves` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `equipment_id` int NOT NULL,
+  `from_location_id` int NULL DEFAULT NULL,
+  `to_location_id` int NULL DEFAULT NULL,
+  `reason` varchar(255) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `equipment_id` (`equipment_id`),
+  CONSTRAINT `fk_move_to_equipment` FOREIGN KEY (`equipment_id`) REFERENCES `serial_numbers` (`auto_id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS `product_models` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `manufacturer_id` int NOT NULL,
+  `device_type_id` int NOT NULL,
+  `model_number` varchar(100) NOT NULL,
+  `name` varchar(100) NOT NULL DEFAULT '',
+  `specs` json NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `manufacturer_model_number` (`manufacturer_id`, `model_number`),
+  KEY `device_type_id` (`device_type_id`),
+  CONSTRAINT `fk_model_to_manufacturer` FOREIGN KEY (`manufacturer_id`) REFERENCES `manufacturer` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT,
+  CONSTRAINT `fk_model_to_device_type` FOREIGN KEY (`device_type_id`) REFERENCES `device_type` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT
+);
+
+ALTER TABLE `serial_numbers`
+  ADD COLUMN `product_model_id` int NULL DEFAULT NULL;
+
+CREATE INDEX `product_model_id` ON `serial_numbers` (`product_model_id`);
+
+ALTER TABLE `serial_numbers`
+  ADD CONSTRAINT `fk_to_product_model` FOREIGN KEY (`product_model_id`) REFERENCES `product_models` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT;
@@ -0,0 +1,24 @@
+-- NOTE: values are stored as strings in the canonical form the attributes package
+-- normalizes them to, so filters can compare them as strings
+CREATE TABLE IF NOT EXISTS `device_type_attributes` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `device_type_id` int NOT NULL,
+  `name` varchar(64) NOT NULL,
+  `type` enum('string','int','float','bool','enum') NOT NULL,
+  `required` tinyint(1) NOT NULL DEFAULT 0,
+  `enum_values` json NOT NULL,
+  `pattern` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `device_type_attribute_name` (`device_type_id`, `name`),
+  CONSTRAINT `fk_attribute_to_device_type` FOREIGN KEY (`device_type_id`) REFERENCES `device_type` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS `equipment_attributes` (
+  `equipment_id` int NOT NULL,
+  `attribute_id` int NOT NULL,
+  `value` varchar(255) NOT NULL,
+  PRIMARY KEY (`equipment_id`, `attribute_id`),
+  KEY `attribute_value` (`attribute_id`, `value`),
+  CONSTRAINT `fk_value_to_equipment` FOREIGN KEY (`equipment_id`) REFERENCES `serial_numbers` (`auto_id`) ON DELETE CASCADE ON UPDATE RESTRICT,
+  CONSTRAINT `fk_value_to_attribute` FOREIGN KEY (`attribute_id`) REFERENCES `device_type_attributes` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS `serial_number_rules` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `manufacturer_id` int NULL DEFAULT NULL,
+  `device_type_id` int NULL DEFAULT NULL,
+  `pattern` varchar(255) NOT NULL DEFAULT '',
+  `min_length` int NOT NULL DEFAULT 0,
+  `max_length` int NOT NULL DEFAULT 0,
+  `prefix` varchar(68) NOT NULL DEFAULT '',
+  `checksum` enum('none','luhn') NOT NULL DEFAULT 'none',
+  PRIMARY KEY (`id`),
+  KEY `manufacturer_id` (`manufacturer_id`),
+  KEY `device_type_id` (`device_type_id`),
+  CONSTRAINT `fk_rule_to_manufacturer` FOREIGN KEY (`manufacturer_id`) REFERENCES `manufacturer` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT,
+  CONSTRAINT `fk_rule_to_device_type` FOREIGN KEY (`device_type_id`) REFERENCES `device_type` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
+
+-- NOTE: the SN- prefix GET /api/v1/equipment/sn used to require, as a rule every serial
+-- number follows until it is changed or deleted. Any rule scoped to a manufacturer or
+-- device type overrides it
+INSERT INTO `serial_number_rules` (`name`, `prefix`, `max_length`) VALUES ('default', 'SN-', 68);
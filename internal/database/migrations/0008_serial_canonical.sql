@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS `serial_normalizations` (
+  `manufacturer_id` int NOT NULL,
+  `trim` tinyint(1) NOT NULL DEFAULT 1,
+  `case_fold` tinyint(1) NOT NULL DEFAULT 1,
+  `separators` varchar(16) NOT NULL DEFAULT ' -_./:',
+  PRIMARY KEY (`manufacturer_id`),
+  CONSTRAINT `fk_normalization_to_manufacturer` FOREIGN KEY (`manufacturer_id`) REFERENCES `manufacturer` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
+
+ALTER TABLE `serial_numbers`
+  ADD COLUMN `serial_canonical` varchar(68) NOT NULL DEFAULT '';
+
+-- NOTE: the same as serial.DefaultNormalizer, no manufacturer has its own normalization yet
+UPDATE `serial_numbers` SET `serial_canonical` = UPPER(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(TRIM(`serial_number`), ' ', ''), '-', ''), '_', ''), '.', ''), '/', ''), ':', ''));
+
+-- NOTE: fails when existing serial numbers only differ in case or separators, find them with
+-- SELECT serial_canonical FROM serial_numbers GROUP BY serial_canonical HAVING COUNT(*) > 1
+CREATE UNIQUE INDEX `serial_canonical` ON `serial_numbers` (`serial_canonical`);
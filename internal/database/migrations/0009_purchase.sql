@@ -0,0 +1,9 @@
+ALTER TABLE `serial_numbers`
+  ADD COLUMN `purchase_date` date NULL DEFAULT NULL,
+  ADD COLUMN `vendor` varchar(100) NOT NULL DEFAULT '',
+  ADD COLUMN `purchase_order` varchar(50) NOT NULL DEFAULT '',
+  ADD COLUMN `cost` decimal(12,2) NULL DEFAULT NULL,
+  ADD COLUMN `warranty_start` date NULL DEFAULT NULL,
+  ADD COLUMN `warranty_end` date NULL DEFAULT NULL;
+
+CREATE INDEX `warranty_end` ON `serial_numbers` (`warranty_end`);
@@ -0,0 +1,20 @@
+-- NOTE: a ticket is open until closed_at is set, equipment has at most one open ticket,
+-- that is enforced by the open ticket handler under a row lock. return_state is the
+-- lifecycle state the equipment was in when the ticket opened, it goes back there when
+-- the ticket closes repaired
+CREATE TABLE IF NOT EXISTS `maintenance_tickets` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `equipment_id` int NOT NULL,
+  `issue` varchar(255) NOT NULL,
+  `vendor` varchar(100) NOT NULL DEFAULT '',
+  `opened_at` date NOT NULL,
+  `closed_at` date NULL DEFAULT NULL,
+  `cost` decimal(12,2) NULL DEFAULT NULL,
+  `outcome` enum('repaired','replaced','unrepairable') NULL DEFAULT NULL,
+  `return_state` varchar(16) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `equipment_id` (`equipment_id`),
+  KEY `opened_at` (`opened_at`),
+  CONSTRAINT `fk_ticket_to_equipment` FOREIGN KEY (`equipment_id`) REFERENCES `serial_numbers` (`auto_id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
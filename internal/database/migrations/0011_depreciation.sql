@@ -0,0 +1,11 @@
+-- NOTE: equipment of a device type without a schedule does not depreciate, it is worth
+-- its cost
+CREATE TABLE IF NOT EXISTS `depreciation_schedules` (
+  `device_type_id` int NOT NULL,
+  `method` enum('straight_line','declining_balance') NOT NULL,
+  `life_months` int NOT NULL,
+  `salvage_percent` int NOT NULL DEFAULT 0,
+  `rate_percent` int NOT NULL DEFAULT 0,
+  PRIMARY KEY (`device_type_id`),
+  CONSTRAINT `fk_depreciation_to_device_type` FOREIGN KEY (`device_type_id`) REFERENCES `device_type` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
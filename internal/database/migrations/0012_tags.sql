@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `tags` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `name` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `equipment_tags` (
+  `equipment_id` int NOT NULL,
+  `tag_id` int NOT NULL,
+  PRIMARY KEY (`equipment_id`, `tag_id`),
+  KEY `tag_id` (`tag_id`),
+  CONSTRAINT `fk_equipment_tag_to_equipment` FOREIGN KEY (`equipment_id`) REFERENCES `serial_numbers` (`auto_id`) ON DELETE CASCADE ON UPDATE RESTRICT,
+  CONSTRAINT `fk_equipment_tag_to_tag` FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT
+);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS `attachments` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `equipment_id` int NULL,
+  `manufacturer_id` int NULL,
+  `kind` enum('invoice','photo','warranty','other') NOT NULL DEFAULT 'other',
+  `filename` varchar(255) NOT NULL,
+  `content_type` varchar(100) NOT NULL,
+  `size` bigint NOT NULL,
+  `sha256` char(64) NOT NULL,
+  `storage_key` varchar(255) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `storage_key` (`storage_key`),
+  KEY `equipment_id` (`equipment_id`),
+  KEY `manufacturer_id` (`manufacturer_id`),
+  CONSTRAINT `fk_attachment_to_equipment` FOREIGN KEY (`equipment_id`) REFERENCES `serial_numbers` (`auto_id`) ON DELETE RESTRICT ON UPDATE RESTRICT,
+  CONSTRAINT `fk_attachment_to_manufacturer` FOREIGN KEY (`manufacturer_id`) REFERENCES `manufacturer` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT
+);
@@ -0,0 +1,8 @@
+-- NOTE: last seen is set by every scan, the location only by scans marking equipment
+-- seen at a location
+ALTER TABLE `serial_numbers`
+  ADD COLUMN `last_seen_at` timestamp NULL DEFAULT NULL,
+  ADD COLUMN `last_seen_location_id` int NULL DEFAULT NULL;
+
+ALTER TABLE `serial_numbers`
+  ADD CONSTRAINT `fk_last_seen_to_location` FOREIGN KEY (`last_seen_location_id`) REFERENCES `locations` (`id`) ON DELETE SET NULL ON UPDATE RESTRICT;
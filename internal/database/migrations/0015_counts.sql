@@ -0,0 +1,32 @@
+-- NOTE: a count session with neither a location nor a device type counts everything
+CREATE TABLE IF NOT EXISTS `count_sessions` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `location_id` int NULL DEFAULT NULL,
+  `device_type_id` int NULL DEFAULT NULL,
+  `status` enum('open','closed') NOT NULL DEFAULT 'open',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `closed_at` timestamp NULL DEFAULT NULL,
+  `applied_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `status` (`status`),
+  CONSTRAINT `fk_count_to_location` FOREIGN KEY (`location_id`) REFERENCES `locations` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT,
+  CONSTRAINT `fk_count_to_device_type` FOREIGN KEY (`device_type_id`) REFERENCES `device_type` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT
+);
+
+-- NOTE: codes that match no equipment are kept with a NULL equipment_id, they are
+-- reported as unexpected
+CREATE TABLE IF NOT EXISTS `count_scans` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `session_id` int NOT NULL,
+  `code` varchar(2048) NOT NULL,
+  `equipment_id` int NULL DEFAULT NULL,
+  `location_id` int NULL DEFAULT NULL,
+  `scanned_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `session_id` (`session_id`),
+  KEY `equipment_id` (`equipment_id`),
+  CONSTRAINT `fk_scan_to_session` FOREIGN KEY (`session_id`) REFERENCES `count_sessions` (`id`) ON DELETE CASCADE ON UPDATE RESTRICT,
+  CONSTRAINT `fk_scan_to_equipment` FOREIGN KEY (`equipment_id`) REFERENCES `serial_numbers` (`auto_id`) ON DELETE CASCADE ON UPDATE RESTRICT,
+  CONSTRAINT `fk_scan_to_location` FOREIGN KEY (`location_id`) REFERENCES `locations` (`id`) ON DELETE RESTRICT ON UPDATE RESTRICT
+);
@@ -0,0 +1,6 @@
+-- NOTE: equipment added before this migration gets the time the migration ran, growth
+-- statistics start from here
+ALTER TABLE `serial_numbers`
+  ADD COLUMN `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX `created_at` ON `serial_numbers` (`created_at`);
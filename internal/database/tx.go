@@ -0,0 +1,25 @@
+package database
+
+import (
+	"context"
+
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+// WithTx runs fn with queries bound to a transaction on the equipment database,
+// committing when fn returns nil and rolling back otherwise
+func WithTx(ctx context.Context, fn func(q *sqlc.Queries) error) error {
+	db, err := EquipmentDB()
+	if err != nil {
+		return err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(Queries(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,89 @@
+package depreciation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Method is how the value of equipment goes down over its life
+type Method string
+
+const (
+	// None keeps equipment at its cost, it is used for device types without a schedule
+	None Method = "none"
+	// StraightLine loses the same amount every month until the salvage value is reached
+	StraightLine Method = "straight_line"
+	// DecliningBalance loses a fixed share of the remaining value every year
+	DecliningBalance Method = "declining_balance"
+)
+
+// MaxLifeMonths is the longest life a schedule can have
+const MaxLifeMonths = 600
+
+// Schedule is how equipment of a device type depreciates
+type Schedule struct {
+	Method Method
+	// LifeMonths is how long the equipment is used, it is worth its salvage value after
+	LifeMonths int
+	// SalvagePercent is the share of the cost the equipment is still worth at the end of its life
+	SalvagePercent int
+	// RatePercent is the share of the remaining value lost every year, only used by DecliningBalance
+	RatePercent int
+}
+
+// Check returns an error when s can't be used to value equipment
+func (s Schedule) Check() error {
+	switch s.Method {
+	case StraightLine, DecliningBalance:
+	default:
+		return fmt.Errorf("unknown depreciation method %q", s.Method)
+	}
+	if s.LifeMonths < 1 || s.LifeMonths > MaxLifeMonths {
+		return fmt.Errorf("life_months must be from 1 to %d", MaxLifeMonths)
+	}
+	if s.SalvagePercent < 0 || s.SalvagePercent > 100 {
+		return errors.New("salvage_percent must be from 0 to 100")
+	}
+	if s.Method == DecliningBalance && (s.RatePercent < 1 || s.RatePercent > 100) {
+		return errors.New("rate_percent must be from 1 to 100 for declining_balance")
+	}
+	return nil
+}
+
+// Months returns the number of whole months from purchased to asOf, 0 when asOf is
+// before purchased
+func Months(purchased, asOf time.Time) int {
+	py, pm, pd := purchased.Date()
+	ay, am, ad := asOf.Date()
+	m := (ay-py)*12 + int(am-pm)
+	if ad < pd {
+		m--
+	}
+	return max(m, 0)
+}
+
+// Value returns what equipment bought for cost cents on purchased is worth in cents on
+// asOf. Equipment is worth its cost until its first month is over
+func (s Schedule) Value(cost int64, purchased, asOf time.Time) int64 {
+	if s.Method == None {
+		return cost
+	}
+	salvage := cost * int64(s.SalvagePercent) / 100
+	m := Months(purchased, asOf)
+	if m >= s.LifeMonths {
+		return salvage
+	}
+
+	var v float64
+	switch s.Method {
+	case StraightLine:
+		v = float64(cost) - float64(cost-salvage)*float64(m)/float64(s.LifeMonths)
+	case DecliningBalance:
+		v = float64(cost) * math.Pow(1-float64(s.RatePercent)/100, float64(m)/12)
+	default:
+		return cost
+	}
+	return max(int64(math.Round(v)), salvage)
+}
@@ -0,0 +1,162 @@
+package fuzzy
+
+import (
+	"math"
+	"slices"
+	"unicode/utf8"
+)
+
+// MaxDistance is the largest edit distance a search may allow, beyond it almost any
+// serial number of a similar length matches
+const MaxDistance = 5
+
+// Candidate is a serial number that may match a search
+type Candidate struct {
+	ID int32
+	// Serial is the canonical serial number of the candidate
+	Serial string
+	// Query is the search in the canonical form of the candidate's manufacturer
+	Query string
+}
+
+// Match is a candidate within the allowed distance of the search
+type Match struct {
+	ID       int32
+	Distance int
+	// Score is 1 for an exact match down towards 0 as the distance grows relative to the length
+	Score float64
+}
+
+// DefaultDistance is the distance allowed for a search of n characters when none is given,
+// short serial numbers get less room so a search doesn't match everything
+func DefaultDistance(n int) int {
+	switch {
+	case n <= 4:
+		return 1
+	case n <= 10:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Pieces splits s into 2*maxDistance+1 pieces of about the same length. Every string
+// within maxDistance of s contains at least one of them unchanged, an edit changes at most
+// one piece and a transposition across a boundary at most two, so they can prefilter
+// candidates. Pieces returns nil when s is too short to split
+func Pieces(s string, maxDistance int) []string {
+	r := []rune(s)
+	n := 2*maxDistance + 1
+	if len(r) < n {
+		return nil
+	}
+	pieces := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		pieces = append(pieces, string(r[i*len(r)/n:(i+1)*len(r)/n]))
+	}
+	return pieces
+}
+
+// Distance returns the optimal string alignment distance between a and b, which counts
+// insertions, deletions, substitutions and transpositions of adjacent characters. It
+// stops early and returns max+1 once the distance is known to be more than max
+func Distance(a, b string, max int) int {
+	var m matcher
+	return m.distance([]rune(a), []rune(b), max)
+}
+
+// matcher holds the buffers distance works in so ranking many candidates doesn't
+// allocate for each of them
+type matcher struct {
+	rows [3][]int
+	b    []rune
+}
+
+func (m *matcher) distance(a, b []rune, max int) int {
+	if d := len(a) - len(b); d > max || -d > max {
+		return max + 1
+	}
+
+	// NOTE: three rows are enough, transpositions only look two rows back
+	for i := range m.rows {
+		if cap(m.rows[i]) < len(b)+1 {
+			m.rows[i] = make([]int, len(b)+1)
+		}
+		m.rows[i] = m.rows[i][:len(b)+1]
+	}
+	prev2, prev, cur := m.rows[0], m.rows[1], m.rows[2]
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			best = min(best, cur[j])
+		}
+		if best > max {
+			return max + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	if prev[len(b)] > max {
+		return max + 1
+	}
+	return prev[len(b)]
+}
+
+// Score returns how alike two strings at distance d are, between 0 and 1 rounded to 3 decimals
+func Score(a, b string, d int) float64 {
+	n := max(len([]rune(a)), len([]rune(b)))
+	if n == 0 {
+		return 1
+	}
+	return math.Round((1-float64(d)/float64(n))*1000) / 1000
+}
+
+// Rank returns the candidates within maxDistance of their query, closest first, at most limit
+func Rank(candidates []Candidate, maxDistance, limit int) []Match {
+	var m matcher
+	queries := map[string][]rune{}
+	matches := []Match{}
+	for _, c := range candidates {
+		query, ok := queries[c.Query]
+		if !ok {
+			query = []rune(c.Query)
+			queries[c.Query] = query
+		}
+		if d := len(query) - utf8.RuneCountInString(c.Serial); d > maxDistance || -d > maxDistance {
+			continue
+		}
+		m.b = append(m.b[:0], []rune(c.Serial)...)
+		d := m.distance(query, m.b, maxDistance)
+		if d > maxDistance {
+			continue
+		}
+		matches = append(matches, Match{ID: c.ID, Distance: d, Score: Score(c.Query, c.Serial, d)})
+	}
+	slices.SortFunc(matches, func(a, b Match) int {
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return int(a.ID - b.ID)
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
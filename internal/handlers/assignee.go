@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+type AssigneeHandler struct{}
+
+func assigneeFromRow(v sqlc.Assignee) models.Assignee {
+	return models.Assignee{
+		ID:     v.ID,
+		Name:   v.Name,
+		Kind:   string(v.Kind),
+		Email:  v.Email,
+		Status: string(v.Status),
+	}
+}
+
+// GetAssignees get all assignees
+//
+//	@Summary		get all assignees
+//	@Description	get all people and teams equipment can be checked out to
+//	@Tags			assignee
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Assignee}
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/assignee [get]
+func (h *AssigneeHandler) GetAssignees(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/assignee")
+		return
+	}
+	d, err := q.GetAssignees(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/assignee")
+		return
+	}
+
+	out := []models.Assignee{}
+	for _, v := range d {
+		out = append(out, assigneeFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
+
+// GetAssigneeByID get an assignee by ID
+//
+//	@Summary		get an assignee by ID
+//	@Description	get an assignee by ID from the database
+//	@Tags			assignee
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"assignee id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=models.Assignee}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/assignee/{id} [get]
+func (h *AssigneeHandler) GetAssigneeByID(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/assignee/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id is not a number", "GET /api/v1/assignee/{id}")
+		return
+	}
+
+	d, err := q.GetAssigneeByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id does not exist in database", "GET /api/v1/assignee/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/assignee/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, assigneeFromRow(d))
+}
+
+// CreateAssignee create an assignee
+//
+//	@Summary		create assignee
+//	@Description	create a person or team equipment can be checked out to
+//	@Tags			assignee
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	query		string	true	"person or team name"	maxlength(100)
+//	@Param			kind	query		string	false	"person or team, defaults to person"	Enums(person, team)
+//	@Param			email	query		string	false	"contact email"	maxlength(255)
+//	@Success		200		{object}	models.JsonResponse{MSG=models.Assignee}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/assignee [post]
+func (h *AssigneeHandler) CreateAssignee(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/assignee?name={name}&kind={kind}&email={email}")
+		return
+	}
+
+	name := r.FormValue("name")
+	if name == "" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing name", "POST /api/v1/assignee?name={name}&kind={kind}&email={email}")
+		return
+	}
+	if len(name) > 100 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "name cannot be longer than 100 characters", "POST /api/v1/assignee?name={name}&kind={kind}&email={email}")
+		return
+	}
+
+	kind := r.FormValue("kind")
+	if kind == "" {
+		kind = "person"
+	}
+	if kind != "person" && kind != "team" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "kind must be either person or team", "POST /api/v1/assignee?name={name}&kind={kind}&email={email}")
+		return
+	}
+
+	email := r.FormValue("email")
+	if len(email) > 255 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "email cannot be longer than 255 characters", "POST /api/v1/assignee?name={name}&kind={kind}&email={email}")
+		return
+	}
+
+	id, err := q.CreateAssignee(r.Context(), sqlc.CreateAssigneeParams{Name: name, Kind: sqlc.AssigneesKind(kind), Email: email})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create assignee in database", "POST /api/v1/assignee?name={name}&kind={kind}&email={email}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, models.Assignee{ID: int32(id), Name: name, Kind: kind, Email: email, Status: "active"})
+}
+
+// UpdateAssigneeStatus update assignee status
+//
+//	@Summary		update assignee status
+//	@Description	update assignee status, inactive assignees cannot check out equipment
+//	@Tags			assignee
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"assignee id"		minimum(1)
+//	@Param			status	query		string	true	"assignee status"	Enums(active, inactive)
+//	@Success		200		{object}	models.JsonResponse
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/assignee/{id}/status [patch]
+func (h *AssigneeHandler) UpdateAssigneeStatus(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "PATCH /api/v1/assignee/{id}/status?status={status}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id is not a number", "PATCH /api/v1/assignee/{id}/status?status={status}")
+		return
+	}
+
+	status := r.FormValue("status")
+	if status != "active" && status != "inactive" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "status must be either active or inactive", "PATCH /api/v1/assignee/{id}/status?status={status}")
+		return
+	}
+
+	_, err = q.GetAssigneeByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id does not exist in database", "PATCH /api/v1/assignee/{id}/status?status={status}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "PATCH /api/v1/assignee/{id}/status?status={status}")
+		return
+	}
+
+	err = q.UpdateAssigneeStatus(r.Context(), sqlc.UpdateAssigneeStatusParams{ID: int32(i), Status: sqlc.AssigneesStatus(status)})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "something went wrong with sql statement "+err.Error(), "PATCH /api/v1/assignee/{id}/status?status={status}")
+		return
+	}
+
+	msg := fmt.Sprintf("assignee with id: %v updated status to %v", i, status)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, msg)
+}
+
+// GetAssigneeEquipment get the equipment an assignee holds
+//
+//	@Summary		get the equipment an assignee holds
+//	@Description	get the equipment currently checked out to an assignee
+//	@Tags			assignee
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int	true	"assignee id"	minimum(1)
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/assignee/{id}/equipment [get]
+func (h *AssigneeHandler) GetAssigneeEquipment(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/assignee/{id}/equipment")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id is not a number", "GET /api/v1/assignee/{id}/equipment")
+		return
+	}
+
+	_, err = q.GetAssigneeByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id does not exist in database", "GET /api/v1/assignee/{id}/equipment")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/assignee/{id}/equipment")
+		return
+	}
+
+	d, err := q.GetEquipmentHeldByAssignee(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/assignee/{id}/equipment")
+		return
+	}
+
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/assignee/{id}/equipment")
+		return
+	}
+
+	e := []models.Equipment{}
+	for _, v := range d {
+		e = append(e, b.equipment(v))
+	}
+
+	respondEquipment(w, r, q, e, "GET /api/v1/assignee/{id}/equipment")
+}
+
+// GetAssigneeAssignments get the assignment history of an assignee
+//
+//	@Summary		get the assignment history of an assignee
+//	@Description	get every check out made to an assignee, oldest first
+//	@Tags			assignee
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"assignee id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Assignment}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/assignee/{id}/assignments [get]
+func (h *AssigneeHandler) GetAssigneeAssignments(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/assignee/{id}/assignments")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id is not a number", "GET /api/v1/assignee/{id}/assignments")
+		return
+	}
+
+	_, err = q.GetAssigneeByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id does not exist in database", "GET /api/v1/assignee/{id}/assignments")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/assignee/{id}/assignments")
+		return
+	}
+
+	d, err := q.GetAssignmentsByAssignee(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/assignee/{id}/assignments")
+		return
+	}
+
+	a := []models.Assignment{}
+	for _, v := range d {
+		a = append(a, assignmentFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, a)
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+func assignmentFromRow(v sqlc.EquipmentAssignment) models.Assignment {
+	a := models.Assignment{
+		ID:           v.ID,
+		EquipmentID:  v.EquipmentID,
+		AssigneeID:   v.AssigneeID,
+		CheckedOutAt: v.CheckedOutAt,
+		Note:         v.Note,
+		CheckinNote:  v.CheckinNote,
+	}
+	if v.CheckedInAt.Valid {
+		a.CheckedInAt = &v.CheckedInAt.Time
+	}
+	return a
+}
+
+// checkOutable returns a statusError when equipment e can't be checked out because it,
+// its device type or its manufacturer is inactive
+func checkOutable(ctx context.Context, q *sqlc.Queries, e sqlc.SerialNumber) error {
+	if e.Status == sqlc.SerialNumbersStatusInactive {
+		return statusError{http.StatusBadRequest, "cannot check out, equipment is inactive"}
+	}
+	d, err := q.GetDeviceTypeById(ctx, e.DeviceTypeID)
+	if err != nil {
+		return err
+	}
+	if d.Status == sqlc.DeviceTypeStatusInactive {
+		return statusError{http.StatusBadRequest, "cannot check out, device is inactive"}
+	}
+	m, err := q.GetManufacturerById(ctx, e.ManufacturerID)
+	if err != nil {
+		return err
+	}
+	if m.Status == sqlc.ManufacturerStatusInactive {
+		return statusError{http.StatusBadRequest, "cannot check out, manufacturer is inactive"}
+	}
+	return nil
+}
+
+// CheckOutEquipment check out equipment to an assignee
+//
+//	@Summary		check out equipment
+//	@Description	check out equipment to an assignee and move it to deployed, inactive equipment or equipment with an inactive device or manufacturer is rejected
+//	@Tags			assignment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"equipment id"	minimum(1)
+//	@Param			assignee	query		int		true	"assignee id"	minimum(1)
+//	@Param			note		query		string	false	"check out note"	maxlength(255)
+//	@Success		200			{object}	models.JsonResponse
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		409			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/equipment/{id}/checkout [post]
+func (h *EquipmentHandler) CheckOutEquipment(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "POST /api/v1/equipment/{id}/checkout?assignee={assignee_id}&note={note}")
+		return
+	}
+
+	a, err := strconv.Atoi(r.FormValue("assignee"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "assignee id is not a number", "POST /api/v1/equipment/{id}/checkout?assignee={assignee_id}&note={note}")
+		return
+	}
+
+	note := r.FormValue("note")
+	if len(note) > maxReasonLength {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "note cannot be longer than 255 characters", "POST /api/v1/equipment/{id}/checkout?assignee={assignee_id}&note={note}")
+		return
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		e, err := q.GetEquipmentByAutoIDForUpdate(r.Context(), int32(i))
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "equipment id does not exist"}
+		} else if err != nil {
+			return err
+		}
+		if err := checkOutable(r.Context(), q, e); err != nil {
+			return err
+		}
+
+		assignee, err := q.GetAssigneeByID(r.Context(), int32(a))
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "assignee id does not exist in database"}
+		} else if err != nil {
+			return err
+		}
+		if assignee.Status == sqlc.AssigneesStatusInactive {
+			return statusError{http.StatusBadRequest, "cannot check out, assignee is inactive"}
+		}
+
+		open, err := q.GetOpenAssignmentByEquipment(r.Context(), int32(i))
+		if err == nil {
+			return statusError{http.StatusConflict, fmt.Sprintf("equipment is already checked out to assignee %v", open.AssigneeID)}
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		_, err = h.transition(r.Context(), q, int32(i), lifecycle.Deployed, "checked out to "+assignee.Name)
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			return statusError{http.StatusConflict, "cannot check out, " + err.Error()}
+		} else if err != nil {
+			return err
+		}
+
+		return q.CreateAssignment(r.Context(), sqlc.CreateAssignmentParams{EquipmentID: int32(i), AssigneeID: int32(a), Note: note})
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment/{id}/checkout?assignee={assignee_id}&note={note}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to check out equipment", "POST /api/v1/equipment/{id}/checkout?assignee={assignee_id}&note={note}")
+		return
+	}
+
+	msg := fmt.Sprintf("equipment with id: %v checked out to assignee %v", i, a)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, msg)
+}
+
+// CheckInEquipment check in equipment
+//
+//	@Summary		check in equipment
+//	@Description	close the open assignment of equipment, deployed equipment moves back to in_stock
+//	@Tags			assignment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"equipment id"	minimum(1)
+//	@Param			note	query		string	false	"check in note"	maxlength(255)
+//	@Success		200		{object}	models.JsonResponse
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		409		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/equipment/{id}/checkin [post]
+func (h *EquipmentHandler) CheckInEquipment(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "POST /api/v1/equipment/{id}/checkin?note={note}")
+		return
+	}
+
+	note := r.FormValue("note")
+	if len(note) > maxReasonLength {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "note cannot be longer than 255 characters", "POST /api/v1/equipment/{id}/checkin?note={note}")
+		return
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		return h.checkIn(r.Context(), q, int32(i), note)
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment/{id}/checkin?note={note}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to check in equipment", "POST /api/v1/equipment/{id}/checkin?note={note}")
+		return
+	}
+
+	msg := fmt.Sprintf("equipment with id: %v checked in", i)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, msg)
+}
+
+// checkIn closes the open assignment of equipment id and moves it back to in_stock when
+// it is deployed, q should be bound to a transaction
+func (h *EquipmentHandler) checkIn(ctx context.Context, q *sqlc.Queries, id int32, note string) error {
+	e, err := q.GetEquipmentByAutoIDForUpdate(ctx, id)
+	if err == sql.ErrNoRows {
+		return statusError{http.StatusBadRequest, "equipment id does not exist"}
+	} else if err != nil {
+		return err
+	}
+
+	open, err := q.GetOpenAssignmentByEquipment(ctx, id)
+	if err == sql.ErrNoRows {
+		return statusError{http.StatusConflict, "equipment is not checked out"}
+	} else if err != nil {
+		return err
+	}
+	if err := q.CheckInAssignment(ctx, sqlc.CheckInAssignmentParams{ID: open.ID, CheckinNote: note}); err != nil {
+		return err
+	}
+
+	// NOTE: equipment that went lost or in_repair while held stays where it is
+	if lifecycle.State(e.LifecycleState) != lifecycle.Deployed {
+		return nil
+	}
+	_, err = h.transition(ctx, q, id, lifecycle.InStock, fmt.Sprintf("checked in from assignee %v", open.AssigneeID))
+	if errors.Is(err, lifecycle.ErrIllegalTransition) {
+		return statusError{http.StatusConflict, "cannot check in, " + err.Error()}
+	}
+	return err
+}
+
+// GetEquipmentAssignments get the assignment history of equipment
+//
+//	@Summary		get the assignment history of equipment
+//	@Description	get every check out of equipment, oldest first
+//	@Tags			assignment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"equipment id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Assignment}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/assignment/equipment/{id} [get]
+func (h *EquipmentHandler) GetEquipmentAssignments(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/assignment/equipment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "GET /api/v1/assignment/equipment/{id}")
+		return
+	}
+
+	_, err = q.GetEquipmentByAutoID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id does not exist", "GET /api/v1/assignment/equipment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "GET /api/v1/assignment/equipment/{id}")
+		return
+	}
+
+	d, err := q.GetAssignmentsByEquipment(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for assignments", "GET /api/v1/assignment/equipment/{id}")
+		return
+	}
+
+	a := []models.Assignment{}
+	for _, v := range d {
+		a = append(a, assignmentFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, a)
+}
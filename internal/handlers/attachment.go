@@ -0,0 +1,483 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/coltonmosier/api-v1/internal/blobstore"
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/logging"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+const (
+	// maxFilenameLength is the size of attachments.filename
+	maxFilenameLength = 255
+	// multipartOverhead is room for the multipart headers and boundaries around the file
+	multipartOverhead = 64 << 10
+)
+
+// errFileTooLarge is returned while reading an upload once it passes the size limit
+var errFileTooLarge = errors.New("file too large")
+
+// AttachmentHandler stores files for equipment and manufacturers in Store, the
+// database keeps what each file is and its checksum
+type AttachmentHandler struct {
+	Store blobstore.Store
+	// MaxSize is the largest file in bytes that can be uploaded
+	MaxSize int64
+	// AllowedTypes are the MIME types that can be uploaded
+	AllowedTypes []string
+}
+
+func attachmentFromRow(v sqlc.Attachment) models.Attachment {
+	a := models.Attachment{
+		ID:          v.ID,
+		Kind:        string(v.Kind),
+		Filename:    v.Filename,
+		ContentType: v.ContentType,
+		Size:        v.Size,
+		Sha256:      v.Sha256,
+		CreatedAt:   v.CreatedAt,
+	}
+	if v.EquipmentID.Valid {
+		a.EquipmentID = &v.EquipmentID.Int32
+	}
+	if v.ManufacturerID.Valid {
+		a.ManufacturerID = &v.ManufacturerID.Int32
+	}
+	return a
+}
+
+// parseAttachmentKind returns the kind named s, other when s is empty
+func parseAttachmentKind(s string) (sqlc.AttachmentsKind, error) {
+	if s == "" {
+		return sqlc.AttachmentsKindOther, nil
+	}
+	switch k := sqlc.AttachmentsKind(s); k {
+	case sqlc.AttachmentsKindInvoice, sqlc.AttachmentsKindPhoto, sqlc.AttachmentsKindWarranty, sqlc.AttachmentsKindOther:
+		return k, nil
+	}
+	return "", statusError{http.StatusBadRequest, "kind must be one of invoice, photo, warranty or other"}
+}
+
+// cleanFilename keeps the last element of the name a client sent and drops control
+// characters, so it is safe to send back in a Content-Disposition header
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	for utf8.RuneCountInString(name) > maxFilenameLength {
+		_, n := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-n]
+	}
+	return name
+}
+
+// newStorageKey returns a key nobody can guess for a file of owner, like equipment/12/...
+func newStorageKey(owner string, id int32) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%d/%s", owner, id, hex.EncodeToString(b)), nil
+}
+
+// checkedReader hashes and counts what is read through it and fails with errFileTooLarge
+// once more than max bytes are read
+type checkedReader struct {
+	r    io.Reader
+	hash hash.Hash
+	size int64
+	max  int64
+}
+
+func (c *checkedReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.hash.Write(p[:n])
+	c.size += int64(n)
+	if c.size > c.max {
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+// checkOwner returns a statusError when the equipment or manufacturer with id does not exist
+func checkOwner(ctx context.Context, q *sqlc.Queries, owner string, id int32) error {
+	var err error
+	if owner == "equipment" {
+		_, err = q.GetEquipmentByAutoID(ctx, id)
+	} else {
+		_, err = q.GetManufacturerById(ctx, id)
+	}
+	if err == sql.ErrNoRows {
+		return statusError{http.StatusBadRequest, owner + " id does not exist in database"}
+	}
+	return err
+}
+
+// upload reads the file part of a multipart request into the store and records it for
+// the equipment or manufacturer with id
+func (h *AttachmentHandler) upload(w http.ResponseWriter, r *http.Request, owner, route string) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", route)
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, owner+" id is not a number", route)
+		return
+	}
+	// NOTE: kind is read from the query string, r.FormValue would read the whole
+	// multipart body into memory
+	kind, err := parseAttachmentKind(r.URL.Query().Get("kind"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, route)
+		return
+	}
+	err = checkOwner(r.Context(), q, owner, int32(i))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, route)
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for "+owner, route)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.MaxSize+multipartOverhead)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "request must be multipart/form-data", route)
+		return
+	}
+	var part io.Reader
+	var filename string
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "invalid multipart body", route)
+			return
+		}
+		if p.FormName() == "file" {
+			part, filename = p, cleanFilename(p.FileName())
+			break
+		}
+	}
+	if part == nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing file", route)
+		return
+	}
+
+	// NOTE: the type is sniffed from the first bytes instead of trusting the client
+	head := make([]byte, 512)
+	n, err := io.ReadFull(part, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to read file", route)
+		return
+	}
+	if n == 0 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "file is empty", route)
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if !slices.Contains(h.AllowedTypes, contentType) {
+		helpers.JsonResponseError(w, http.StatusUnsupportedMediaType, fmt.Sprintf("files of type %s cannot be attached", contentType), route)
+		return
+	}
+
+	key, err := newStorageKey(owner, int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to name file", route)
+		return
+	}
+	body := &checkedReader{r: io.MultiReader(bytes.NewReader(head[:n]), part), hash: sha256.New(), max: h.MaxSize}
+	err = h.Store.Put(r.Context(), key, body, contentType)
+	var tooLarge *http.MaxBytesError
+	if errors.Is(err, errFileTooLarge) || errors.As(err, &tooLarge) {
+		helpers.JsonResponseError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("file cannot be larger than %d bytes", h.MaxSize), route)
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to store file", route)
+		return
+	}
+
+	arg := sqlc.CreateAttachmentParams{
+		Kind:        kind,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        body.size,
+		Sha256:      hex.EncodeToString(body.hash.Sum(nil)),
+		StorageKey:  key,
+	}
+	if owner == "equipment" {
+		arg.EquipmentID = sql.NullInt32{Int32: int32(i), Valid: true}
+	} else {
+		arg.ManufacturerID = sql.NullInt32{Int32: int32(i), Valid: true}
+	}
+	id, err := q.CreateAttachment(r.Context(), arg)
+	if err != nil {
+		h.deleteBlob(r.Context(), key)
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create attachment in database", route)
+		return
+	}
+
+	a, err := q.GetAttachmentByID(r.Context(), int32(id))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for attachment", route)
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, attachmentFromRow(a))
+}
+
+// deleteBlob removes a file that no attachment points at anymore, a failure only
+// leaves an orphaned file behind so it is logged rather than returned
+func (h *AttachmentHandler) deleteBlob(ctx context.Context, key string) {
+	if err := h.Store.Delete(ctx, key); err != nil {
+		logging.FromContext(ctx).Warn("failed to delete attachment file", slog.String("key", key), slog.String("error", err.Error()))
+	}
+}
+
+// list writes the attachments of the equipment or manufacturer with id
+func (h *AttachmentHandler) list(w http.ResponseWriter, r *http.Request, owner, route string) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", route)
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, owner+" id is not a number", route)
+		return
+	}
+	err = checkOwner(r.Context(), q, owner, int32(i))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, route)
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for "+owner, route)
+		return
+	}
+
+	var d []sqlc.Attachment
+	if owner == "equipment" {
+		d, err = q.GetEquipmentAttachments(r.Context(), sql.NullInt32{Int32: int32(i), Valid: true})
+	} else {
+		d, err = q.GetManufacturerAttachments(r.Context(), sql.NullInt32{Int32: int32(i), Valid: true})
+	}
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for attachments", route)
+		return
+	}
+
+	a := []models.Attachment{}
+	for _, v := range d {
+		a = append(a, attachmentFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, a)
+}
+
+// UploadEquipmentAttachment attach a file to equipment
+//
+//	@Summary		attach a file to equipment
+//	@Description	upload a file like an invoice, a photo of damage or a warranty certificate for equipment. The file is sent in the file field of a multipart/form-data body, its type is sniffed from the contents and must be one of the allowed types
+//	@Tags			attachment
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			id		path		int		true	"equipment id"	minimum(1)
+//	@Param			kind	query		string	false	"what the file is"	Enums(invoice, photo, warranty, other)	default(other)
+//	@Param			file	formData	file	true	"the file"
+//	@Success		200		{object}	models.JsonResponse{MSG=models.Attachment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		413		{object}	models.JsonResponse
+//	@Failure		415		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/equipment/{id}/attachments [post]
+func (h *AttachmentHandler) UploadEquipmentAttachment(w http.ResponseWriter, r *http.Request) {
+	h.upload(w, r, "equipment", "POST /api/v1/equipment/{id}/attachments?kind={kind}")
+}
+
+// UploadManufacturerAttachment attach a file to a manufacturer
+//
+//	@Summary		attach a file to a manufacturer
+//	@Description	upload a file like a warranty certificate or a support contract for a manufacturer. The file is sent in the file field of a multipart/form-data body, its type is sniffed from the contents and must be one of the allowed types
+//	@Tags			attachment
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			id		path		int		true	"manufacturer id"	minimum(1)
+//	@Param			kind	query		string	false	"what the file is"	Enums(invoice, photo, warranty, other)	default(other)
+//	@Param			file	formData	file	true	"the file"
+//	@Success		200		{object}	models.JsonResponse{MSG=models.Attachment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		413		{object}	models.JsonResponse
+//	@Failure		415		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/manufacturer/{id}/attachments [post]
+func (h *AttachmentHandler) UploadManufacturerAttachment(w http.ResponseWriter, r *http.Request) {
+	h.upload(w, r, "manufacturer", "POST /api/v1/manufacturer/{id}/attachments?kind={kind}")
+}
+
+// GetEquipmentAttachments get the files attached to equipment
+//
+//	@Summary		get the files attached to equipment
+//	@Description	get the files attached to equipment, newest first
+//	@Tags			attachment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"equipment id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Attachment}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/attachment/equipment/{id} [get]
+func (h *AttachmentHandler) GetEquipmentAttachments(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, "equipment", "GET /api/v1/attachment/equipment/{id}")
+}
+
+// GetManufacturerAttachments get the files attached to a manufacturer
+//
+//	@Summary		get the files attached to a manufacturer
+//	@Description	get the files attached to a manufacturer, newest first
+//	@Tags			attachment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"manufacturer id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Attachment}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/attachment/manufacturer/{id} [get]
+func (h *AttachmentHandler) GetManufacturerAttachments(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, "manufacturer", "GET /api/v1/attachment/manufacturer/{id}")
+}
+
+// DownloadAttachment download an attached file
+//
+//	@Summary		download an attached file
+//	@Description	download an attached file with the name it was uploaded with. The ETag is the SHA-256 checksum of the file and range requests are supported
+//	@Tags			attachment
+//	@Produce		octet-stream
+//	@Param			id	path		int	true	"attachment id"	minimum(1)
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		404	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/attachment/{id} [get]
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/attachment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "attachment id is not a number", "GET /api/v1/attachment/{id}")
+		return
+	}
+
+	a, err := q.GetAttachmentByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "attachment id does not exist in database", "GET /api/v1/attachment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for attachment", "GET /api/v1/attachment/{id}")
+		return
+	}
+
+	f, err := h.Store.Get(r.Context(), a.StorageKey)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		helpers.JsonResponseError(w, http.StatusNotFound, "attachment file is missing from storage", "GET /api/v1/attachment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to read attachment file", "GET /api/v1/attachment/{id}")
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+	w.Header().Set("ETag", `"`+a.Sha256+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if rs, ok := f.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", a.CreatedAt, rs)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+	io.Copy(w, f)
+}
+
+// DeleteAttachment delete an attached file
+//
+//	@Summary		delete an attached file
+//	@Description	delete an attached file from the database and from storage
+//	@Tags			attachment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"attachment id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/attachment/{id} [delete]
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "DELETE /api/v1/attachment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "attachment id is not a number", "DELETE /api/v1/attachment/{id}")
+		return
+	}
+
+	a, err := q.GetAttachmentByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "attachment id does not exist in database", "DELETE /api/v1/attachment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for attachment", "DELETE /api/v1/attachment/{id}")
+		return
+	}
+
+	// NOTE: the row goes first so an attachment is never listed without its file
+	if err := q.DeleteAttachment(r.Context(), int32(i)); err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to delete attachment from database", "DELETE /api/v1/attachment/{id}")
+		return
+	}
+	h.deleteBlob(r.Context(), a.StorageKey)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, fmt.Sprintf("attachment with id: %v deleted", i))
+}
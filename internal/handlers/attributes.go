@@ -0,0 +1,447 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/attributes"
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+func definitionFromRow(v sqlc.DeviceTypeAttribute) attributes.Definition {
+	d := attributes.Definition{
+		Name:     v.Name,
+		Type:     attributes.Type(v.Type),
+		Required: v.Required,
+		Pattern:  v.Pattern,
+	}
+	json.Unmarshal(v.EnumValues, &d.Enum)
+	return d
+}
+
+func attributeDefinitionFromRow(v sqlc.DeviceTypeAttribute) models.AttributeDefinition {
+	d := definitionFromRow(v)
+	if d.Enum == nil {
+		d.Enum = []string{}
+	}
+	return models.AttributeDefinition{
+		ID:           v.ID,
+		DeviceTypeID: v.DeviceTypeID,
+		Name:         d.Name,
+		Type:         string(d.Type),
+		Required:     d.Required,
+		Enum:         d.Enum,
+		Pattern:      d.Pattern,
+	}
+}
+
+// equipmentAttributes returns the attribute values of every equipment in ids
+func equipmentAttributes(ctx context.Context, q *sqlc.Queries, ids []int32) (map[int32]map[string]string, error) {
+	out := map[int32]map[string]string{}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	d, err := q.GetEquipmentAttributes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range d {
+		if out[v.EquipmentID] == nil {
+			out[v.EquipmentID] = map[string]string{}
+		}
+		out[v.EquipmentID][v.Name] = v.Value
+	}
+	return out, nil
+}
+
+// GetDeviceTypeAttributes get the attribute schema of a device type
+//
+//	@Summary		get the attribute schema of a device type
+//	@Description	get the attributes equipment of a device type carries
+//	@Tags			device
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"device id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.AttributeDefinition}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/device/{id}/attributes [get]
+func (h *DeviceHandler) GetDeviceTypeAttributes(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/device/{id}/attributes")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "GET /api/v1/device/{id}/attributes")
+		return
+	}
+
+	_, err = q.GetDeviceTypeById(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id does not exist in database", "GET /api/v1/device/{id}/attributes")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/device/{id}/attributes")
+		return
+	}
+
+	d, err := q.GetDeviceTypeAttributes(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/device/{id}/attributes")
+		return
+	}
+
+	out := []models.AttributeDefinition{}
+	for _, v := range d {
+		out = append(out, attributeDefinitionFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
+
+// CreateDeviceTypeAttribute add an attribute to a device type
+//
+//	@Summary		add an attribute to a device type
+//	@Description	add an attribute to the schema of a device type. Required attributes are enforced when equipment is created or imported and whenever the attributes of equipment are written, existing equipment without a value keeps it until then
+//	@Tags			device
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"device id"	minimum(1)
+//	@Param			name		query		string	true	"attribute name, lowercase letters, digits and underscores"	maxlength(64)
+//	@Param			type		query		string	true	"attribute type"	Enums(string, int, float, bool, enum)
+//	@Param			required	query		bool	false	"whether equipment needs a value"
+//	@Param			enum		query		string	false	"comma separated allowed values of an enum attribute"
+//	@Param			pattern		query		string	false	"regular expression string values have to match"	maxlength(255)
+//	@Success		200			{object}	models.JsonResponse{MSG=models.AttributeDefinition}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/device/{id}/attributes [post]
+func (h *DeviceHandler) CreateDeviceTypeAttribute(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	}
+
+	d := attributes.Definition{
+		Name:     r.FormValue("name"),
+		Type:     attributes.Type(r.FormValue("type")),
+		Required: r.FormValue("required") == "true",
+		Pattern:  r.FormValue("pattern"),
+	}
+	if enum := r.FormValue("enum"); enum != "" {
+		for _, v := range strings.Split(enum, ",") {
+			d.Enum = append(d.Enum, strings.TrimSpace(v))
+		}
+	}
+	if err := d.Check(); err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	}
+	if len(d.Pattern) > 255 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "pattern cannot be longer than 255 characters", "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	}
+
+	_, err = q.GetDeviceTypeById(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id does not exist in database", "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	}
+
+	_, err = q.GetDeviceTypeAttributeByName(r.Context(), sqlc.GetDeviceTypeAttributeByNameParams{DeviceTypeID: int32(i), Name: d.Name})
+	if err == nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "attribute already exists for device", "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	} else if err != sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	}
+
+	enum := d.Enum
+	if enum == nil {
+		enum = []string{}
+	}
+	enumValues, _ := json.Marshal(enum)
+	id, err := q.CreateDeviceTypeAttribute(r.Context(), sqlc.CreateDeviceTypeAttributeParams{
+		DeviceTypeID: int32(i),
+		Name:         d.Name,
+		Type:         sqlc.DeviceTypeAttributesType(d.Type),
+		Required:     d.Required,
+		EnumValues:   enumValues,
+		Pattern:      d.Pattern,
+	})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create attribute in database", "POST /api/v1/device/{id}/attributes?name={name}&type={type}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, models.AttributeDefinition{
+		ID:           int32(id),
+		DeviceTypeID: int32(i),
+		Name:         d.Name,
+		Type:         string(d.Type),
+		Required:     d.Required,
+		Enum:         enum,
+		Pattern:      d.Pattern,
+	})
+}
+
+// DeleteDeviceTypeAttribute remove an attribute from a device type
+//
+//	@Summary		remove an attribute from a device type
+//	@Description	remove an attribute from the schema of a device type along with every value equipment has for it
+//	@Tags			device
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"device id"	minimum(1)
+//	@Param			name	path		string	true	"attribute name"
+//	@Success		200		{object}	models.JsonResponse
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/device/{id}/attributes/{name} [delete]
+func (h *DeviceHandler) DeleteDeviceTypeAttribute(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "DELETE /api/v1/device/{id}/attributes/{name}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "DELETE /api/v1/device/{id}/attributes/{name}")
+		return
+	}
+
+	d, err := q.GetDeviceTypeAttributeByName(r.Context(), sqlc.GetDeviceTypeAttributeByNameParams{DeviceTypeID: int32(i), Name: r.PathValue("name")})
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "attribute does not exist for device", "DELETE /api/v1/device/{id}/attributes/{name}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "DELETE /api/v1/device/{id}/attributes/{name}")
+		return
+	}
+
+	err = q.DeleteDeviceTypeAttribute(r.Context(), d.ID)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to delete attribute in database", "DELETE /api/v1/device/{id}/attributes/{name}")
+		return
+	}
+
+	msg := fmt.Sprintf("attribute %v removed from device with id: %v", d.Name, i)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, msg)
+}
+
+// GetEquipmentAttributes get the attribute values of equipment
+//
+//	@Summary		get the attribute values of equipment
+//	@Description	get the values equipment has for the attributes of its device type
+//	@Tags			equipment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"equipment id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=map[string]string}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/attributes/equipment/{id} [get]
+func (h *EquipmentHandler) GetEquipmentAttributes(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/attributes/equipment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "GET /api/v1/attributes/equipment/{id}")
+		return
+	}
+
+	_, err = q.GetEquipmentByAutoID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id does not exist", "GET /api/v1/attributes/equipment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "GET /api/v1/attributes/equipment/{id}")
+		return
+	}
+
+	values, err := equipmentAttributes(r.Context(), q, []int32{int32(i)})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for attributes", "GET /api/v1/attributes/equipment/{id}")
+		return
+	}
+
+	out := values[int32(i)]
+	if out == nil {
+		out = map[string]string{}
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
+
+// UpdateEquipmentAttributes set attribute values of equipment
+//
+//	@Summary		set attribute values of equipment
+//	@Description	set attribute values of equipment from a JSON object of name to value, a null value clears the attribute and attributes left out keep their value. The result is validated against the device type's schema
+//	@Tags			equipment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"equipment id"	minimum(1)
+//	@Param			attributes	body		map[string]string	true	"attribute values by name"
+//	@Success		200			{object}	models.JsonResponse{MSG=map[string]string}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/equipment/{id}/attributes [patch]
+func (h *EquipmentHandler) UpdateEquipmentAttributes(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "PATCH /api/v1/equipment/{id}/attributes")
+		return
+	}
+
+	var changes map[string]*string
+	if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "body must be a JSON object of attribute names to string values", "PATCH /api/v1/equipment/{id}/attributes")
+		return
+	}
+
+	var out map[string]string
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		out, err = setEquipmentAttributes(r.Context(), q, int32(i), changes)
+		return err
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment/{id}/attributes")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update attributes", "PATCH /api/v1/equipment/{id}/attributes")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
+
+// attributeSchema is the attribute schema of a device type
+type attributeSchema struct {
+	defs []attributes.Definition
+	// ids are the ids of the attributes by name
+	ids map[string]int32
+}
+
+// deviceTypeSchema returns the attribute schema of device type id
+func deviceTypeSchema(ctx context.Context, q *sqlc.Queries, id int32) (attributeSchema, error) {
+	rows, err := q.GetDeviceTypeAttributes(ctx, id)
+	if err != nil {
+		return attributeSchema{}, err
+	}
+	s := attributeSchema{
+		defs: make([]attributes.Definition, 0, len(rows)),
+		ids:  make(map[string]int32, len(rows)),
+	}
+	for _, v := range rows {
+		s.defs = append(s.defs, definitionFromRow(v))
+		s.ids[v.Name] = v.ID
+	}
+	return s, nil
+}
+
+// check returns values normalized, or a statusError listing every unknown, missing or
+// invalid attribute
+func (s attributeSchema) check(values map[string]string) (map[string]string, error) {
+	values, err := attributes.Validate(s.defs, values)
+	if err != nil {
+		return nil, statusError{http.StatusBadRequest, strings.ReplaceAll(err.Error(), "\n", "; ")}
+	}
+	return values, nil
+}
+
+// store replaces the attribute values of equipment id with values, which check has
+// already normalized. q should be bound to a transaction
+func (s attributeSchema) store(ctx context.Context, q *sqlc.Queries, id int32, values map[string]string) error {
+	if err := q.DeleteEquipmentAttributes(ctx, id); err != nil {
+		return err
+	}
+	for name, v := range values {
+		err := q.CreateEquipmentAttribute(ctx, sqlc.CreateEquipmentAttributeParams{EquipmentID: id, AttributeID: s.ids[name], Value: v})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formAttributes reads the attribute values new equipment is created with from its
+// attr.{name} parameters, empty values are left out
+func formAttributes(r *http.Request) map[string]string {
+	r.ParseForm()
+	values := map[string]string{}
+	for key := range r.Form {
+		if name, ok := strings.CutPrefix(key, attributeFilterPrefix); ok && r.Form.Get(key) != "" {
+			values[name] = r.Form.Get(key)
+		}
+	}
+	return values
+}
+
+// setEquipmentAttributes merges changes into the attribute values of equipment id and
+// stores them when the result is valid for its device type, q should be bound to a
+// transaction
+func setEquipmentAttributes(ctx context.Context, q *sqlc.Queries, id int32, changes map[string]*string) (map[string]string, error) {
+	e, err := q.GetEquipmentByAutoIDForUpdate(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, statusError{http.StatusBadRequest, "equipment id does not exist"}
+	} else if err != nil {
+		return nil, err
+	}
+
+	schema, err := deviceTypeSchema(ctx, q, e.DeviceTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := equipmentAttributes(ctx, q, []int32{id})
+	if err != nil {
+		return nil, err
+	}
+	values := current[id]
+	if values == nil {
+		values = map[string]string{}
+	}
+	for name, v := range changes {
+		if v == nil {
+			delete(values, name)
+		} else {
+			values[name] = *v
+		}
+	}
+
+	if values, err = schema.check(values); err != nil {
+		return nil, err
+	}
+	if err := schema.store(ctx, q, id, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,726 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+const (
+	// maxCountNameLength is the size of count_sessions.name
+	maxCountNameLength = 100
+	// maxCountScans is the most codes a request can record
+	maxCountScans = 1000
+)
+
+func countSessionFromRow(v sqlc.CountSession, scans int64) models.CountSession {
+	return models.CountSession{
+		ID:           v.ID,
+		Name:         v.Name,
+		LocationID:   nullInt32(v.LocationID),
+		DeviceTypeID: nullInt32(v.DeviceTypeID),
+		Status:       string(v.Status),
+		Scans:        scans,
+		CreatedAt:    v.CreatedAt,
+		ClosedAt:     nullTime(v.ClosedAt),
+		AppliedAt:    nullTime(v.AppliedAt),
+	}
+}
+
+func countScanFromRow(v sqlc.CountScan) models.CountScan {
+	return models.CountScan{
+		ID:          v.ID,
+		SessionID:   v.SessionID,
+		Code:        v.Code,
+		EquipmentID: nullInt32(v.EquipmentID),
+		LocationID:  nullInt32(v.LocationID),
+		ScannedAt:   v.ScannedAt,
+	}
+}
+
+// countSession returns the count session with id, locked when forUpdate is set
+func countSession(ctx context.Context, q *sqlc.Queries, id int32, forUpdate bool) (sqlc.CountSession, error) {
+	var s sqlc.CountSession
+	var err error
+	if forUpdate {
+		s, err = q.GetCountSessionByIDForUpdate(ctx, id)
+	} else {
+		s, err = q.GetCountSessionByID(ctx, id)
+	}
+	if err == sql.ErrNoRows {
+		return s, statusError{http.StatusBadRequest, "count session id does not exist in database"}
+	}
+	return s, err
+}
+
+// countScope is where a count looks and what it expects to find there
+type countScope struct {
+	session sqlc.CountSession
+	// subtrees are every location with the locations below it, by id
+	subtrees map[int32]map[int32]bool
+}
+
+func newCountScope(ctx context.Context, q *sqlc.Queries, s sqlc.CountSession) (countScope, error) {
+	locations, err := q.GetLocations(ctx)
+	if err != nil {
+		return countScope{}, err
+	}
+	tree := locationTree(locations)
+	c := countScope{session: s, subtrees: map[int32]map[int32]bool{}}
+	for _, v := range locations {
+		in := map[int32]bool{}
+		for _, id := range tree.Subtree(v.ID) {
+			in[id] = true
+		}
+		c.subtrees[v.ID] = in
+	}
+	return c, nil
+}
+
+// at reports whether equipment e is recorded at location l or below it
+func (c countScope) at(e sqlc.SerialNumber, l int32) bool {
+	return e.LocationID.Valid && c.subtrees[l][e.LocationID.Int32]
+}
+
+// counts reports whether the count covers equipment e wherever it is, equipment that
+// is lost, retired or disposed isn't expected to be found
+func (c countScope) counts(e sqlc.SerialNumber) bool {
+	if c.session.DeviceTypeID.Valid && e.DeviceTypeID != c.session.DeviceTypeID.Int32 {
+		return false
+	}
+	return lifecycle.State(e.LifecycleState).Legacy() == "active"
+}
+
+// expects reports whether the count expects to find equipment e
+func (c countScope) expects(e sqlc.SerialNumber) bool {
+	if !c.counts(e) {
+		return false
+	}
+	return !c.session.LocationID.Valid || c.at(e, c.session.LocationID.Int32)
+}
+
+// scanLocation returns the location a code was scanned at, the location of the count
+// when the scan didn't give one
+func (c countScope) scanLocation(v sqlc.CountScan) sql.NullInt32 {
+	if v.LocationID.Valid {
+		return v.LocationID
+	}
+	return c.session.LocationID
+}
+
+// reconcileCount compares the scans of count session s with the equipment it expects.
+// The equipment is compared as it is now, not as it was when the count was opened
+func reconcileCount(ctx context.Context, q *sqlc.Queries, s sqlc.CountSession) (models.CountReconciliation, error) {
+	var rec models.CountReconciliation
+	c, err := newCountScope(ctx, q, s)
+	if err != nil {
+		return rec, err
+	}
+	equipment, err := q.GetEquipmentForCount(ctx, sqlc.GetEquipmentForCountParams{DeviceTypeID: s.DeviceTypeID})
+	if err != nil {
+		return rec, err
+	}
+	scans, err := q.GetCountScans(ctx, s.ID)
+	if err != nil {
+		return rec, err
+	}
+	b, err := newBookValuer(ctx, q, today())
+	if err != nil {
+		return rec, err
+	}
+
+	rec.Session = countSessionFromRow(s, int64(len(scans)))
+	rec.Missing = []models.Equipment{}
+	rec.Unexpected = []models.CountItem{}
+	rec.Elsewhere = []models.CountItem{}
+
+	// NOTE: equipment of other device types is looked up by id, GetEquipmentForCount
+	// only returns the device type being counted
+	byID := map[int32]sqlc.SerialNumber{}
+	for _, v := range equipment {
+		byID[v.AutoID] = v
+	}
+	var others []int32
+	for _, v := range scans {
+		if _, ok := byID[v.EquipmentID.Int32]; v.EquipmentID.Valid && !ok {
+			others = append(others, v.EquipmentID.Int32)
+		}
+	}
+	if len(others) > 0 {
+		rows, err := q.GetEquipmentByAutoIDs(ctx, others)
+		if err != nil {
+			return rec, err
+		}
+		for _, v := range rows {
+			byID[v.AutoID] = v
+		}
+	}
+
+	scanned := map[int32]bool{}
+	for _, v := range scans {
+		where := c.scanLocation(v)
+		item := models.CountItem{Code: v.Code, ScannedLocationID: nullInt32(where)}
+		e, ok := byID[v.EquipmentID.Int32]
+		if !v.EquipmentID.Valid || !ok {
+			item.Reason = "unknown_code"
+			rec.Unexpected = append(rec.Unexpected, item)
+			continue
+		}
+		scanned[e.AutoID] = true
+		out := b.equipment(e)
+		item.Equipment = &out
+
+		switch {
+		case s.DeviceTypeID.Valid && e.DeviceTypeID != s.DeviceTypeID.Int32:
+			item.Reason = "other_device_type"
+			rec.Unexpected = append(rec.Unexpected, item)
+		case !c.counts(e):
+			item.Reason = "lifecycle_state"
+			rec.Unexpected = append(rec.Unexpected, item)
+		case where.Valid && !c.at(e, where.Int32):
+			item.Reason = "other_location"
+			rec.Elsewhere = append(rec.Elsewhere, item)
+		default:
+			rec.Found++
+		}
+	}
+
+	for _, v := range equipment {
+		if !c.expects(v) {
+			continue
+		}
+		rec.Expected++
+		if !scanned[v.AutoID] {
+			rec.Missing = append(rec.Missing, b.equipment(v))
+		}
+	}
+	return rec, nil
+}
+
+// GetCountSessions get all count sessions
+//
+//	@Summary		get all count sessions
+//	@Description	get every physical inventory count, newest first
+//	@Tags			count
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.CountSession}
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/count [get]
+func (h *EquipmentHandler) GetCountSessions(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/count")
+		return
+	}
+
+	d, err := q.GetCountSessions(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for count sessions", "GET /api/v1/count")
+		return
+	}
+
+	s := []models.CountSession{}
+	for _, v := range d {
+		s = append(s, countSessionFromRow(sqlc.CountSession{
+			ID:           v.ID,
+			Name:         v.Name,
+			LocationID:   v.LocationID,
+			DeviceTypeID: v.DeviceTypeID,
+			Status:       v.Status,
+			CreatedAt:    v.CreatedAt,
+			ClosedAt:     v.ClosedAt,
+			AppliedAt:    v.AppliedAt,
+		}, v.Scans))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, s)
+}
+
+// GetCountSession get a count session
+//
+//	@Summary		get a count session
+//	@Description	get a physical inventory count by id
+//	@Tags			count
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"count session id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=models.CountSession}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/count/{id} [get]
+func (h *EquipmentHandler) GetCountSession(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/count/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "count session id is not a number", "GET /api/v1/count/{id}")
+		return
+	}
+
+	s, err := countSession(r.Context(), q, int32(i), false)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/count/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for count session", "GET /api/v1/count/{id}")
+		return
+	}
+	scans, err := q.GetCountScans(r.Context(), s.ID)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for count scans", "GET /api/v1/count/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, countSessionFromRow(s, int64(len(scans))))
+}
+
+// CreateCountSession open a count session
+//
+//	@Summary		open a count session
+//	@Description	open a physical inventory count of a location, sub locations included, a device type or both. Leave both out to count everything
+//	@Tags			count
+//	@Accept			json
+//	@Produce		json
+//	@Param			name		query		string	true	"what is being counted"	maxlength(100)
+//	@Param			location	query		int		false	"location id to count"	minimum(1)
+//	@Param			device		query		int		false	"device id to count"	minimum(1)
+//	@Success		200			{object}	models.JsonResponse{MSG=models.CountSession}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/count [post]
+func (h *EquipmentHandler) CreateCountSession(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing name", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+		return
+	}
+	if utf8.RuneCountInString(name) > maxCountNameLength {
+		helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("name cannot be longer than %d characters", maxCountNameLength), "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+		return
+	}
+
+	var location, device sql.NullInt32
+	if v := r.FormValue("location"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "location id is not a number", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+			return
+		}
+		_, err = q.GetLocationByID(r.Context(), int32(l))
+		if err == sql.ErrNoRows {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "location id does not exist in database", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+			return
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for location", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+			return
+		}
+		location = sql.NullInt32{Int32: int32(l), Valid: true}
+	}
+	if v := r.FormValue("device"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+			return
+		}
+		_, err = q.GetDeviceTypeById(r.Context(), int32(d))
+		if err == sql.ErrNoRows {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "device id does not exist in database", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+			return
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for device type", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+			return
+		}
+		device = sql.NullInt32{Int32: int32(d), Valid: true}
+	}
+
+	id, err := q.CreateCountSession(r.Context(), sqlc.CreateCountSessionParams{Name: name, LocationID: location, DeviceTypeID: device})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create count session in database", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+		return
+	}
+	s, err := q.GetCountSessionByID(r.Context(), int32(id))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for count session", "POST /api/v1/count?name={name}&location={location_id}&device={device_id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, countSessionFromRow(s, 0))
+}
+
+// GetCountScans get the codes scanned in a count session
+//
+//	@Summary		get the codes scanned in a count session
+//	@Description	get every distinct code scanned in a count, in the order they were first scanned
+//	@Tags			count
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"count session id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.CountScan}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/count/{id}/scans [get]
+func (h *EquipmentHandler) GetCountScans(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/count/{id}/scans")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "count session id is not a number", "GET /api/v1/count/{id}/scans")
+		return
+	}
+
+	_, err = countSession(r.Context(), q, int32(i), false)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/count/{id}/scans")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for count session", "GET /api/v1/count/{id}/scans")
+		return
+	}
+
+	d, err := q.GetCountScans(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for count scans", "GET /api/v1/count/{id}/scans")
+		return
+	}
+
+	s := []models.CountScan{}
+	for _, v := range d {
+		s = append(s, countScanFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, s)
+}
+
+// RecordCountScans record codes scanned in a count session
+//
+//	@Summary		record codes scanned in a count session
+//	@Description	record codes scanned in an open count, resolved like POST /scan. Codes that match no equipment are kept and reported as unexpected, scanning equipment again updates where it was scanned. Scanned equipment is marked seen
+//	@Tags			count
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int			true	"count session id"	minimum(1)
+//	@Param			code		query		[]string	true	"scanned codes"	collectionFormat(multi)
+//	@Param			location	query		int			false	"location id the codes were scanned at, defaults to the location of the count"	minimum(1)
+//	@Success		200			{object}	models.JsonResponse{MSG=[]models.CountScan}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		409			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/count/{id}/scans [post]
+func (h *EquipmentHandler) RecordCountScans(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "count session id is not a number", "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "invalid form", "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+		return
+	}
+
+	var codes []string
+	for _, v := range r.Form["code"] {
+		if code := cleanScan(v); code != "" {
+			codes = append(codes, code)
+		}
+		if len(v) > maxScanLength {
+			helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("code cannot be longer than %d characters", maxScanLength), "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+			return
+		}
+	}
+	if len(codes) == 0 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing code", "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+		return
+	}
+	if len(codes) > maxCountScans {
+		helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("at most %d codes can be recorded at once", maxCountScans), "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+		return
+	}
+	var location sql.NullInt32
+	if v := r.Form.Get("location"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "location id is not a number", "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+			return
+		}
+		location = sql.NullInt32{Int32: int32(l), Valid: true}
+	}
+
+	var scans []models.CountScan
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		s, err := countSession(r.Context(), q, int32(i), true)
+		if err != nil {
+			return err
+		}
+		if s.Status != sqlc.CountSessionsStatusOpen {
+			return statusError{http.StatusConflict, "count session is closed"}
+		}
+		if location.Valid {
+			c, err := newCountScope(r.Context(), q, s)
+			if err != nil {
+				return err
+			}
+			if _, ok := c.subtrees[location.Int32]; !ok {
+				return statusError{http.StatusBadRequest, "location id does not exist in database"}
+			}
+			if s.LocationID.Valid && !c.subtrees[s.LocationID.Int32][location.Int32] {
+				return statusError{http.StatusBadRequest, "location is outside the location being counted"}
+			}
+		}
+
+		for _, code := range codes {
+			var equipment sql.NullInt32
+			e, _, err := resolveScan(r.Context(), q, code)
+			if _, ok := asStatusError(err); ok {
+				// NOTE: unknown codes are recorded, they are reported as unexpected
+			} else if err != nil {
+				return err
+			} else {
+				equipment = sql.NullInt32{Int32: e.AutoID, Valid: true}
+			}
+
+			var id int32
+			prev, err := q.GetCountScanByEquipment(r.Context(), sqlc.GetCountScanByEquipmentParams{SessionID: s.ID, EquipmentID: equipment})
+			if equipment.Valid && err == nil {
+				id = prev.ID
+				err = q.UpdateCountScan(r.Context(), sqlc.UpdateCountScanParams{ID: id, Code: code, LocationID: location})
+			} else if equipment.Valid && err != sql.ErrNoRows {
+				return err
+			} else {
+				var n int64
+				n, err = q.CreateCountScan(r.Context(), sqlc.CreateCountScanParams{SessionID: s.ID, Code: code, EquipmentID: equipment, LocationID: location})
+				id = int32(n)
+			}
+			if err != nil {
+				return err
+			}
+
+			if equipment.Valid {
+				where := location
+				if !where.Valid {
+					where = s.LocationID
+				}
+				err = q.UpdateEquipmentLastSeen(r.Context(), sqlc.UpdateEquipmentLastSeenParams{AutoID: equipment.Int32, LocationID: where})
+				if err != nil {
+					return err
+				}
+			}
+			scans = append(scans, models.CountScan{
+				ID:          id,
+				SessionID:   s.ID,
+				Code:        code,
+				EquipmentID: nullInt32(equipment),
+				LocationID:  nullInt32(location),
+			})
+		}
+		return nil
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to record count scans", "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+		return
+	}
+
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+		return
+	}
+	// NOTE: scanned_at is set by the database, read back so the response matches GET
+	d, err := q.GetCountScans(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for count scans", "POST /api/v1/count/{id}/scans?code={code}&location={location_id}")
+		return
+	}
+	byID := map[int32]sqlc.CountScan{}
+	for _, v := range d {
+		byID[v.ID] = v
+	}
+	for n, v := range scans {
+		scans[n] = countScanFromRow(byID[v.ID])
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, scans)
+}
+
+// GetCountReconciliation get the reconciliation of a count session
+//
+//	@Summary		get the reconciliation of a count session
+//	@Description	compare what a count scanned with what it expected: expected equipment that was not scanned is missing, codes that match no equipment, equipment of another device type and lost, retired or disposed equipment are unexpected, and equipment scanned away from its recorded location is elsewhere. Equipment is compared as it is now
+//	@Tags			count
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"count session id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=models.CountReconciliation}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/count/{id}/reconciliation [get]
+func (h *EquipmentHandler) GetCountReconciliation(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/count/{id}/reconciliation")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "count session id is not a number", "GET /api/v1/count/{id}/reconciliation")
+		return
+	}
+
+	s, err := countSession(r.Context(), q, int32(i), false)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/count/{id}/reconciliation")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for count session", "GET /api/v1/count/{id}/reconciliation")
+		return
+	}
+
+	rec, err := reconcileCount(r.Context(), q, s)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to reconcile count", "GET /api/v1/count/{id}/reconciliation")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, rec)
+}
+
+// ApplyCountSession apply the results of a count session
+//
+//	@Summary		apply the results of a count session
+//	@Description	apply the reconciliation of an open count and close it. With mark_lost missing equipment moves to lost, equipment the lifecycle doesn't allow to be lost is skipped. With move equipment found elsewhere moves to where it was scanned
+//	@Tags			count
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"count session id"	minimum(1)
+//	@Param			mark_lost	query		bool	false	"move missing equipment to lost"
+//	@Param			move		query		bool	false	"move equipment found elsewhere to where it was scanned"
+//	@Success		200			{object}	models.JsonResponse{MSG=models.CountApplyResult}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		409			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/count/{id}/apply [post]
+func (h *EquipmentHandler) ApplyCountSession(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "count session id is not a number", "POST /api/v1/count/{id}/apply?mark_lost={mark_lost}&move={move}")
+		return
+	}
+	markLost := r.FormValue("mark_lost") == "true"
+	move := r.FormValue("move") == "true"
+
+	res := models.CountApplyResult{MarkedLost: []int32{}, Moved: []int32{}, Skipped: []models.CountSkip{}}
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		s, err := countSession(r.Context(), q, int32(i), true)
+		if err != nil {
+			return err
+		}
+		if s.Status != sqlc.CountSessionsStatusOpen {
+			return statusError{http.StatusConflict, "count session is closed"}
+		}
+		rec, err := reconcileCount(r.Context(), q, s)
+		if err != nil {
+			return err
+		}
+
+		if markLost {
+			for _, v := range rec.Missing {
+				_, err := h.transition(r.Context(), q, v.AutoID, lifecycle.Lost, fmt.Sprintf("missing in count %d", s.ID))
+				if errors.Is(err, lifecycle.ErrIllegalTransition) {
+					res.Skipped = append(res.Skipped, models.CountSkip{EquipmentID: v.AutoID, Reason: err.Error()})
+					continue
+				} else if err != nil {
+					return err
+				}
+				res.MarkedLost = append(res.MarkedLost, v.AutoID)
+			}
+		}
+		if move {
+			for _, v := range rec.Elsewhere {
+				if err := moveEquipment(r.Context(), q, v.Equipment.AutoID, *v.ScannedLocationID, fmt.Sprintf("found in count %d", s.ID)); err != nil {
+					return err
+				}
+				res.Moved = append(res.Moved, v.Equipment.AutoID)
+			}
+		}
+		return q.ApplyCountSession(r.Context(), s.ID)
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/count/{id}/apply?mark_lost={mark_lost}&move={move}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to apply count", "POST /api/v1/count/{id}/apply?mark_lost={mark_lost}&move={move}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, res)
+}
+
+// CloseCountSession close a count session
+//
+//	@Summary		close a count session
+//	@Description	close an open count without changing any equipment, its reconciliation can still be read
+//	@Tags			count
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"count session id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		409	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/count/{id}/close [post]
+func (h *EquipmentHandler) CloseCountSession(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "count session id is not a number", "POST /api/v1/count/{id}/close")
+		return
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		s, err := countSession(r.Context(), q, int32(i), true)
+		if err != nil {
+			return err
+		}
+		if s.Status != sqlc.CountSessionsStatusOpen {
+			return statusError{http.StatusConflict, "count session is already closed"}
+		}
+		return q.CloseCountSession(r.Context(), s.ID)
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/count/{id}/close")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to close count session", "POST /api/v1/count/{id}/close")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, fmt.Sprintf("count session with id: %v closed", i))
+}
@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/depreciation"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+func depreciationFromRow(v sqlc.DepreciationSchedule) depreciation.Schedule {
+	return depreciation.Schedule{
+		Method:         depreciation.Method(v.Method),
+		LifeMonths:     int(v.LifeMonths),
+		SalvagePercent: int(v.SalvagePercent),
+		RatePercent:    int(v.RatePercent),
+	}
+}
+
+func depreciationScheduleModel(deviceTypeID int32, s depreciation.Schedule, isDefault bool) models.DepreciationSchedule {
+	return models.DepreciationSchedule{
+		DeviceTypeID:   deviceTypeID,
+		Method:         string(s.Method),
+		LifeMonths:     int32(s.LifeMonths),
+		SalvagePercent: int32(s.SalvagePercent),
+		RatePercent:    int32(s.RatePercent),
+		Default:        isDefault,
+	}
+}
+
+// bookValuer values equipment with the depreciation schedule of its device type
+type bookValuer struct {
+	schedules map[int32]depreciation.Schedule
+	asOf      time.Time
+}
+
+// newBookValuer returns a bookValuer valuing equipment on asOf
+func newBookValuer(ctx context.Context, q *sqlc.Queries, asOf time.Time) (bookValuer, error) {
+	b := bookValuer{schedules: map[int32]depreciation.Schedule{}, asOf: asOf}
+	rows, err := q.GetDepreciationSchedules(ctx)
+	if err != nil {
+		return b, err
+	}
+	for _, v := range rows {
+		b.schedules[v.DeviceTypeID] = depreciationFromRow(v)
+	}
+	return b, nil
+}
+
+// value returns what v is worth in cents, false when its cost or purchase date is unknown
+func (b bookValuer) value(v sqlc.SerialNumber) (int64, bool) {
+	if !v.Cost.Valid || !v.PurchaseDate.Valid {
+		return 0, false
+	}
+	s, ok := b.schedules[v.DeviceTypeID]
+	if !ok {
+		s = depreciation.Schedule{Method: depreciation.None}
+	}
+	return s.Value(cents(v.Cost.String), v.PurchaseDate.Time, b.asOf), true
+}
+
+// equipment returns the api model of v with its book value
+func (b bookValuer) equipment(v sqlc.SerialNumber) models.Equipment {
+	e := equipmentFromRow(v)
+	if c, ok := b.value(v); ok {
+		n := json.Number(formatCents(c))
+		e.BookValue = &n
+	}
+	return e
+}
+
+// GetDepreciationSchedule get the depreciation schedule of a device type
+//
+//	@Summary		get the depreciation schedule of a device type
+//	@Description	get how equipment of a device type loses value, device types without a schedule keep their cost
+//	@Tags			device
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"device id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=models.DepreciationSchedule}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/device/{id}/depreciation [get]
+func (h *DeviceHandler) GetDepreciationSchedule(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "GET /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	_, err = q.GetDeviceTypeById(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id does not exist in database", "GET /api/v1/device/{id}/depreciation")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for device", "GET /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	v, err := q.GetDepreciationSchedule(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseSuccess(w, http.StatusOK, depreciationScheduleModel(int32(i), depreciation.Schedule{Method: depreciation.None}, true))
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedule", "GET /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, depreciationScheduleModel(int32(i), depreciationFromRow(v), false))
+}
+
+// UpdateDepreciationSchedule update the depreciation schedule of a device type
+//
+//	@Summary		update the depreciation schedule of a device type
+//	@Description	change how equipment of a device type loses value, only the given fields change. method and life_months are needed when the device type has no schedule yet
+//	@Tags			device
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int		true	"device id"	minimum(1)
+//	@Param			method			query		string	false	"how the value goes down"	Enums(straight_line, declining_balance)
+//	@Param			life_months		query		int		false	"months the equipment is used"	minimum(1)	maximum(600)
+//	@Param			salvage_percent	query		int		false	"share of the cost left at the end of its life"	minimum(0)	maximum(100)
+//	@Param			rate_percent	query		int		false	"share of the remaining value lost every year, needed by declining_balance"	minimum(1)	maximum(100)
+//	@Success		200				{object}	models.JsonResponse{MSG=models.DepreciationSchedule}
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/device/{id}/depreciation [patch]
+func (h *DeviceHandler) UpdateDepreciationSchedule(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "PATCH /api/v1/device/{id}/depreciation")
+		return
+	}
+	id := int32(i)
+
+	r.ParseForm()
+	var s depreciation.Schedule
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		_, err := q.GetDeviceTypeById(r.Context(), id)
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "device id does not exist in database"}
+		} else if err != nil {
+			return err
+		}
+
+		v, err := q.GetDepreciationSchedule(r.Context(), id)
+		if err == nil {
+			s = depreciationFromRow(v)
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		if r.Form.Has("method") {
+			s.Method = depreciation.Method(r.Form.Get("method"))
+		}
+		numbers := []struct {
+			name  string
+			value *int
+		}{
+			{"life_months", &s.LifeMonths},
+			{"salvage_percent", &s.SalvagePercent},
+			{"rate_percent", &s.RatePercent},
+		}
+		for _, n := range numbers {
+			if !r.Form.Has(n.name) {
+				continue
+			}
+			*n.value, err = strconv.Atoi(r.Form.Get(n.name))
+			if err != nil {
+				return statusError{http.StatusBadRequest, n.name + " is not a number"}
+			}
+		}
+		if err := s.Check(); err != nil {
+			return statusError{http.StatusBadRequest, err.Error()}
+		}
+
+		return q.SetDepreciationSchedule(r.Context(), sqlc.SetDepreciationScheduleParams{
+			DeviceTypeID:   id,
+			Method:         sqlc.DepreciationSchedulesMethod(s.Method),
+			LifeMonths:     int32(s.LifeMonths),
+			SalvagePercent: int32(s.SalvagePercent),
+			RatePercent:    int32(s.RatePercent),
+		})
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/device/{id}/depreciation")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update depreciation schedule in database", "PATCH /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, depreciationScheduleModel(id, s, false))
+}
+
+// DeleteDepreciationSchedule delete the depreciation schedule of a device type
+//
+//	@Summary		delete the depreciation schedule of a device type
+//	@Description	delete the depreciation schedule of a device type, its equipment keeps its cost from then on
+//	@Tags			device
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"device id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/device/{id}/depreciation [delete]
+func (h *DeviceHandler) DeleteDepreciationSchedule(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "DELETE /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "DELETE /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	_, err = q.GetDepreciationSchedule(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device has no depreciation schedule", "DELETE /api/v1/device/{id}/depreciation")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedule", "DELETE /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	if err := q.DeleteDepreciationSchedule(r.Context(), int32(i)); err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to delete depreciation schedule from database", "DELETE /api/v1/device/{id}/depreciation")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, "depreciation schedule deleted")
+}
+
+// valuationTally adds up the equipment of one device type, manufacturer or location
+type valuationTally struct {
+	report models.ValuationReport
+	cost   int64
+	value  int64
+}
+
+// valuationReport values the equipment bought by asOf, grouped by device type, manufacturer
+// or location
+func valuationReport(ctx context.Context, q *sqlc.Queries, group string, asOf time.Time) ([]models.ValuationReport, error) {
+	b, err := newBookValuer(ctx, q, asOf)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.GetEquipmentPurchasedBy(ctx, sql.NullTime{Time: asOf, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: equipment without a location is tallied under 0, location ids start at 1
+	tallies := map[int32]*valuationTally{}
+	for _, v := range rows {
+		var key int32
+		switch group {
+		case "device":
+			key = v.DeviceTypeID
+		case "location":
+			key = v.LocationID.Int32
+		default:
+			key = v.ManufacturerID
+		}
+		t, ok := tallies[key]
+		if !ok {
+			t = &valuationTally{}
+			if key != 0 {
+				id := key
+				t.report.ID = &id
+			}
+			tallies[key] = t
+		}
+
+		value, ok := b.value(v)
+		if !ok {
+			t.report.Unpriced++
+			continue
+		}
+		t.report.Equipment++
+		t.cost += cents(v.Cost.String)
+		t.value += value
+	}
+
+	names := map[int32]string{}
+	switch group {
+	case "device":
+		d, err := q.GetDeviceTypes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range d {
+			names[v.ID] = v.Name
+		}
+	case "location":
+		l, err := q.GetLocations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range l {
+			names[v.ID] = v.Name
+		}
+	default:
+		m, err := q.GetManufacturers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range m {
+			names[v.ID] = v.Name
+		}
+	}
+
+	reports := make([]models.ValuationReport, 0, len(tallies))
+	for key, t := range tallies {
+		rep := t.report
+		rep.Name = names[key]
+		rep.Cost = json.Number(formatCents(t.cost))
+		rep.BookValue = json.Number(formatCents(t.value))
+		rep.Depreciation = json.Number(formatCents(t.cost - t.value))
+		reports = append(reports, rep)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].ID == nil || reports[j].ID == nil {
+			return reports[j].ID == nil && reports[i].ID != nil
+		}
+		return *reports[i].ID < *reports[j].ID
+	})
+	return reports, nil
+}
+
+// GetValuationReport get the book value of equipment
+//
+//	@Summary		get the book value of equipment
+//	@Description	get what the equipment bought by as_of cost and was worth on as_of after depreciation, per device type, manufacturer or location. Disposed equipment and equipment without a purchase date are left out, equipment without a cost is counted as unpriced. Equipment is grouped by its current location
+//	@Tags			valuation
+//	@Accept			json
+//	@Produce		json
+//	@Param			group	query		string	false	"what to group the equipment by"	Enums(device, manufacturer, location)	default(device)
+//	@Param			as_of	query		string	false	"day to value the equipment on like 2024-12-31, defaults to today"
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.ValuationReport}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/valuation [get]
+func (h *EquipmentHandler) GetValuationReport(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/valuation?group={group}&as_of={as_of}")
+		return
+	}
+
+	group := r.FormValue("group")
+	if group == "" {
+		group = "device"
+	}
+	if group != "device" && group != "manufacturer" && group != "location" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "group must be device, manufacturer or location", "GET /api/v1/valuation?group={group}&as_of={as_of}")
+		return
+	}
+
+	asOf, err := parseDate("as_of", r.FormValue("as_of"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/valuation?group={group}&as_of={as_of}")
+		return
+	}
+	if !asOf.Valid {
+		asOf = sql.NullTime{Time: today(), Valid: true}
+	}
+
+	reports, err := valuationReport(r.Context(), q, group, asOf.Time)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/valuation?group={group}&as_of={as_of}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, reports)
+}
@@ -13,38 +13,86 @@ import (
 	"github.com/coltonmosier/api-v1/internal/sqlc"
 )
 
-type DeviceHandler struct{}
+type DeviceHandler struct {
+	// BaseURL is the url the API is reachable on, used for internal lookups
+	BaseURL string
+}
 
 // GetDeviceTypes Getting all device types
 //	@Summary		get all device types
-//	@Description	get all device types from the database
+//	@Description	get device types from the database, filtered by status and name and paged with limit and offset. X-Total-Count holds how many device types match across all pages
 //	@Tags			device
 //	@x-order		1
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	models.JsonResponse{MSG=[]models.DeviceType}
-//	@Failure		500	{object}	models.JsonResponse 
+//	@Param			status	query		string	false	"device type status"	Enums(active, inactive)
+//	@Param			name	query		string	false	"part of the device type name"
+//	@Param			limit	query		int		false	"most device types to return, every one when left out"	minimum(1)	maximum(1000)
+//	@Param			offset	query		int		false	"device types to skip"	minimum(0)
+//	@Param			counts	query		bool	false	"include how much equipment of every device type is active and inactive"
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.DeviceType}
+//	@Header			200		{integer}	X-Total-Count	"device types matching the filters"
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
 //	@Router			/device [get]
 func (h *DeviceHandler) GetDeviceTypes(w http.ResponseWriter, r *http.Request) {
-	var out []models.DeviceType
+	out := []models.DeviceType{}
 	q, err := database.InitEquipmentDatabase()
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/device")
 		return
 	}
-	d, err := q.GetDeviceTypesActive(r.Context())
+
+	p, err := parseListParams(r)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/device")
+		return
+	}
+	status := sqlc.NullDeviceTypeStatus{DeviceTypeStatus: sqlc.DeviceTypeStatus(p.status), Valid: p.status != ""}
+
+	d, err := q.ListDeviceTypes(r.Context(), sqlc.ListDeviceTypesParams{Status: status, Name: p.name, Limit: p.limit, Offset: p.offset})
 	if err != nil {
-		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/device")
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for device types", "GET /api/v1/device")
 		return
 	}
+	total, err := q.CountDeviceTypes(r.Context(), sqlc.CountDeviceTypesParams{Status: status, Name: p.name})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for device types", "GET /api/v1/device")
+		return
+	}
+
+	counts := map[int32]*models.EquipmentCounts{}
+	if p.counts && len(d) > 0 {
+		ids := make([]int32, len(d))
+		for i, v := range d {
+			ids[i] = v.ID
+		}
+		c, err := q.CountEquipmentByDeviceType(r.Context(), ids)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment counts", "GET /api/v1/device")
+			return
+		}
+		for _, v := range c {
+			counts[v.ID] = &models.EquipmentCounts{Active: v.Active, Inactive: v.Inactive}
+		}
+	}
+
 	for _, v := range d {
-		out = append(out, models.DeviceType{
+		m := models.DeviceType{
 			ID:     v.ID,
 			Name:   v.Name,
 			Status: string(v.Status),
-		})
+		}
+		if p.counts {
+			m.Equipment = counts[v.ID]
+			if m.Equipment == nil {
+				m.Equipment = &models.EquipmentCounts{}
+			}
+		}
+		out = append(out, m)
 	}
 
+	setTotalCount(w, total)
 	helpers.JsonResponseSuccess(w, http.StatusOK, out)
 }
 
@@ -124,7 +172,7 @@ func (h *DeviceHandler) UpdateDeviceTypeName(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/device/" + id)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + id)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device/"+id, "PATCH /api/v1/device/{id}/name?name={newName}")
 		return
@@ -191,7 +239,7 @@ func (h *DeviceHandler) UpdateDeviceType(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/device/" + id)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + id)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device/"+id, "PATCH /api/v1/device/{id}/status?status={newStatus}")
 		return
@@ -260,7 +308,7 @@ func (h *DeviceHandler) CreateDeviceType(w http.ResponseWriter, r *http.Request)
         return
     }
 
-    req, err := http.Get("http://localhost:8081/api/v1/device")
+    req, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/device")
     if err != nil {
         helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device", "POST /api/v1/device?name={newName}")
         return
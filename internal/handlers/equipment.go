@@ -3,18 +3,50 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/coltonmosier/api-v1/internal/database"
 	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
 	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/serial"
 	"github.com/coltonmosier/api-v1/internal/sqlc"
 )
 
-type EquipmentHandler struct{}
+type EquipmentHandler struct {
+	// BaseURL is the url the API is reachable on, used for internal lookups
+	BaseURL string
+	// Lifecycle holds the lifecycle transitions equipment may make
+	Lifecycle *lifecycle.Machine
+}
+
+// equipmentFromRow returns the api model of a serial_numbers row
+func equipmentFromRow(v sqlc.SerialNumber) models.Equipment {
+	return models.Equipment{
+		AutoID:         v.AutoID,
+		DeviceTypeID:   v.DeviceTypeID,
+		ManufacturerID: v.ManufacturerID,
+		SerialNumber:   v.SerialNumber,
+		Status:         string(v.Status),
+		LifecycleState: string(v.LifecycleState),
+		LocationID:     nullInt32(v.LocationID),
+		ProductModelID: nullInt32(v.ProductModelID),
+		PurchaseDate:   nullDate(v.PurchaseDate),
+		Vendor:         v.Vendor,
+		PurchaseOrder:  v.PurchaseOrder,
+		Cost:           nullCost(v.Cost),
+		WarrantyStart:  nullDate(v.WarrantyStart),
+		WarrantyEnd:    nullDate(v.WarrantyEnd),
+		LastSeenAt:     nullTime(v.LastSeenAt),
+		LastSeenLocationID: nullInt32(v.LastSeenLocationID),
+		CreatedAt:      v.CreatedAt,
+	}
+}
 
 func (h *EquipmentHandler) BadEndpointHandler(w http.ResponseWriter, r *http.Request) {
 	helpers.JsonResponseError(w, http.StatusNotFound, "endpoint not found", "none")
@@ -27,9 +59,13 @@ func (h *EquipmentHandler) BadEndpointHandler(w http.ResponseWriter, r *http.Req
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
-//	@Param			all	query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
-//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Equipment}
-//	@Failure		500	{object}	models.JsonResponse
+//	@Param			all		query		bool		true	"set to true to get all equipment, otherwise only active equipment is returned"
+//	@Param			tag		query		[]string	false	"only equipment carrying all these tags, repeated or comma separated"	collectionFormat(multi)
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
 //	@Router			/equipment [get]
 func (h *EquipmentHandler) GetEquipments(w http.ResponseWriter, r *http.Request) {
 	q, err := database.InitEquipmentDatabase()
@@ -37,41 +73,62 @@ func (h *EquipmentHandler) GetEquipments(w http.ResponseWriter, r *http.Request)
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/equipment")
 		return
 	}
-	d, err := q.GetAllEquipment(r.Context())
+	r.ParseForm()
+	tags, err := parseTagNames(r.Form["tag"])
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/equipment")
+		return
+	}
+	var d []sqlc.SerialNumber
+	if len(tags) > 0 {
+		p := sqlc.SearchEquipmentParams{TagNames: tags, TagCount: sql.NullInt32{Int32: int32(len(tags)), Valid: true}, Limit: exportPageSize}
+		if r.FormValue("all") != "true" {
+			p.Status = sqlc.NullSerialNumbersStatus{SerialNumbersStatus: sqlc.SerialNumbersStatusActive, Valid: true}
+		}
+		// NOTE: every equipment carrying the tags is returned, a page at a time
+		for {
+			var page []sqlc.SerialNumber
+			page, err = q.SearchEquipment(r.Context(), p)
+			if err != nil {
+				break
+			}
+			d = append(d, page...)
+			if len(page) < int(p.Limit) {
+				break
+			}
+			p.AfterID = page[len(page)-1].AutoID
+		}
+	} else {
+		d, err = q.GetAllEquipment(r.Context())
+	}
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "GET /api/v1/equipment")
 		return
 	}
 
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment")
+		return
+	}
+
 	all := r.FormValue("all")
 	if all == "true" {
 		var e []models.Equipment
 		for _, v := range d {
-			e = append(e, models.Equipment{
-				AutoID:         v.AutoID,
-				DeviceTypeID:   v.DeviceTypeID,
-				ManufacturerID: v.ManufacturerID,
-				SerialNumber:   v.SerialNumber,
-				Status:         string(v.Status),
-			})
+			e = append(e, b.equipment(v))
 		}
 
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment")
 		return
 	} else {
 		var e []models.Equipment
 		for _, v := range d {
 			if v.Status == sqlc.SerialNumbersStatusActive {
-				e = append(e, models.Equipment{
-					AutoID:         v.AutoID,
-					DeviceTypeID:   v.DeviceTypeID,
-					ManufacturerID: v.ManufacturerID,
-					SerialNumber:   v.SerialNumber,
-					Status:         string(v.Status),
-				})
+				e = append(e, b.equipment(v))
 			}
 		}
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment")
 		return
 	}
 }
@@ -79,14 +136,16 @@ func (h *EquipmentHandler) GetEquipments(w http.ResponseWriter, r *http.Request)
 // GetEquipmentBySN get equipment by serial number
 //
 //	@Summary		get equipment by serial number
-//	@Description	get equipment by serial number from the database
+//	@Description	get equipment by serial number from the database, the serial number is matched in its canonical form so case and separators don't matter
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
-//	@Param			sn	query		string	true	"serial number"
-//	@Success		200	{object}	models.JsonResponse{MSG=models.Equipment}
-//	@Failure		400	{object}	models.JsonResponse
-//	@Failure		500	{object}	models.JsonResponse
+//	@Param			sn		query		string	true	"serial number"
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
 //	@Router			/equipment/sn [get]
 func (h *EquipmentHandler) GetEquipmentBySN(w http.ResponseWriter, r *http.Request) {
 	q, err := database.InitEquipmentDatabase()
@@ -101,18 +160,18 @@ func (h *EquipmentHandler) GetEquipmentBySN(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	s := strings.Split(sn, "-")[0]
-	if s != "SN" {
-		helpers.JsonResponseError(w, http.StatusBadRequest, "serial number must start with SN-", "GET /api/v1/equipment/sn?sn=sn")
+	if len(sn) > serial.MaxLength {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "serial number cannot be longer than 68 characters", "GET /api/v1/equipment/sn?sn=sn")
 		return
 	}
 
-	if len(sn) > 68 {
-		helpers.JsonResponseError(w, http.StatusBadRequest, "serial number cannot be longer than 68 characters", "GET /api/v1/equipment/sn?sn=sn")
+	ns, err := serialNormalizers(r.Context(), q)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/equipment/sn?sn=sn")
 		return
 	}
 
-	d, err := q.GetEquipmentBySerialNumber(r.Context(), sn)
+	d, err := findBySerial(r.Context(), q, ns, sn)
 	if err == sql.ErrNoRows {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment/serial number does not exist in database", "GET /api/v1/equipment")
 		return
@@ -121,15 +180,15 @@ func (h *EquipmentHandler) GetEquipmentBySN(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	e := models.Equipment{
-		AutoID:         d.AutoID,
-		DeviceTypeID:   d.DeviceTypeID,
-		ManufacturerID: d.ManufacturerID,
-		SerialNumber:   d.SerialNumber,
-        Status:        string(d.Status),
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/sn?sn=sn")
+		return
 	}
 
-	helpers.JsonResponseSuccess(w, http.StatusOK, e)
+	e := b.equipment(d)
+
+	respondEquipmentOne(w, r, q, e, "GET /api/v1/equipment/sn?sn=sn")
 }
 
 // GetEquipmentByID get equipment by auto ID
@@ -139,10 +198,12 @@ func (h *EquipmentHandler) GetEquipmentBySN(w http.ResponseWriter, r *http.Reque
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	query		int	true	"auto_id"	minimum(1)
-//	@Success		200	{object}	models.JsonResponse{MSG=models.Equipment}
-//	@Failure		400	{object}	models.JsonResponse
-//	@Failure		500	{object}	models.JsonResponse
+//	@Param			id		query		int	true	"auto_id"	minimum(1)
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
 //	@Router			/equipment/id [get]
 func (h *EquipmentHandler) GetEquipmentByID(w http.ResponseWriter, r *http.Request) {
 	q, err := database.InitEquipmentDatabase()
@@ -171,15 +232,15 @@ func (h *EquipmentHandler) GetEquipmentByID(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	e := models.Equipment{
-		AutoID:         d.AutoID,
-		DeviceTypeID:   d.DeviceTypeID,
-		ManufacturerID: d.ManufacturerID,
-		SerialNumber:   d.SerialNumber,
-		Status:         string(d.Status),
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/id?id={id}")
+		return
 	}
 
-	helpers.JsonResponseSuccess(w, http.StatusOK, e)
+	e := b.equipment(d)
+
+	respondEquipmentOne(w, r, q, e, "GET /api/v1/equipment/id?id={id}")
 }
 
 // GetEquipmentLikeSn get equipment like serial number
@@ -189,11 +250,13 @@ func (h *EquipmentHandler) GetEquipmentByID(w http.ResponseWriter, r *http.Reque
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
-//	@Param			sn	path		string	true	"serial number"
-//	@Param			all	query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
-//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Equipment}
-//	@Failure		400	{object}	models.JsonResponse
-//	@Failure		500	{object}	models.JsonResponse
+//	@Param			sn		path		string	true	"serial number"
+//	@Param			all		query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
 //	@Router			/equipment/sn-like/{sn} [get]
 func (h *EquipmentHandler) GetEquipmentLikeSN(w http.ResponseWriter, r *http.Request) {
 	q, err := database.InitEquipmentDatabase()
@@ -208,9 +271,16 @@ func (h *EquipmentHandler) GetEquipmentLikeSN(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	sn = "%" + sn + "%"
+	ns, err := serialNormalizers(r.Context(), q)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/equipment/sn-like/{sn}")
+		return
+	}
+
+	var arg sqlc.GetEquipmentLikeSerialNumberParams
+	arg.SerialNumber, arg.CanonicalPattern = likeSerials(ns, sn)
 
-	d, err := q.GetEquipmentLikeSerialNumber(r.Context(), sn)
+	d, err := q.GetEquipmentLikeSerialNumber(r.Context(), arg)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment "+err.Error(), "GET /api/v1/equipment/sn-like/{sn}")
 		return
@@ -220,35 +290,29 @@ func (h *EquipmentHandler) GetEquipmentLikeSN(w http.ResponseWriter, r *http.Req
 		helpers.JsonResponseError(w, http.StatusBadRequest, "no equipment found", "GET /api/v1/equipment/sn-like/{sn}")
 		return
 	}
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/sn-like/{sn}")
+		return
+	}
+
 	all := r.FormValue("all")
 	if all == "true" {
 		var e []models.Equipment
 		for _, v := range d {
-			e = append(e, models.Equipment{
-				AutoID:         v.AutoID,
-				DeviceTypeID:   v.DeviceTypeID,
-				ManufacturerID: v.ManufacturerID,
-				SerialNumber:   v.SerialNumber,
-				Status:         string(v.Status),
-			})
+			e = append(e, b.equipment(v))
 		}
 
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/sn-like/{sn}")
 		return
 	} else {
 		var e []models.Equipment
 		for _, v := range d {
 			if v.Status == sqlc.SerialNumbersStatusActive {
-				e = append(e, models.Equipment{
-					AutoID:         v.AutoID,
-					DeviceTypeID:   v.DeviceTypeID,
-					ManufacturerID: v.ManufacturerID,
-					SerialNumber:   v.SerialNumber,
-					Status:         string(v.Status),
-				})
+				e = append(e, b.equipment(v))
 			}
 		}
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/sn-like/{sn}")
 		return
 	}
 }
@@ -260,11 +324,13 @@ func (h *EquipmentHandler) GetEquipmentLikeSN(w http.ResponseWriter, r *http.Req
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int		true	"manufacturer id"	minimum(1)
-//	@Param			all	query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
-//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Equipment}
-//	@Failure		400	{object}	models.JsonResponse
-//	@Failure		500	{object}	models.JsonResponse
+//	@Param			id		path		int		true	"manufacturer id"	minimum(1)
+//	@Param			all		query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
 //	@Router			/equipment/manufacturer/{id} [get]
 func (h *EquipmentHandler) GetEquipmentByManufacturerID(w http.ResponseWriter, r *http.Request) {
 	q, err := database.InitEquipmentDatabase()
@@ -284,7 +350,7 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerID(w http.ResponseWriter, r
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/manufacturer/" + manufacturerID)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + manufacturerID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+manufacturerID, "GET /api/v1/equipment/manufacturer/{id}")
 		return
@@ -313,35 +379,29 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerID(w http.ResponseWriter, r
 		helpers.JsonResponseError(w, http.StatusBadRequest, "no equipment found", "GET /api/v1/equipment/manufacturer/{id}")
 		return
 	}
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/manufacturer/{id}")
+		return
+	}
+
 	all := r.FormValue("all")
 	if all == "true" {
 		var e []models.Equipment
 		for _, v := range d {
-			e = append(e, models.Equipment{
-				AutoID:         v.AutoID,
-				DeviceTypeID:   v.DeviceTypeID,
-				ManufacturerID: v.ManufacturerID,
-				SerialNumber:   v.SerialNumber,
-				Status:         string(v.Status),
-			})
+			e = append(e, b.equipment(v))
 		}
 
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/manufacturer/{id}")
 		return
 	} else {
 		var e []models.Equipment
 		for _, v := range d {
 			if v.Status == sqlc.SerialNumbersStatusActive {
-				e = append(e, models.Equipment{
-					AutoID:         v.AutoID,
-					DeviceTypeID:   v.DeviceTypeID,
-					ManufacturerID: v.ManufacturerID,
-					SerialNumber:   v.SerialNumber,
-					Status:         string(v.Status),
-				})
+				e = append(e, b.equipment(v))
 			}
 		}
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/manufacturer/{id}")
 		return
 	}
 }
@@ -353,11 +413,13 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerID(w http.ResponseWriter, r
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int		true	"device id"	minimum(1)
-//	@Param			all	query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
-//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Equipment}
-//	@Failure		400	{object}	models.JsonResponse
-//	@Failure		500	{object}	models.JsonResponse
+//	@Param			id		path		int		true	"device id"	minimum(1)
+//	@Param			all		query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
 //	@Router			/equipment/device/{id} [get]
 func (h *EquipmentHandler) GetEquipmentByDeviceID(w http.ResponseWriter, r *http.Request) {
 	q, err := database.InitEquipmentDatabase()
@@ -377,7 +439,7 @@ func (h *EquipmentHandler) GetEquipmentByDeviceID(w http.ResponseWriter, r *http
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/device/" + deviceID)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + deviceID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device/"+deviceID, "GET /api/v1/equipment/device/{id}")
 		return
@@ -406,35 +468,29 @@ func (h *EquipmentHandler) GetEquipmentByDeviceID(w http.ResponseWriter, r *http
 		helpers.JsonResponseError(w, http.StatusBadRequest, "no equipment found", "GET /api/v1/equipment/device/{id}")
 		return
 	}
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/device/{id}")
+		return
+	}
+
 	all := r.FormValue("all")
 	if all == "true" {
 		var e []models.Equipment
 		for _, v := range d {
-			e = append(e, models.Equipment{
-				AutoID:         v.AutoID,
-				DeviceTypeID:   v.DeviceTypeID,
-				ManufacturerID: v.ManufacturerID,
-				SerialNumber:   v.SerialNumber,
-				Status:         string(v.Status),
-			})
+			e = append(e, b.equipment(v))
 		}
 
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/device/{id}")
 		return
 	} else {
 		var e []models.Equipment
 		for _, v := range d {
 			if v.Status == sqlc.SerialNumbersStatusActive {
-				e = append(e, models.Equipment{
-					AutoID:         v.AutoID,
-					DeviceTypeID:   v.DeviceTypeID,
-					ManufacturerID: v.ManufacturerID,
-					SerialNumber:   v.SerialNumber,
-					Status:         string(v.Status),
-				})
+				e = append(e, b.equipment(v))
 			}
 		}
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/device/{id}")
 		return
 	}
 }
@@ -449,6 +505,8 @@ func (h *EquipmentHandler) GetEquipmentByDeviceID(w http.ResponseWriter, r *http
 //	@Param			device_id		path		int		true	"device id"			minimum(1)
 //	@Param			manufacturer_id	path		int		true	"manufacturer id"	minimum(1)
 //	@Param			all				query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
+//	@Param			expand			query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields			query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
 //	@Success		200				{object}	models.JsonResponse{MSG=[]models.Equipment}
 //	@Failure		400				{object}	models.JsonResponse
 //	@Failure		500				{object}	models.JsonResponse
@@ -471,7 +529,7 @@ func (h *EquipmentHandler) GetEquipmentByDeviceIDAndManufacturerID(w http.Respon
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/device/" + deviceID)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + deviceID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device/"+deviceID, "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}")
 		return
@@ -500,7 +558,7 @@ func (h *EquipmentHandler) GetEquipmentByDeviceIDAndManufacturerID(w http.Respon
 		helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id is not a number", "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}")
 		return
 	}
-	resp, err = http.Get("http://localhost:8081/api/v1/manufacturer/" + manufacturerID)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + manufacturerID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+manufacturerID, "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}")
 		return
@@ -530,35 +588,29 @@ func (h *EquipmentHandler) GetEquipmentByDeviceIDAndManufacturerID(w http.Respon
 		helpers.JsonResponseError(w, http.StatusBadRequest, "no equipment found", "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}")
 		return
 	}
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}")
+		return
+	}
+
 	all := r.FormValue("all")
 	if all == "true" {
 		var e []models.Equipment
 		for _, v := range d {
-			e = append(e, models.Equipment{
-				AutoID:         v.AutoID,
-				DeviceTypeID:   v.DeviceTypeID,
-				ManufacturerID: v.ManufacturerID,
-				SerialNumber:   v.SerialNumber,
-				Status:         string(v.Status),
-			})
+			e = append(e, b.equipment(v))
 		}
 
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}")
 		return
 	} else {
 		var e []models.Equipment
 		for _, v := range d {
 			if v.Status == sqlc.SerialNumbersStatusActive {
-				e = append(e, models.Equipment{
-					AutoID:         v.AutoID,
-					DeviceTypeID:   v.DeviceTypeID,
-					ManufacturerID: v.ManufacturerID,
-					SerialNumber:   v.SerialNumber,
-					Status:         string(v.Status),
-				})
+				e = append(e, b.equipment(v))
 			}
 		}
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/device/{device_id}/manufacturer/{manufacturer_id}")
 		return
 	}
 }
@@ -572,6 +624,8 @@ func (h *EquipmentHandler) GetEquipmentByDeviceIDAndManufacturerID(w http.Respon
 //	@Produce		json
 //	@Param			device_id	path		int		true	"device id"	minimum(1)
 //	@Param			sn			path		string	true	"serial number"
+//	@Param			expand		query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields		query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
 //	@Success		200			{object}	models.JsonResponse{MSG=models.Equipment}
 //	@Failure		400			{object}	models.JsonResponse
 //	@Failure		500			{object}	models.JsonResponse
@@ -589,9 +643,9 @@ func (h *EquipmentHandler) GetEquipmentByDeviceIDAndSN(w http.ResponseWriter, r
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/equipment/" + sn)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/sn?sn=" + url.QueryEscape(sn))
 	if err != nil {
-		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/"+sn, "GET /api/v1/equipment/sn/{sn}/device/{device_id}")
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/sn?sn="+sn, "GET /api/v1/equipment/sn/{sn}/device/{device_id}")
 		return
 	}
 	defer resp.Body.Close()
@@ -621,7 +675,7 @@ func (h *EquipmentHandler) GetEquipmentByDeviceIDAndSN(w http.ResponseWriter, r
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/device/" + deviceID)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + deviceID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device/"+deviceID, "GET /api/v1/equipment/sn/{sn}/device/{device_id}")
 		return
@@ -639,20 +693,30 @@ func (h *EquipmentHandler) GetEquipmentByDeviceIDAndSN(w http.ResponseWriter, r
 		return
 	}
 
-	d, err := q.GetEquipmentByDeviceTypeAndSerialNumber(r.Context(), sqlc.GetEquipmentByDeviceTypeAndSerialNumberParams{SerialNumber: sn, DeviceTypeID: int32(id)})
+	ns, err := serialNormalizers(r.Context(), q)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/equipment/sn/{sn}/device/{device_id}")
+		return
+	}
+
+	d, err := findBySerial(r.Context(), q, ns, sn)
+	if err == nil && d.DeviceTypeID != int32(id) {
+		err = sql.ErrNoRows
+	}
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "GET /api/v1/equipment/sn/{sn}/device/{device_id}")
 		return
 	}
 
-	e := models.Equipment{
-		AutoID:         d.AutoID,
-		DeviceTypeID:   d.DeviceTypeID,
-		ManufacturerID: d.ManufacturerID,
-		SerialNumber:   d.SerialNumber,
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/sn/{sn}/device/{device_id}")
+		return
 	}
 
-	helpers.JsonResponseSuccess(w, http.StatusOK, e)
+	e := b.equipment(d)
+
+	respondEquipmentOne(w, r, q, e, "GET /api/v1/equipment/sn/{sn}/device/{device_id}")
 }
 
 // GetEquipmentByManufacturerIDAndSN get equipment by manufacturer id and serial number
@@ -664,6 +728,8 @@ func (h *EquipmentHandler) GetEquipmentByDeviceIDAndSN(w http.ResponseWriter, r
 //	@Produce		json
 //	@Param			manufacturer_id	path		int		true	"manufacturer id"	minimum(1)
 //	@Param			sn				path		string	true	"serial number"
+//	@Param			expand			query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields			query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
 //	@Success		200				{object}	models.JsonResponse{MSG=models.Equipment}
 //	@Failure		400				{object}	models.JsonResponse
 //	@Failure		500				{object}	models.JsonResponse
@@ -681,9 +747,9 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndSN(w http.ResponseWrit
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/equipment/" + sn)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/sn?sn=" + url.QueryEscape(sn))
 	if err != nil {
-		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/"+sn, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}")
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/sn?sn="+sn, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}")
 		return
 	}
 	defer resp.Body.Close()
@@ -708,7 +774,7 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndSN(w http.ResponseWrit
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/manufacturer/" + manufacturerID)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + manufacturerID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+manufacturerID, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}")
 		return
@@ -726,7 +792,16 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndSN(w http.ResponseWrit
 		return
 	}
 
-	d, err := q.GetEquipmentByManufacturerAndSerialNumber(r.Context(), sqlc.GetEquipmentByManufacturerAndSerialNumberParams{SerialNumber: sn, ManufacturerID: int32(id)})
+	canonical, err := canonicalSerial(r.Context(), q, sn, int32(id))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}")
+		return
+	}
+
+	d, err := q.GetEquipmentByManufacturerAndSerialNumber(r.Context(), sqlc.GetEquipmentByManufacturerAndSerialNumberParams{SerialCanonical: canonical, ManufacturerID: int32(id)})
 	if err == sql.ErrNoRows {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment does not exists", "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}")
 		return
@@ -735,14 +810,15 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndSN(w http.ResponseWrit
 		return
 	}
 
-	out := models.Equipment{
-		AutoID:         d.AutoID,
-		DeviceTypeID:   d.DeviceTypeID,
-		ManufacturerID: d.ManufacturerID,
-		SerialNumber:   d.SerialNumber,
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}")
+		return
 	}
 
-	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+	out := b.equipment(d)
+
+	respondEquipmentOne(w, r, q, out, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}")
 }
 
 // GetEquipmentByManufacturerIDAndDeviceIDAndSN get equipment by manufacturer id and serial number and device id
@@ -755,6 +831,8 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndSN(w http.ResponseWrit
 //	@Param			manufacturer_id	path		int		true	"manufacturer id"	minimum(1)
 //	@Param			device_id		path		int		true	"device id"			minimum(1)
 //	@Param			sn				path		string	true	"serial number"
+//	@Param			expand			query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields			query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
 //	@Success		200				{object}	models.JsonResponse{MSG=models.Equipment}
 //	@Failure		400				{object}	models.JsonResponse
 //	@Failure		500				{object}	models.JsonResponse
@@ -772,9 +850,9 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDAndSN(w http.R
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/equipment/" + sn)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/sn?sn=" + url.QueryEscape(sn))
 	if err != nil {
-		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/"+sn, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/sn?sn="+sn, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 		return
 	}
 	defer resp.Body.Close()
@@ -799,7 +877,7 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDAndSN(w http.R
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/manufacturer/" + manufacturerID)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + manufacturerID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+manufacturerID, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 		return
@@ -829,7 +907,7 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDAndSN(w http.R
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/device/" + deviceID)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + deviceID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device/"+deviceID, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 		return
@@ -847,8 +925,17 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDAndSN(w http.R
 		return
 	}
 
+	canonical, err := canonicalSerial(r.Context(), q, sn, int32(mid))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
+		return
+	}
+
 	d, err := q.GetEquipmentByDeviceTypeManufacturerAndSerialNumber(r.Context(), sqlc.GetEquipmentByDeviceTypeManufacturerAndSerialNumberParams{
-		SerialNumber:   sn,
+		SerialCanonical: canonical,
 		DeviceTypeID:   int32(did),
 		ManufacturerID: int32(mid),
 	})
@@ -861,7 +948,13 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDAndSN(w http.R
 		return
 	}
 
-	helpers.JsonResponseSuccess(w, http.StatusOK, d)
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
+		return
+	}
+
+	respondEquipmentOne(w, r, q, b.equipment(d), "GET /api/v1/equipment/sn/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 }
 
 // GetEquipmentByManufacturerIDAndDeviceIDLikeSN get equipment by manufacturer id like serial number and device id
@@ -875,6 +968,8 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDAndSN(w http.R
 //	@Param			device_id		path		int		true	"device id"			minimum(1)
 //	@Param			sn				path		string	true	"serial number"
 //	@Param			all				query		bool	true	"set to true to get all equipment, otherwise only active equipment is returned"
+//	@Param			expand			query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields			query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
 //	@Success		200				{object}	models.JsonResponse{MSG=[]models.Equipment}
 //	@Failure		400				{object}	models.JsonResponse
 //	@Failure		500				{object}	models.JsonResponse
@@ -900,7 +995,7 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDLikeSN(w http.
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/manufacturer/" + manufacturerID)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + manufacturerID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+manufacturerID, "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 		return
@@ -931,7 +1026,7 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDLikeSN(w http.
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/device/" + deviceID)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + deviceID)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device/"+deviceID, "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 		return
@@ -949,13 +1044,19 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDLikeSN(w http.
 		return
 	}
 
-	sn = "%" + sn + "%"
+	n, err := normalizerFor(r.Context(), q, int32(mid))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
+		return
+	}
 
-	d, err := q.GetEquipmentByDeviceTypeManufacturerLikeSerialNumber(r.Context(), sqlc.GetEquipmentByDeviceTypeManufacturerLikeSerialNumberParams{
-		SerialNumber:   sn,
+	arg := sqlc.GetEquipmentByDeviceTypeManufacturerLikeSerialNumberParams{
 		DeviceTypeID:   int32(did),
 		ManufacturerID: int32(mid),
-	})
+	}
+	arg.SerialNumber, arg.SerialCanonical = likeSerial(n, sn)
+
+	d, err := q.GetEquipmentByDeviceTypeManufacturerLikeSerialNumber(r.Context(), arg)
 
 	if err == sql.ErrNoRows {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment does not exists", "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
@@ -969,35 +1070,29 @@ func (h *EquipmentHandler) GetEquipmentByManufacturerIDAndDeviceIDLikeSN(w http.
 		helpers.JsonResponseError(w, http.StatusBadRequest, "no equipment found", "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 		return
 	}
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
+		return
+	}
+
 	all := r.FormValue("all")
 	if all == "true" {
 		var e []models.Equipment
 		for _, v := range d {
-			e = append(e, models.Equipment{
-				AutoID:         v.AutoID,
-				DeviceTypeID:   v.DeviceTypeID,
-				ManufacturerID: v.ManufacturerID,
-				SerialNumber:   v.SerialNumber,
-				Status:         string(v.Status),
-			})
+			e = append(e, b.equipment(v))
 		}
 
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 		return
 	} else {
 		var e []models.Equipment
 		for _, v := range d {
 			if v.Status == sqlc.SerialNumbersStatusActive {
-				e = append(e, models.Equipment{
-					AutoID:         v.AutoID,
-					DeviceTypeID:   v.DeviceTypeID,
-					ManufacturerID: v.ManufacturerID,
-					SerialNumber:   v.SerialNumber,
-					Status:         string(v.Status),
-				})
+				e = append(e, b.equipment(v))
 			}
 		}
-		helpers.JsonResponseSuccess(w, http.StatusOK, e)
+		respondEquipment(w, r, q, e, "GET /api/v1/equipment/sn-like/{sn}/manufacturer/{manufacturer_id}/device/{device_id}")
 		return
 	}
 }
@@ -1033,7 +1128,7 @@ func (h *EquipmentHandler) UpdateSerialNumber(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/equipment/id?id=" + id)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/id?id=" + id)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment?id="+id, "PATCH /api/v1/equipment?id={id}&sn={sn}")
 		return
@@ -1051,31 +1146,58 @@ func (h *EquipmentHandler) UpdateSerialNumber(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	sn := r.FormValue("sn")
+	sn := strings.TrimSpace(r.FormValue("sn"))
 	if sn == "" {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "missing serial number", "PATCH /api/v1/equipment?id={id}&sn={sn}")
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/equipment/sn/" + sn)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/sn?sn=" + url.QueryEscape(sn))
 	if err != nil {
-		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/sn/"+sn, "PATCH /api/v1/equipment?id={id}&sn={sn}")
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/sn?sn="+sn, "PATCH /api/v1/equipment?id={id}&sn={sn}")
 		return
 	}
 	defer resp.Body.Close()
 
+	req = models.JsonResponse{}
 	err = json.NewDecoder(resp.Body).Decode(&req)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to decode response from /api/v1/equipment", "PATCH /api/v1/equipment?id={id}&sn={sn}")
 		return
 	}
 
-	if req.Status == "ERROR" {
+	if req.Status == "ERROR" && req.Message != "equipment/serial number does not exist in database" {
 		helpers.JsonResponseError(w, http.StatusBadRequest, req.Message, "PATCH /api/v1/equipment?id={id}&sn={sn}")
 		return
+	} else if req.Status == "SUCCESS" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "serial number already exists", "PATCH /api/v1/equipment?id={id}&sn={sn}")
+		return
 	}
 
-	err = q.UpdateSerialNumber(r.Context(), sqlc.UpdateSerialNumberParams{AutoID: int32(i), SerialNumber: sn})
+	e, err := q.GetEquipmentByAutoID(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "PATCH /api/v1/equipment?id={id}&sn={sn}")
+		return
+	}
+	err = checkSerialNumber(r.Context(), q, sn, e.ManufacturerID, e.DeviceTypeID)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment?id={id}&sn={sn}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial number rules", "PATCH /api/v1/equipment?id={id}&sn={sn}")
+		return
+	}
+
+	canonical, err := canonicalSerial(r.Context(), q, sn, e.ManufacturerID)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment?id={id}&sn={sn}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "PATCH /api/v1/equipment?id={id}&sn={sn}")
+		return
+	}
+
+	err = q.UpdateSerialNumber(r.Context(), sqlc.UpdateSerialNumberParams{AutoID: int32(i), SerialNumber: sn, SerialCanonical: canonical})
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to update serial number in database", "PATCH /api/v1/equipment?id={id}&sn={sn}")
 		return
@@ -1087,7 +1209,7 @@ func (h *EquipmentHandler) UpdateSerialNumber(w http.ResponseWriter, r *http.Req
 // UpdateEquipment update equipment
 //
 //	@Summary		update equipment
-//	@Description	update equipment in the database
+//	@Description	update equipment in the database. Moving equipment to another device type replaces its attribute values with the attr.{name} values given, every required attribute of the new device type has to be among them
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
@@ -1095,6 +1217,7 @@ func (h *EquipmentHandler) UpdateSerialNumber(w http.ResponseWriter, r *http.Req
 //	@Param			sn				query		string	true	"serial number"
 //	@Param			manufacturer_id	query		int		true	"manufacturer id"	minimum(1)
 //	@Param			device_id		query		int		true	"device id"			minimum(1)
+//	@Param			attr.{name}		query		string	false	"attribute value for the new device type, only used when device_id changes"
 //	@Success		200				{object}	models.JsonResponse{MSG=models.Equipment}
 //	@Failure		400				{object}	models.JsonResponse
 //	@Failure		500				{object}	models.JsonResponse
@@ -1116,7 +1239,7 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/equipment/id?id=" + id)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/id?id=" + id)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment?id="+id, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
@@ -1134,13 +1257,13 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	sn := r.FormValue("sn")
+	sn := strings.TrimSpace(r.FormValue("sn"))
 	if sn == "" {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "missing serial number", "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/equipment/sn?sn=" + sn)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/sn?sn=" + url.QueryEscape(sn))
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/sn/"+sn, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
@@ -1153,19 +1276,20 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if req.Status == "ERROR" && req.Message != "equipment does not exist in database" {
+	if req.Status == "ERROR" && req.Message != "equipment/serial number does not exist in database" {
 		helpers.JsonResponseError(w, http.StatusBadRequest, req.Message, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
 	} else if req.Status == "SUCCESS" {
 		var tmp models.Equipment
 		if m, ok := req.Message.(map[string]interface{}); ok {
-			tmp.SerialNumber = m["serial_number"].(string)
+			tmp.AutoID = int32(m["auto_id"].(float64))
 		} else {
 			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to decode response from /api/v1/equipment", "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 			return
 		}
 
-		if tmp.SerialNumber == sn {
+		// NOTE: keeping its own serial number is fine, taking another equipment's is not
+		if tmp.AutoID != int32(i) {
 			helpers.JsonResponseError(w, http.StatusBadRequest, "serial number already exists", "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 			return
 		}
@@ -1183,7 +1307,7 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/device/" + did)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + did)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/device/"+did, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
@@ -1213,7 +1337,7 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/manufacturer/" + mid)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + mid)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+mid, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
@@ -1231,8 +1355,63 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = q.UpdateEquipment(r.Context(), sqlc.UpdateEquipmentParams{SerialNumber: sn, DeviceTypeID: int32(d), ManufacturerID: int32(m), AutoID: int32(i)})
+	err = checkSerialNumber(r.Context(), q, sn, int32(m), int32(d))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial number rules", "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	}
+
+	canonical, err := canonicalSerial(r.Context(), q, sn, int32(m))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	}
+
+	e, err := q.GetEquipmentByAutoID(r.Context(), int32(i))
 	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	}
+	// NOTE: the product model has to be cleared or changed before the equipment can move
+	// to a manufacturer or device it doesn't match
+	if e.ProductModelID.Valid {
+		err = checkProductModel(r.Context(), q, e.ProductModelID.Int32, int32(d), int32(m))
+		if se, ok := asStatusError(err); ok {
+			helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
+			return
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for product model", "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
+			return
+		}
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		err := q.UpdateEquipment(r.Context(), sqlc.UpdateEquipmentParams{SerialNumber: sn, SerialCanonical: canonical, DeviceTypeID: int32(d), ManufacturerID: int32(m), AutoID: int32(i)})
+		if err != nil || e.DeviceTypeID == int32(d) {
+			return err
+		}
+		// NOTE: attribute values belong to the device type, moving to another one replaces
+		// them with the attr.{name} values given, which have to satisfy its schema
+		schema, err := deviceTypeSchema(r.Context(), q, int32(d))
+		if err != nil {
+			return err
+		}
+		values, err := schema.check(formAttributes(r))
+		if err != nil {
+			return err
+		}
+		return schema.store(r.Context(), q, int32(i), values)
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	} else if err != nil {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to update equipment in database", "PATCH /api/v1/equipment?id={id}&sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
 	}
@@ -1243,7 +1422,7 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 // UpdateEquipmentStatus update equipment status
 //
 //	@Summary		update equipment status
-//	@Description	update equipment status in the database
+//	@Description	update equipment status in the database, active moves the equipment to in_stock and inactive to retired. Moves the lifecycle does not allow are rejected
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
@@ -1251,10 +1430,11 @@ func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Reques
 //	@Param			status	query		string	true	"equipment status"	Enums("active", "inactive")
 //	@Success		200		{object}	models.JsonResponse
 //	@Failure		400		{object}	models.JsonResponse
+//	@Failure		409		{object}	models.JsonResponse
 //	@Failure		500		{object}	models.JsonResponse
 //	@Router			/equipment/{id}/status [patch]
 func (h *EquipmentHandler) UpdateEquipmentStatus(w http.ResponseWriter, r *http.Request) {
-	q, err := database.InitEquipmentDatabase()
+	_, err := database.InitEquipmentDatabase()
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "PATCH /api/v1/equipment/{id}/status?status={status}")
 		return
@@ -1270,7 +1450,7 @@ func (h *EquipmentHandler) UpdateEquipmentStatus(w http.ResponseWriter, r *http.
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/equipment/id?id=" + id)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/id?id=" + id)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment?id="+id, "PATCH /api/v1/equipment/{id}/status?status={status}")
 		return
@@ -1296,18 +1476,25 @@ func (h *EquipmentHandler) UpdateEquipmentStatus(w http.ResponseWriter, r *http.
 	if status != "active" && status != "inactive" {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "status must be either active or inactive", "PATCH /api/v1/equipment/{id}/status?status={status}")
 		return
-	} else if status == "active" {
-		err = q.UpdateEquipmentStatus(r.Context(), sqlc.UpdateEquipmentStatusParams{AutoID: int32(i), Status: sqlc.SerialNumbersStatusActive})
-		if err != nil {
-			helpers.JsonResponseError(w, http.StatusBadRequest, "something went wrong with sql statement"+err.Error(), "PATCH /api/v1/equipment/{id}/status?status={status}")
-			return
-		}
-	} else { // status must be inactive here
-		err = q.UpdateEquipmentStatus(r.Context(), sqlc.UpdateEquipmentStatusParams{AutoID: int32(i), Status: sqlc.SerialNumbersStatusInactive})
+	}
+
+	// NOTE: status is derived from the lifecycle state, older clients setting it move the
+	// equipment to in_stock or retired when the lifecycle allows the move
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		e, err := q.GetEquipmentByAutoIDForUpdate(r.Context(), int32(i))
 		if err != nil {
-			helpers.JsonResponseError(w, http.StatusBadRequest, "something went wrong with sql statement"+err.Error(), "PATCH /api/v1/equipment/{id}/status?status={status}")
-			return
+			return err
 		}
+		to := lifecycle.FromLegacy(lifecycle.State(e.LifecycleState), status)
+		_, err = h.transition(r.Context(), q, int32(i), to, "status set to "+status)
+		return err
+	})
+	if errors.Is(err, lifecycle.ErrIllegalTransition) {
+		helpers.JsonResponseError(w, http.StatusConflict, err.Error(), "PATCH /api/v1/equipment/{id}/status?status={status}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "something went wrong with sql statement"+err.Error(), "PATCH /api/v1/equipment/{id}/status?status={status}")
+		return
 	}
 
 	msg := fmt.Sprintf("equipment with id: %v updated status to %v", i, status)
@@ -1325,6 +1512,8 @@ func (h *EquipmentHandler) UpdateEquipmentStatus(w http.ResponseWriter, r *http.
 //	@Param			sn				query		string	true	"serial number"
 //	@Param			manufacturer	query		int		true	"manufacturer id"	minimum(1)
 //	@Param			device			query		int		true	"device id"			minimum(1)
+//	@Param			model			query		int		false	"product model id, has to match the manufacturer and device"	minimum(1)
+//	@Param			attr.{name}		query		string	false	"attribute value, for example attr.imei=356938035643809, every required attribute of the device type has to be given"
 //	@Success		200				{object}	models.JsonResponse{MSG=models.Equipment}
 //	@Failure		400				{object}	models.JsonResponse
 //	@Failure		500				{object}	models.JsonResponse
@@ -1335,14 +1524,14 @@ func (h *EquipmentHandler) CreateEquipment(w http.ResponseWriter, r *http.Reques
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
 	}
-	sn := r.FormValue("sn")
+	sn := strings.TrimSpace(r.FormValue("sn"))
 	if sn == "" {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "missing serial number", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
 	}
 
 	var req models.JsonResponse
-	resp, err := http.Get("http://localhost:8081/api/v1/equipment/sn?sn=" + sn)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/equipment/sn?sn=" + url.QueryEscape(sn))
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/equipment/sn/"+sn, "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
@@ -1355,10 +1544,10 @@ func (h *EquipmentHandler) CreateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if req.Status == "ERROR" && req.Message != "equipment does not exist in database" {
+	if req.Status == "ERROR" && req.Message != "equipment/serial number does not exist in database" {
 		helpers.JsonResponseError(w, http.StatusBadRequest, req.Message, "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
-	} else if req.Message != "equipment does not exist in database" {
+	} else if req.Status == "SUCCESS" {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment already exists in database", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
 	}
@@ -1375,7 +1564,7 @@ func (h *EquipmentHandler) CreateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/device/" + did)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/device/" + did)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1//"+did, "POST /api/v1/equipment?sn={sn}&device_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
@@ -1420,7 +1609,7 @@ func (h *EquipmentHandler) CreateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	resp, err = http.Get("http://localhost:8081/api/v1/manufacturer/" + mid)
+	resp, err = helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + mid)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+mid, "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
@@ -1453,7 +1642,60 @@ func (h *EquipmentHandler) CreateEquipment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = q.CreateEquipment(r.Context(), sqlc.CreateEquipmentParams{SerialNumber: sn, DeviceTypeID: int32(d), ManufacturerID: int32(m)})
+	err = checkSerialNumber(r.Context(), q, sn, int32(m), int32(d))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial number rules", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	}
+
+	canonical, err := canonicalSerial(r.Context(), q, sn, int32(m))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	}
+
+	var model sql.NullInt32
+	if pid := r.FormValue("model"); pid != "" {
+		p, err := strconv.Atoi(pid)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "product model id is not a number", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+			return
+		}
+		err = checkProductModel(r.Context(), q, int32(p), int32(d), int32(m))
+		if se, ok := asStatusError(err); ok {
+			helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+			return
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for product model", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+			return
+		}
+		model = sql.NullInt32{Int32: int32(p), Valid: true}
+	}
+
+	schema, err := deviceTypeSchema(r.Context(), q, int32(d))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for attributes", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	}
+	values, err := schema.check(formAttributes(r))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
+		return
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		id, err := q.CreateEquipment(r.Context(), sqlc.CreateEquipmentParams{SerialNumber: sn, SerialCanonical: canonical, DeviceTypeID: int32(d), ManufacturerID: int32(m), ProductModelID: model})
+		if err != nil {
+			return err
+		}
+		return schema.store(r.Context(), q, int32(id), values)
+	})
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to create equipment in database", "POST /api/v1/equipment?sn={sn}&_id={device_id}&manufacturer_id={manufacturer_id}")
 		return
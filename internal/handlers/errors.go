@@ -0,0 +1,21 @@
+package handlers
+
+import "errors"
+
+// statusError is returned from inside a database transaction to fail the request with
+// code and msg once the transaction has rolled back
+type statusError struct {
+	code int
+	msg  string
+}
+
+func (e statusError) Error() string {
+	return e.msg
+}
+
+// asStatusError reports whether err is, or wraps, a statusError
+func asStatusError(err error) (statusError, bool) {
+	var se statusError
+	ok := errors.As(err, &se)
+	return se, ok
+}
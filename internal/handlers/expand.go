@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+// maxRelationIDs is how many equipment ids GetEquipmentRelations is given at once, it
+// keeps the IN list well under the placeholder limit of MySQL
+const maxRelationIDs = 5000
+
+// equipmentFields are the json names of the fields of models.Equipment
+var equipmentFields = func() []string {
+	var names []string
+	t := reflect.TypeOf(models.Equipment{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		names = append(names, name)
+	}
+	return names
+}()
+
+// equipmentView is how equipment is shaped in a response, which related resources are
+// embedded and which fields are kept
+type equipmentView struct {
+	deviceType   bool
+	manufacturer bool
+	// fields are the json names of the fields to keep, every field when empty
+	fields []string
+}
+
+// parseEquipmentView reads the expand and fields parameters, both can be repeated or
+// comma separated
+func parseEquipmentView(r *http.Request) (equipmentView, error) {
+	var v equipmentView
+	r.ParseForm()
+	for _, raw := range r.Form["expand"] {
+		for _, name := range strings.Split(raw, ",") {
+			name = strings.TrimSpace(name)
+			switch name {
+			case "":
+			case "device_type":
+				v.deviceType = true
+			case "manufacturer":
+				v.manufacturer = true
+			default:
+				return v, statusError{http.StatusBadRequest, fmt.Sprintf("cannot expand %q, expand must be device_type or manufacturer", name)}
+			}
+		}
+	}
+	for _, raw := range r.Form["fields"] {
+		for _, name := range strings.Split(raw, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || slices.Contains(v.fields, name) {
+				continue
+			}
+			if !slices.Contains(equipmentFields, name) {
+				return v, statusError{http.StatusBadRequest, fmt.Sprintf("unknown equipment field %q", name)}
+			}
+			v.fields = append(v.fields, name)
+		}
+	}
+	// NOTE: expanded resources are kept when fields trims the rest
+	if len(v.fields) > 0 && v.deviceType && !slices.Contains(v.fields, "device_type") {
+		v.fields = append(v.fields, "device_type")
+	}
+	if len(v.fields) > 0 && v.manufacturer && !slices.Contains(v.fields, "manufacturer") {
+		v.fields = append(v.fields, "manufacturer")
+	}
+	return v, nil
+}
+
+// plain reports whether v leaves equipment as it is
+func (v equipmentView) plain() bool {
+	return !v.deviceType && !v.manufacturer && len(v.fields) == 0
+}
+
+// expand embeds the related resources v asks for into e
+func (v equipmentView) expand(ctx context.Context, q *sqlc.Queries, e []models.Equipment) error {
+	if !v.deviceType && !v.manufacturer {
+		return nil
+	}
+	ids := make([]int32, len(e))
+	for n, x := range e {
+		ids[n] = x.AutoID
+	}
+	related := map[int32]sqlc.GetEquipmentRelationsRow{}
+	for len(ids) > 0 {
+		chunk := ids[:min(len(ids), maxRelationIDs)]
+		ids = ids[len(chunk):]
+		rows, err := q.GetEquipmentRelations(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			related[row.AutoID] = row
+		}
+	}
+	for n := range e {
+		row, ok := related[e[n].AutoID]
+		if !ok {
+			continue
+		}
+		if v.deviceType {
+			e[n].DeviceType = &models.DeviceType{ID: row.DeviceType.ID, Name: row.DeviceType.Name, Status: string(row.DeviceType.Status)}
+		}
+		if v.manufacturer {
+			e[n].Manufacturer = &models.Manufacturer{ID: row.Manufacturer.ID, Name: row.Manufacturer.Name, Status: string(row.Manufacturer.Status)}
+		}
+	}
+	return nil
+}
+
+// trim returns e with only the fields v keeps
+func (v equipmentView) trim(e models.Equipment) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	all := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+	out := map[string]json.RawMessage{}
+	for _, name := range v.fields {
+		if raw, ok := all[name]; ok {
+			out[name] = raw
+		}
+	}
+	return out, nil
+}
+
+// render returns e shaped by v
+func (v equipmentView) render(ctx context.Context, q *sqlc.Queries, e []models.Equipment) (models.Message, error) {
+	if e == nil || v.plain() {
+		return e, nil
+	}
+	if err := v.expand(ctx, q, e); err != nil {
+		return nil, err
+	}
+	if len(v.fields) == 0 {
+		return e, nil
+	}
+	out := []map[string]json.RawMessage{}
+	for _, x := range e {
+		t, err := v.trim(x)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// respondEquipment writes e shaped by the expand and fields parameters of r
+func respondEquipment(w http.ResponseWriter, r *http.Request, q *sqlc.Queries, e []models.Equipment, action string) {
+	v, err := parseEquipmentView(r)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, action)
+		return
+	}
+	out, err := v.render(r.Context(), q, e)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for related resources", action)
+		return
+	}
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
+
+// respondEquipmentOne writes a single equipment e shaped by the expand and fields
+// parameters of r
+func respondEquipmentOne(w http.ResponseWriter, r *http.Request, q *sqlc.Queries, e models.Equipment, action string) {
+	v, err := parseEquipmentView(r)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, action)
+		return
+	}
+	out, err := v.render(r.Context(), q, []models.Equipment{e})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for related resources", action)
+		return
+	}
+	if list, ok := out.([]map[string]json.RawMessage); ok {
+		helpers.JsonResponseSuccess(w, http.StatusOK, list[0])
+		return
+	}
+	helpers.JsonResponseSuccess(w, http.StatusOK, out.([]models.Equipment)[0])
+}
+
+// respondEquipmentMatches writes fuzzy matches m with their equipment shaped by the expand
+// and fields parameters of r, the distance and score are always kept
+func respondEquipmentMatches(w http.ResponseWriter, r *http.Request, q *sqlc.Queries, m []models.EquipmentMatch, action string) {
+	v, err := parseEquipmentView(r)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, action)
+		return
+	}
+	e := make([]models.Equipment, len(m))
+	for n, x := range m {
+		e[n] = x.Equipment
+	}
+	if err := v.expand(r.Context(), q, e); err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for related resources", action)
+		return
+	}
+	if len(v.fields) == 0 {
+		for n := range m {
+			m[n].Equipment = e[n]
+		}
+		helpers.JsonResponseSuccess(w, http.StatusOK, m)
+		return
+	}
+
+	out := []map[string]json.RawMessage{}
+	for n, x := range e {
+		t, err := v.trim(x)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to render equipment", action)
+			return
+		}
+		t["distance"], _ = json.Marshal(m[n].Distance)
+		t["score"], _ = json.Marshal(m[n].Score)
+		out = append(out, t)
+	}
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
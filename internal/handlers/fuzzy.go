@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/fuzzy"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+const (
+	defaultFuzzyLimit = 20
+	maxFuzzyLimit     = 100
+)
+
+// fuzzyPieces returns a regular expression matching serial numbers that contain a piece
+// of any of the canonical forms of a search, see fuzzy.Pieces. It is null when a form is
+// too short to split and every serial number of the right length has to be ranked
+func fuzzyPieces(forms []string, distance int) sql.NullString {
+	var pieces []string
+	for _, f := range forms {
+		p := fuzzy.Pieces(f, distance)
+		if p == nil {
+			return sql.NullString{}
+		}
+		for _, v := range p {
+			v = regexp.QuoteMeta(v)
+			if !slices.Contains(pieces, v) {
+				pieces = append(pieces, v)
+			}
+		}
+	}
+	return sql.NullString{String: strings.Join(pieces, "|"), Valid: len(pieces) > 0}
+}
+
+// FuzzySearchEquipment fuzzy search equipment by serial number
+//
+//	@Summary		fuzzy search equipment by serial number
+//	@Description	find equipment whose serial number is close to sn, allowing typos, missing or extra characters and swapped neighbours. Serial numbers are compared in canonical form and ranked by edit distance, closest first
+//	@Tags			equipment
+//	@Accept			json
+//	@Produce		json
+//	@Param			sn				query		string	true	"serial number as typed"
+//	@Param			device			query		int		false	"device id"			minimum(1)
+//	@Param			manufacturer	query		int		false	"manufacturer id"	minimum(1)
+//	@Param			max_distance	query		int		false	"largest edit distance to match, defaults to 1 to 3 depending on the length of sn"	minimum(0)	maximum(5)
+//	@Param			limit			query		int		false	"most matches to return"	minimum(1)	maximum(100)	default(20)
+//	@Param			all				query		bool	false	"set to true to include inactive equipment"
+//	@Param			expand			query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields			query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out. distance and score are always kept"	collectionFormat(multi)
+//	@Success		200				{object}	models.JsonResponse{MSG=[]models.EquipmentMatch}
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/equipment/fuzzy [get]
+func (h *EquipmentHandler) FuzzySearchEquipment(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/equipment/fuzzy?sn={sn}")
+		return
+	}
+
+	sn := r.FormValue("sn")
+	if sn == "" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing serial number", "GET /api/v1/equipment/fuzzy?sn={sn}")
+		return
+	}
+
+	var arg sqlc.GetFuzzySerialCandidatesParams
+	filters := []struct {
+		name  string
+		value *sql.NullInt32
+	}{
+		{"device", &arg.DeviceTypeID},
+		{"manufacturer", &arg.ManufacturerID},
+	}
+	for _, f := range filters {
+		raw := r.FormValue(f.name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, f.name+" id is not a number", "GET /api/v1/equipment/fuzzy?sn={sn}")
+			return
+		}
+		*f.value = sql.NullInt32{Int32: int32(n), Valid: true}
+	}
+
+	limit := defaultFuzzyLimit
+	if raw := r.FormValue("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxFuzzyLimit {
+			helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("limit must be a number from 1 to %d", maxFuzzyLimit), "GET /api/v1/equipment/fuzzy?sn={sn}")
+			return
+		}
+	}
+
+	ns, err := serialNormalizers(r.Context(), q)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/equipment/fuzzy?sn={sn}")
+		return
+	}
+
+	// NOTE: the search is compared in the canonical form of each candidate's manufacturer,
+	// the length bounds cover all of them
+	shortest, longest := -1, 0
+	for _, c := range ns.Candidates(sn) {
+		n := utf8.RuneCountInString(c)
+		if shortest < 0 || n < shortest {
+			shortest = n
+		}
+		longest = max(longest, n)
+	}
+	if longest == 0 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "serial number is empty once normalized", "GET /api/v1/equipment/fuzzy?sn={sn}")
+		return
+	}
+
+	distance := fuzzy.DefaultDistance(longest)
+	if raw := r.FormValue("max_distance"); raw != "" {
+		distance, err = strconv.Atoi(raw)
+		if err != nil || distance < 0 || distance > fuzzy.MaxDistance {
+			helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("max distance must be a number from 0 to %d", fuzzy.MaxDistance), "GET /api/v1/equipment/fuzzy?sn={sn}")
+			return
+		}
+	}
+
+	// NOTE: serial numbers more than distance characters longer or shorter can't match,
+	// and neither can ones without any piece of the search, so the database only returns
+	// the ones that can
+	arg.MinLength = int64(max(shortest-distance, 1))
+	arg.MaxLength = int64(longest + distance)
+	arg.Pieces = fuzzyPieces(ns.Candidates(sn), distance)
+	if r.FormValue("all") != "true" {
+		arg.Status = sqlc.NullSerialNumbersStatus{SerialNumbersStatus: sqlc.SerialNumbersStatusActive, Valid: true}
+	}
+	rows, err := q.GetFuzzySerialCandidates(r.Context(), arg)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/equipment/fuzzy?sn={sn}")
+		return
+	}
+
+	queries := map[int32]string{}
+	candidates := make([]fuzzy.Candidate, 0, len(rows))
+	for _, v := range rows {
+		query, ok := queries[v.ManufacturerID]
+		if !ok {
+			query = ns.For(v.ManufacturerID).Canonical(sn)
+			queries[v.ManufacturerID] = query
+		}
+		candidates = append(candidates, fuzzy.Candidate{ID: v.AutoID, Serial: v.SerialCanonical, Query: query})
+	}
+	ranked := fuzzy.Rank(candidates, distance, limit)
+
+	matches := []models.EquipmentMatch{}
+	if len(ranked) == 0 {
+		respondEquipmentMatches(w, r, q, matches, "GET /api/v1/equipment/fuzzy?sn={sn}")
+		return
+	}
+
+	ids := make([]int32, 0, len(ranked))
+	for _, m := range ranked {
+		ids = append(ids, m.ID)
+	}
+	d, err := q.GetEquipmentByAutoIDs(r.Context(), ids)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/equipment/fuzzy?sn={sn}")
+		return
+	}
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/fuzzy?sn={sn}")
+		return
+	}
+
+	equipment := map[int32]sqlc.SerialNumber{}
+	for _, v := range d {
+		equipment[v.AutoID] = v
+	}
+
+	for _, m := range ranked {
+		e, ok := equipment[m.ID]
+		if !ok {
+			// NOTE: deleted between the two queries
+			continue
+		}
+		matches = append(matches, models.EquipmentMatch{Equipment: b.equipment(e), Distance: m.Distance, Score: m.Score})
+	}
+
+	respondEquipmentMatches(w, r, q, matches, "GET /api/v1/equipment/fuzzy?sn={sn}")
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/logging"
+	"github.com/coltonmosier/api-v1/internal/models"
+)
+
+// checkTimeout bounds each dependency check so a hung database can't hang the probe
+const checkTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	draining atomic.Bool
+}
+
+// Drain marks the API as shutting down so readiness fails and traffic moves elsewhere
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Livez liveness probe, served at /livez outside the versioned api
+// reports the process is up and does not check any dependency
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	helpers.JsonResponseSuccess(w, http.StatusOK, "alive")
+}
+
+// Readyz readiness probe, served at /readyz outside the versioned api
+// checks the database pool and migrations and fails while the server is draining
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		helpers.JsonResponseError(w, http.StatusServiceUnavailable, "shutting down", "none")
+		return
+	}
+
+	report := h.report(r.Context())
+	if report.Status != "up" {
+		helpers.JsonResponseError(w, http.StatusServiceUnavailable, report, "none")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, report)
+}
+
+// Health detailed health report
+//
+//	@Summary		detailed health report
+//	@Description	lists each dependency with its status and how long the check took
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	models.JsonResponse{MSG=models.HealthReport}
+//	@Failure		503	{object}	models.JsonResponse{MSG=models.HealthReport}
+//	@Router			/health [get]
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	report := h.report(r.Context())
+	if report.Status != "up" {
+		helpers.JsonResponseError(w, http.StatusServiceUnavailable, report, "none")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, report)
+}
+
+func (h *HealthHandler) report(ctx context.Context) models.HealthReport {
+	out := models.HealthReport{Status: "up"}
+	out.Checks = append(out.Checks, runCheck(ctx, "equipment_database", checkDatabase))
+	out.Checks = append(out.Checks, runCheck(ctx, "migrations", checkMigrations))
+
+	for _, c := range out.Checks {
+		if c.Status != "up" {
+			out.Status = "down"
+			logging.FromContext(ctx).Warn("health check failed", slog.String("check", c.Name), slog.String("error", c.Error))
+		}
+	}
+	return out
+}
+
+func runCheck(ctx context.Context, name string, check func(context.Context) error) models.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	out := models.HealthCheck{
+		Name:    name,
+		Status:  "up",
+		Latency: time.Since(start).String(),
+	}
+	if err != nil {
+		out.Status = "down"
+		out.Error = err.Error()
+	}
+	return out
+}
+
+func checkDatabase(ctx context.Context) error {
+	db, err := database.EquipmentDB()
+	if err != nil {
+		return err
+	}
+	return db.PingContext(ctx)
+}
+
+func checkMigrations(ctx context.Context) error {
+	pending, err := database.PendingMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending: %s", len(pending), strings.Join(pending, ", "))
+	}
+	return nil
+}
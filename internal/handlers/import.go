@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/serial"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+const (
+	// maxImportSize is the largest csv body an import accepts
+	maxImportSize = 5 << 20
+	// maxImportRows is the most equipment a single import can create
+	maxImportRows = 5000
+)
+
+// importColumns are the csv columns every import needs, product_model_id and the purchase columns are optional
+var importColumns = []string{"serial_number", "device_type_id", "manufacturer_id"}
+
+// importer checks import rows, caching the lookups rows share
+type importer struct {
+	q           *sqlc.Queries
+	rules       []serial.Rule
+	normalizers serial.Normalizers
+	cols        map[string]int
+	// seen maps the canonical serial numbers of earlier rows to their row
+	seen          map[string]int
+	devices       map[int32]sqlc.DeviceType
+	manufacturers map[int32]sqlc.Manufacturer
+	schemas       map[int32]attributeSchema
+}
+
+// importRow is the equipment a csv row creates with its attribute values
+type importRow struct {
+	params sqlc.CreateEquipmentParams
+	attrs  map[string]string
+}
+
+// field returns the value of column name in rec, empty when the column is missing
+func (im *importer) field(rec []string, name string) string {
+	i, ok := im.cols[name]
+	if !ok || i >= len(rec) {
+		return ""
+	}
+	return strings.TrimSpace(rec[i])
+}
+
+// id parses column name of rec as an id
+func (im *importer) id(rec []string, name string) (int32, error) {
+	n, err := strconv.Atoi(im.field(rec, name))
+	if err != nil {
+		return 0, statusError{http.StatusBadRequest, name + " is not a number"}
+	}
+	return int32(n), nil
+}
+
+// attributes returns the attribute values in the attr.{name} columns of rec, empty cells
+// are left out
+func (im *importer) attributes(rec []string) map[string]string {
+	values := map[string]string{}
+	for col := range im.cols {
+		if name, ok := strings.CutPrefix(col, attributeFilterPrefix); ok && im.field(rec, col) != "" {
+			values[name] = im.field(rec, col)
+		}
+	}
+	return values
+}
+
+// row returns the equipment rec creates, or a statusError saying why it can't be created
+func (im *importer) row(ctx context.Context, rec []string, line int) (importRow, error) {
+	var row importRow
+	p := &row.params
+	p.SerialNumber = im.field(rec, "serial_number")
+	if p.SerialNumber == "" {
+		return row, statusError{http.StatusBadRequest, "missing serial number"}
+	}
+	var err error
+	if p.DeviceTypeID, err = im.id(rec, "device_type_id"); err != nil {
+		return row, err
+	}
+	if p.ManufacturerID, err = im.id(rec, "manufacturer_id"); err != nil {
+		return row, err
+	}
+
+	d, ok := im.devices[p.DeviceTypeID]
+	if !ok {
+		d, err = im.q.GetDeviceTypeById(ctx, p.DeviceTypeID)
+		if err == sql.ErrNoRows {
+			return row, statusError{http.StatusBadRequest, "device id does not exist in database"}
+		} else if err != nil {
+			return row, err
+		}
+		im.devices[p.DeviceTypeID] = d
+	}
+	if d.Status == sqlc.DeviceTypeStatusInactive {
+		return row, statusError{http.StatusBadRequest, "cannot create, device is inactive"}
+	}
+
+	m, ok := im.manufacturers[p.ManufacturerID]
+	if !ok {
+		m, err = im.q.GetManufacturerById(ctx, p.ManufacturerID)
+		if err == sql.ErrNoRows {
+			return row, statusError{http.StatusBadRequest, "manufacturer id does not exist in database"}
+		} else if err != nil {
+			return row, err
+		}
+		im.manufacturers[p.ManufacturerID] = m
+	}
+	if m.Status == sqlc.ManufacturerStatusInactive {
+		return row, statusError{http.StatusBadRequest, "cannot create, manufacturer is inactive"}
+	}
+
+	if failures := serial.Validate(im.rules, p.SerialNumber, p.ManufacturerID, p.DeviceTypeID); len(failures) > 0 {
+		reasons := make([]string, 0, len(failures))
+		for _, f := range failures {
+			reasons = append(reasons, fmt.Sprintf("rule %s: %s", f.Rule, f.Reason))
+		}
+		return row, statusError{http.StatusBadRequest, strings.Join(reasons, "; ")}
+	}
+
+	p.SerialCanonical = im.normalizers.For(p.ManufacturerID).Canonical(p.SerialNumber)
+	if p.SerialCanonical == "" {
+		return row, statusError{http.StatusBadRequest, "serial number is empty once normalized"}
+	}
+	if prev, ok := im.seen[p.SerialCanonical]; ok {
+		return row, statusError{http.StatusBadRequest, fmt.Sprintf("serial number is already on row %d", prev)}
+	}
+	im.seen[p.SerialCanonical] = line
+
+	_, err = findBySerial(ctx, im.q, im.normalizers, p.SerialNumber)
+	if err == nil {
+		return row, statusError{http.StatusBadRequest, "equipment already exists in database"}
+	} else if err != sql.ErrNoRows {
+		return row, err
+	}
+	taken, err := im.q.GetEquipmentBySerialCanonicals(ctx, []string{p.SerialCanonical})
+	if err != nil {
+		return row, err
+	} else if len(taken) > 0 {
+		return row, statusError{http.StatusBadRequest, "equipment already exists in database"}
+	}
+
+	if im.field(rec, "product_model_id") != "" {
+		model, err := im.id(rec, "product_model_id")
+		if err != nil {
+			return row, err
+		}
+		if err := checkProductModel(ctx, im.q, model, p.DeviceTypeID, p.ManufacturerID); err != nil {
+			return row, err
+		}
+		p.ProductModelID = sql.NullInt32{Int32: model, Valid: true}
+	}
+
+	var pu purchase
+	err = pu.set(func(name string) (string, bool) {
+		_, ok := im.cols[name]
+		return im.field(rec, name), ok
+	})
+	if err != nil {
+		return row, err
+	}
+	p.PurchaseDate, p.Vendor, p.PurchaseOrder = pu.PurchaseDate, pu.Vendor, pu.PurchaseOrder
+	p.Cost, p.WarrantyStart, p.WarrantyEnd = pu.Cost, pu.WarrantyStart, pu.WarrantyEnd
+
+	schema, ok := im.schemas[p.DeviceTypeID]
+	if !ok {
+		if schema, err = deviceTypeSchema(ctx, im.q, p.DeviceTypeID); err != nil {
+			return row, err
+		}
+		im.schemas[p.DeviceTypeID] = schema
+	}
+	if row.attrs, err = schema.check(im.attributes(rec)); err != nil {
+		return row, err
+	}
+	return row, nil
+}
+
+// ImportEquipment import equipment from csv
+//
+//	@Summary		import equipment from csv
+//	@Description	create equipment from a csv with a header row and the columns serial_number, device_type_id, manufacturer_id and optionally product_model_id, purchase_date, vendor, purchase_order, cost, warranty_start and warranty_end, and an attr.{name} column per attribute, which every required attribute of the device type of a row needs. Every row is checked like POST /equipment, including the serial number rules, and nothing is created unless every row is valid
+//	@Tags			equipment
+//	@Accept			text/csv
+//	@Produce		json
+//	@Param			file	body		string	true	"csv of the equipment to create"
+//	@Success		200		{object}	models.JsonResponse
+//	@Failure		400		{object}	models.JsonResponse{MSG=[]models.ImportRowError}
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/equipment/import [post]
+func (h *EquipmentHandler) ImportEquipment(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/equipment/import")
+		return
+	}
+
+	cr := csv.NewReader(http.MaxBytesReader(w, r.Body, maxImportSize))
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing csv header", "POST /api/v1/equipment/import")
+		return
+	}
+	records, err := cr.ReadAll()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "invalid csv: "+err.Error(), "POST /api/v1/equipment/import")
+		return
+	}
+	if len(records) == 0 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "no rows to import", "POST /api/v1/equipment/import")
+		return
+	}
+	if len(records) > maxImportRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("cannot import more than %d rows at once", maxImportRows), "POST /api/v1/equipment/import")
+		return
+	}
+
+	im := importer{
+		q:             q,
+		cols:          map[string]int{},
+		seen:          map[string]int{},
+		devices:       map[int32]sqlc.DeviceType{},
+		manufacturers: map[int32]sqlc.Manufacturer{},
+		schemas:       map[int32]attributeSchema{},
+	}
+	for i, name := range header {
+		im.cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importColumns {
+		if _, ok := im.cols[name]; !ok {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "missing csv column "+name, "POST /api/v1/equipment/import")
+			return
+		}
+	}
+
+	rules, err := q.GetSerialNumberRules(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial number rules", "POST /api/v1/equipment/import")
+		return
+	}
+	for _, v := range rules {
+		im.rules = append(im.rules, serialRuleFromRow(v))
+	}
+	im.normalizers, err = serialNormalizers(r.Context(), q)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "POST /api/v1/equipment/import")
+		return
+	}
+
+	rowErrors := []models.ImportRowError{}
+	rows := make([]importRow, 0, len(records))
+	for n, rec := range records {
+		// NOTE: rows are numbered like the csv lines, the header is row 1
+		line := n + 2
+		p, err := im.row(r.Context(), rec, line)
+		if se, ok := asStatusError(err); ok {
+			rowErrors = append(rowErrors, models.ImportRowError{Row: line, SerialNumber: p.params.SerialNumber, Error: se.msg})
+			continue
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for row "+strconv.Itoa(line), "POST /api/v1/equipment/import")
+			return
+		}
+		rows = append(rows, p)
+	}
+	if len(rowErrors) > 0 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, rowErrors, "POST /api/v1/equipment/import")
+		return
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		for _, v := range rows {
+			id, err := q.CreateEquipment(r.Context(), v.params)
+			if err != nil {
+				return err
+			}
+			if err := im.schemas[v.params.DeviceTypeID].store(r.Context(), q, int32(id), v.attrs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create equipment in database", "POST /api/v1/equipment/import")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, fmt.Sprintf("%d equipment imported", len(rows)))
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/label"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+const (
+	// defaultLabelScale is how many pixels a module is drawn with when scale is left out
+	defaultLabelScale = 4
+	// maxLabelSheet is the most equipment a sheet of labels can be printed for
+	maxLabelSheet = 1000
+)
+
+// labelOptions is what goes on a label, read from the query string
+type labelOptions struct {
+	symbology label.Symbology
+	// content is sn, id or url
+	content string
+}
+
+func parseLabelOptions(r *http.Request) (labelOptions, error) {
+	o := labelOptions{symbology: label.Code128, content: "sn"}
+	if v := r.FormValue("symbology"); v != "" {
+		sym, err := label.Parse(v)
+		if err != nil {
+			return o, statusError{http.StatusBadRequest, err.Error()}
+		}
+		o.symbology = sym
+	}
+	switch v := r.FormValue("content"); v {
+	case "":
+	case "sn", "id", "url":
+		o.content = v
+	default:
+		return o, statusError{http.StatusBadRequest, "content must be one of sn, id or url"}
+	}
+	return o, nil
+}
+
+// equipmentURL is the url equipment can be looked up on, printed in url labels
+func (h *EquipmentHandler) equipmentURL(id int32) string {
+	return fmt.Sprintf("%s/api/v1/equipment/id?id=%d", strings.TrimSuffix(h.BaseURL, "/"), id)
+}
+
+// labelNames returns the names of every device type and manufacturer by id, for the text
+// printed on labels
+func labelNames(ctx context.Context, q *sqlc.Queries) (devices, manufacturers map[int32]string, err error) {
+	d, err := q.GetDeviceTypes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	devices = map[int32]string{}
+	for _, v := range d {
+		devices[v.ID] = v.Name
+	}
+	m, err := q.GetManufacturers(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	manufacturers = map[int32]string{}
+	for _, v := range m {
+		manufacturers[v.ID] = v.Name
+	}
+	return devices, manufacturers, nil
+}
+
+// newLabel encodes the label of e, the text is its serial number, what it is and its id
+func (h *EquipmentHandler) newLabel(e models.Equipment, o labelOptions, devices, manufacturers map[int32]string) (label.Label, error) {
+	content := e.SerialNumber
+	switch o.content {
+	case "id":
+		content = strconv.Itoa(int(e.AutoID))
+	case "url":
+		content = h.equipmentURL(e.AutoID)
+	}
+	c, err := label.Encode(o.symbology, content)
+	if err != nil {
+		return label.Label{}, statusError{http.StatusBadRequest, fmt.Sprintf("equipment %d: %s", e.AutoID, err)}
+	}
+	return label.Label{
+		Code: c,
+		Lines: []string{
+			e.SerialNumber,
+			strings.TrimSpace(manufacturers[e.ManufacturerID] + " " + devices[e.DeviceTypeID]),
+			fmt.Sprintf("#%d", e.AutoID),
+		},
+	}, nil
+}
+
+// GetEquipmentLabel get the barcode or QR code of equipment
+//
+//	@Summary		get the barcode or QR code of equipment
+//	@Description	render a Code128 barcode or a QR code of the serial number, auto_id or lookup url of equipment as png or svg, or a full label as ZPL for 2 x 1 inch stock on a 203 dpi thermal printer
+//	@Tags			label
+//	@Produce		png
+//	@Produce		image/svg+xml
+//	@Produce		application/zpl
+//	@Param			id			path		int		true	"equipment id"	minimum(1)
+//	@Param			symbology	query		string	false	"kind of code"	Enums(code128, qr)	default(code128)
+//	@Param			content		query		string	false	"what the code holds"	Enums(sn, id, url)	default(sn)
+//	@Param			format		query		string	false	"output format"	Enums(png, svg, zpl)	default(png)
+//	@Param			scale		query		int		false	"pixels per module"	minimum(1)	maximum(20)	default(4)
+//	@Success		200			{file}		file
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/label/equipment/{id} [get]
+func (h *EquipmentHandler) GetEquipmentLabel(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/label/equipment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id is not a number", "GET /api/v1/label/equipment/{id}")
+		return
+	}
+	o, err := parseLabelOptions(r)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/label/equipment/{id}")
+		return
+	}
+	format := r.FormValue("format")
+	switch format {
+	case "":
+		format = "png"
+	case "png", "svg", "zpl":
+	default:
+		helpers.JsonResponseError(w, http.StatusBadRequest, "format must be one of png, svg or zpl", "GET /api/v1/label/equipment/{id}")
+		return
+	}
+	scale := defaultLabelScale
+	if v := r.FormValue("scale"); v != "" {
+		scale, err = strconv.Atoi(v)
+		if err != nil || scale < 1 || scale > label.MaxScale {
+			helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("scale must be a number from 1 to %d", label.MaxScale), "GET /api/v1/label/equipment/{id}")
+			return
+		}
+	}
+
+	d, err := q.GetEquipmentByAutoID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id does not exist in database", "GET /api/v1/label/equipment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/label/equipment/{id}")
+		return
+	}
+	devices, manufacturers, err := labelNames(r.Context(), q)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for names", "GET /api/v1/label/equipment/{id}")
+		return
+	}
+
+	l, err := h.newLabel(equipmentFromRow(d), o, devices, manufacturers)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/label/equipment/{id}")
+		return
+	}
+
+	// NOTE: rendered into a buffer first so a failure can still be sent as json
+	var b bytes.Buffer
+	var contentType string
+	switch format {
+	case "png":
+		contentType, err = "image/png", l.Code.PNG(&b, scale)
+	case "svg":
+		contentType, err = "image/svg+xml", l.Code.SVG(&b, scale)
+	case "zpl":
+		contentType, err = "application/zpl", label.ZPL(&b, []label.Label{l})
+	}
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to render label", "GET /api/v1/label/equipment/{id}")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="label-%d.%s"`, i, format))
+	w.Write(b.Bytes())
+}
+
+// GetLabelSheet get printable labels for equipment
+//
+//	@Summary		get printable labels for equipment
+//	@Description	render labels for the equipment matching the search filters, as a PDF laid out for sheets of label stock or as ZPL for 2 x 1 inch stock on a 203 dpi thermal printer. Each label has the code and the serial number, manufacturer, device type and id of the equipment. The letter layout fits Avery 5160 stock and the a4 layout Avery L7160 stock. At most 1000 equipment can match the filters
+//	@Tags			label
+//	@Produce		application/pdf
+//	@Produce		application/zpl
+//	@Param			symbology		query		string	false	"kind of code"	Enums(code128, qr)	default(code128)
+//	@Param			content			query		string	false	"what the code holds"	Enums(sn, id, url)	default(sn)
+//	@Param			format			query		string	false	"output format"	Enums(pdf, zpl)	default(pdf)
+//	@Param			layout			query		string	false	"label stock the pdf is laid out for"	Enums(letter, a4)	default(letter)
+//	@Param			skip			query		int		false	"number of labels already used on the first sheet"	minimum(0)
+//	@Param			device			query		int		false	"device id"	minimum(1)
+//	@Param			manufacturer	query		int		false	"manufacturer id"	minimum(1)
+//	@Param			location		query		int		false	"location id, only equipment directly at it"	minimum(1)
+//	@Param			model			query		int		false	"product model id"	minimum(1)
+//	@Param			status			query		string	false	"equipment status"	Enums(active, inactive)
+//	@Param			state			query		string	false	"lifecycle state"	Enums(received, in_stock, deployed, in_repair, lost, retired, disposed)
+//	@Param			sn				query		string	false	"part of the serial number, as typed or in canonical form"
+//	@Param			attr.{name}		query		string	false	"attribute value, for example attr.imei=356938035643809"
+//	@Param			tag				query		[]string	false	"tags equipment has to carry, repeated or comma separated"	collectionFormat(multi)
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/label/sheet [get]
+func (h *EquipmentHandler) GetLabelSheet(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/label/sheet")
+		return
+	}
+
+	o, err := parseLabelOptions(r)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/label/sheet")
+		return
+	}
+	format := r.FormValue("format")
+	switch format {
+	case "":
+		format = "pdf"
+	case "pdf", "zpl":
+	default:
+		helpers.JsonResponseError(w, http.StatusBadRequest, "format must be either pdf or zpl", "GET /api/v1/label/sheet")
+		return
+	}
+	layout, ok := label.Layouts["letter"], true
+	if v := r.FormValue("layout"); v != "" {
+		layout, ok = label.Layouts[v]
+	}
+	if !ok {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "layout must be either letter or a4", "GET /api/v1/label/sheet")
+		return
+	}
+	skip := 0
+	if v := r.FormValue("skip"); v != "" {
+		skip, err = strconv.Atoi(v)
+		if err != nil || skip < 0 || skip >= layout.PerPage() {
+			helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("skip must be a number from 0 to %d", layout.PerPage()-1), "GET /api/v1/label/sheet")
+			return
+		}
+	}
+	s, err := parseEquipmentSearch(r)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "GET /api/v1/label/sheet")
+		return
+	}
+
+	// NOTE: one more than a sheet can hold is fetched so too many matches are noticed
+	e, err := searchEquipment(r.Context(), q, &s, maxLabelSheet+1)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/label/sheet")
+		return
+	}
+	if len(e) == 0 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "no equipment matches the filters", "GET /api/v1/label/sheet")
+		return
+	}
+	if len(e) > maxLabelSheet {
+		helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("more than %d equipment match the filters, labels can be printed for at most %d at once", maxLabelSheet, maxLabelSheet), "GET /api/v1/label/sheet")
+		return
+	}
+	devices, manufacturers, err := labelNames(r.Context(), q)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for names", "GET /api/v1/label/sheet")
+		return
+	}
+
+	labels := make([]label.Label, 0, len(e))
+	for _, v := range e {
+		l, err := h.newLabel(v, o, devices, manufacturers)
+		if se, ok := asStatusError(err); ok {
+			helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/label/sheet")
+			return
+		}
+		labels = append(labels, l)
+	}
+
+	var b bytes.Buffer
+	var contentType string
+	if format == "pdf" {
+		contentType, err = "application/pdf", label.Sheet(&b, labels, layout, skip)
+	} else {
+		contentType, err = "application/zpl", label.ZPL(&b, labels)
+	}
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to render labels", "GET /api/v1/label/sheet")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="labels.%s"`, format))
+	w.Write(b.Bytes())
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+// maxReasonLength is the size of lifecycle_transitions.reason
+const maxReasonLength = 255
+
+// transition moves equipment id to state to and records why, returning the state it left.
+// Moves the lifecycle doesn't allow fail with lifecycle.ErrIllegalTransition. q should be
+// bound to a transaction, the row is locked until it ends
+func (h *EquipmentHandler) transition(ctx context.Context, q *sqlc.Queries, id int32, to lifecycle.State, reason string) (lifecycle.State, error) {
+	e, err := q.GetEquipmentByAutoIDForUpdate(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	from := lifecycle.State(e.LifecycleState)
+	if from == to {
+		return from, nil
+	}
+	if err := h.Lifecycle.Check(from, to); err != nil {
+		return from, err
+	}
+
+	err = q.UpdateEquipmentLifecycle(ctx, sqlc.UpdateEquipmentLifecycleParams{
+		AutoID:         id,
+		LifecycleState: sqlc.SerialNumbersLifecycleState(to),
+		Status:         sqlc.SerialNumbersStatus(to.Legacy()),
+	})
+	if err != nil {
+		return from, err
+	}
+	err = q.CreateLifecycleTransition(ctx, sqlc.CreateLifecycleTransitionParams{
+		EquipmentID: id,
+		FromState:   string(from),
+		ToState:     string(to),
+		Reason:      reason,
+	})
+	return from, err
+}
+
+// GetLifecycle get the equipment lifecycle
+//
+//	@Summary		get the equipment lifecycle
+//	@Description	get every lifecycle state and the transitions allowed between them
+//	@Tags			lifecycle
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.JsonResponse{MSG=models.Lifecycle}
+//	@Router			/lifecycle [get]
+func (h *EquipmentHandler) GetLifecycle(w http.ResponseWriter, r *http.Request) {
+	l := models.Lifecycle{Transitions: h.Lifecycle.Transitions()}
+	for _, v := range lifecycle.States {
+		l.States = append(l.States, string(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, l)
+}
+
+// GetEquipmentByLifecycleState get equipment by lifecycle state
+//
+//	@Summary		get equipment by lifecycle state
+//	@Description	get equipment in a lifecycle state from the database
+//	@Tags			lifecycle
+//	@Accept			json
+//	@Produce		json
+//	@Param			state	query		string	true	"lifecycle state"	Enums(received, in_stock, deployed, in_repair, lost, retired, disposed)
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/lifecycle/equipment [get]
+func (h *EquipmentHandler) GetEquipmentByLifecycleState(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/lifecycle/equipment?state={state}")
+		return
+	}
+
+	state, err := lifecycle.Parse(r.FormValue("state"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "GET /api/v1/lifecycle/equipment?state={state}")
+		return
+	}
+
+	d, err := q.GetEquipmentByLifecycleState(r.Context(), sqlc.SerialNumbersLifecycleState(state))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "GET /api/v1/lifecycle/equipment?state={state}")
+		return
+	}
+
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/lifecycle/equipment?state={state}")
+		return
+	}
+
+	e := []models.Equipment{}
+	for _, v := range d {
+		e = append(e, b.equipment(v))
+	}
+
+	respondEquipment(w, r, q, e, "GET /api/v1/lifecycle/equipment?state={state}")
+}
+
+// GetEquipmentLifecycleHistory get the lifecycle transitions of equipment
+//
+//	@Summary		get the lifecycle transitions of equipment
+//	@Description	get every lifecycle transition of equipment, oldest first
+//	@Tags			lifecycle
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"equipment id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.LifecycleTransition}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/lifecycle/equipment/{id} [get]
+func (h *EquipmentHandler) GetEquipmentLifecycleHistory(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/lifecycle/equipment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "GET /api/v1/lifecycle/equipment/{id}")
+		return
+	}
+
+	_, err = q.GetEquipmentByAutoID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id does not exist", "GET /api/v1/lifecycle/equipment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "GET /api/v1/lifecycle/equipment/{id}")
+		return
+	}
+
+	d, err := q.GetLifecycleTransitions(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for lifecycle transitions", "GET /api/v1/lifecycle/equipment/{id}")
+		return
+	}
+
+	t := []models.LifecycleTransition{}
+	for _, v := range d {
+		t = append(t, models.LifecycleTransition{
+			ID:          v.ID,
+			EquipmentID: v.EquipmentID,
+			From:        v.FromState,
+			To:          v.ToState,
+			Reason:      v.Reason,
+			CreatedAt:   v.CreatedAt,
+		})
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, t)
+}
+
+// TransitionEquipment move equipment to another lifecycle state
+//
+//	@Summary		move equipment to another lifecycle state
+//	@Description	move equipment to another lifecycle state, moves the lifecycle does not allow are rejected
+//	@Tags			lifecycle
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"equipment id"		minimum(1)
+//	@Param			state	query		string	true	"lifecycle state"	Enums(received, in_stock, deployed, in_repair, lost, retired, disposed)
+//	@Param			reason	query		string	false	"why the equipment moved"	maxlength(255)
+//	@Success		200		{object}	models.JsonResponse
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		409		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/equipment/{id}/lifecycle [patch]
+func (h *EquipmentHandler) TransitionEquipment(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "PATCH /api/v1/equipment/{id}/lifecycle?state={state}&reason={reason}")
+		return
+	}
+
+	to, err := lifecycle.Parse(r.FormValue("state"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "PATCH /api/v1/equipment/{id}/lifecycle?state={state}&reason={reason}")
+		return
+	}
+
+	reason := r.FormValue("reason")
+	if len(reason) > maxReasonLength {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "reason cannot be longer than 255 characters", "PATCH /api/v1/equipment/{id}/lifecycle?state={state}&reason={reason}")
+		return
+	}
+
+	var from lifecycle.State
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		from, err = h.transition(r.Context(), q, int32(i), to, reason)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id does not exist", "PATCH /api/v1/equipment/{id}/lifecycle?state={state}&reason={reason}")
+		return
+	} else if errors.Is(err, lifecycle.ErrIllegalTransition) {
+		helpers.JsonResponseError(w, http.StatusConflict, err.Error(), "PATCH /api/v1/equipment/{id}/lifecycle?state={state}&reason={reason}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update lifecycle state", "PATCH /api/v1/equipment/{id}/lifecycle?state={state}&reason={reason}")
+		return
+	}
+
+	msg := fmt.Sprintf("equipment with id: %v moved from %v to %v", i, from, to)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, msg)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxListLimit is the most rows a page of a listing can hold
+const maxListLimit = 1000
+
+// likeEscaper escapes the LIKE wildcards so a name search matches them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// listParams are the filters and page of a device type or manufacturer listing
+type listParams struct {
+	// status is active or inactive, empty for both
+	status string
+	// name matches names containing it, ignoring case
+	name   sql.NullString
+	limit  int32
+	offset int32
+	// counts asks for the active and inactive equipment of every row
+	counts bool
+}
+
+// parseListParams reads status, name, limit, offset and counts from the query string.
+// Without a limit every row is returned, like the listings did before they were paged
+func parseListParams(r *http.Request) (listParams, error) {
+	p := listParams{limit: math.MaxInt32}
+
+	p.status = r.FormValue("status")
+	if p.status != "" && p.status != "active" && p.status != "inactive" {
+		return p, statusError{http.StatusBadRequest, "status must be either active or inactive"}
+	}
+	if name := strings.TrimSpace(r.FormValue("name")); name != "" {
+		p.name = sql.NullString{String: "%" + likeEscaper.Replace(name) + "%", Valid: true}
+	}
+	if raw := r.FormValue("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxListLimit {
+			return p, statusError{http.StatusBadRequest, fmt.Sprintf("limit must be a number from 1 to %d", maxListLimit)}
+		}
+		p.limit = int32(n)
+	}
+	if raw := r.FormValue("offset"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 || n > math.MaxInt32 {
+			return p, statusError{http.StatusBadRequest, "offset must be a number of at least 0"}
+		}
+		p.offset = int32(n)
+	}
+	if raw := r.FormValue("counts"); raw != "" {
+		counts, err := strconv.ParseBool(raw)
+		if err != nil {
+			return p, statusError{http.StatusBadRequest, "counts must be true or false"}
+		}
+		p.counts = counts
+	}
+	return p, nil
+}
+
+// setTotalCount tells the client how many rows match the filters across all pages
+func setTotalCount(w http.ResponseWriter, total int64) {
+	w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+}
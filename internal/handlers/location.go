@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/location"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+type LocationHandler struct{}
+
+// nullInt32 returns nil for a NULL column so it encodes as null
+func nullInt32(v sql.NullInt32) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int32
+}
+
+func locationFromRow(v sqlc.Location) models.Location {
+	return models.Location{
+		ID:       v.ID,
+		ParentID: nullInt32(v.ParentID),
+		Name:     v.Name,
+		Kind:     string(v.Kind),
+	}
+}
+
+// locationTree loads every location into a tree
+func locationTree(rows []sqlc.Location) *location.Tree {
+	nodes := make([]location.Node, 0, len(rows))
+	for _, v := range rows {
+		nodes = append(nodes, location.Node{ID: v.ID, Parent: v.ParentID.Int32})
+	}
+	return location.NewTree(nodes)
+}
+
+// GetLocations get all locations
+//
+//	@Summary		get all locations
+//	@Description	get every location, parent_id links them into a tree
+//	@Tags			location
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Location}
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/location [get]
+func (h *LocationHandler) GetLocations(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/location")
+		return
+	}
+	d, err := q.GetLocations(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/location")
+		return
+	}
+
+	out := []models.Location{}
+	for _, v := range d {
+		out = append(out, locationFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
+
+// GetLocationByID get a location by ID
+//
+//	@Summary		get a location by ID
+//	@Description	get a location by ID from the database
+//	@Tags			location
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"location id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=models.Location}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/location/{id} [get]
+func (h *LocationHandler) GetLocationByID(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/location/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "location id is not a number", "GET /api/v1/location/{id}")
+		return
+	}
+
+	d, err := q.GetLocationByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "location id does not exist in database", "GET /api/v1/location/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/location/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, locationFromRow(d))
+}
+
+// CreateLocation create a location
+//
+//	@Summary		create location
+//	@Description	create a location, sites have no parent, buildings go in sites, rooms in buildings, racks in rooms and shelves in rooms or racks
+//	@Tags			location
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	query		string	true	"location name"	maxlength(100)
+//	@Param			kind	query		string	true	"location kind"	Enums(site, building, room, rack, shelf)
+//	@Param			parent	query		int		false	"parent location id, required for everything but sites"	minimum(1)
+//	@Success		200		{object}	models.JsonResponse{MSG=models.Location}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/location [post]
+func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+		return
+	}
+
+	name := r.FormValue("name")
+	if name == "" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing name", "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+		return
+	}
+	if len(name) > 100 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "name cannot be longer than 100 characters", "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+		return
+	}
+
+	kind, err := location.ParseKind(r.FormValue("kind"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+		return
+	}
+
+	var parent sql.NullInt32
+	var parentKind location.Kind
+	if pid := r.FormValue("parent"); pid != "" {
+		p, err := strconv.Atoi(pid)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "parent id is not a number", "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+			return
+		}
+		d, err := q.GetLocationByID(r.Context(), int32(p))
+		if err == sql.ErrNoRows {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "parent location does not exist in database", "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+			return
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+			return
+		}
+		parent = sql.NullInt32{Int32: d.ID, Valid: true}
+		parentKind = location.Kind(d.Kind)
+	}
+
+	if err := location.CheckParent(kind, parentKind); err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+		return
+	}
+
+	id, err := q.CreateLocation(r.Context(), sqlc.CreateLocationParams{ParentID: parent, Name: name, Kind: sqlc.LocationsKind(kind)})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create location in database", "POST /api/v1/location?name={name}&kind={kind}&parent={parent_id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, models.Location{ID: int32(id), ParentID: nullInt32(parent), Name: name, Kind: string(kind)})
+}
+
+// GetLocationCounts get equipment counts per location
+//
+//	@Summary		get equipment counts per location
+//	@Description	get the equipment at each location directly and rolled up with every location below it
+//	@Tags			location
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.LocationCount}
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/location/counts [get]
+func (h *LocationHandler) GetLocationCounts(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/location/counts")
+		return
+	}
+	locations, err := q.GetLocations(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/location/counts")
+		return
+	}
+	d, err := q.CountEquipmentByLocation(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/location/counts")
+		return
+	}
+
+	direct := map[int32]int64{}
+	for _, v := range d {
+		direct[v.LocationID.Int32] = v.Total
+	}
+	total := locationTree(locations).RollUp(direct)
+
+	out := []models.LocationCount{}
+	for _, v := range locations {
+		out = append(out, models.LocationCount{
+			Location: locationFromRow(v),
+			Direct:   direct[v.ID],
+			Total:    total[v.ID],
+		})
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
+
+// GetEquipmentByLocation get equipment under a location
+//
+//	@Summary		get equipment under a location
+//	@Description	get equipment at a location or any location below it
+//	@Tags			equipment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"location id"	minimum(1)
+//	@Param			all		query		bool	false	"set to true to get all equipment, otherwise only active equipment is returned"
+//	@Param			expand	query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields	query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.Equipment}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/equipment/location/{id} [get]
+func (h *EquipmentHandler) GetEquipmentByLocation(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/equipment/location/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "location id is not a number", "GET /api/v1/equipment/location/{id}")
+		return
+	}
+
+	locations, err := q.GetLocations(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/equipment/location/{id}")
+		return
+	}
+	found := false
+	for _, v := range locations {
+		found = found || v.ID == int32(i)
+	}
+	if !found {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "location id does not exist in database", "GET /api/v1/equipment/location/{id}")
+		return
+	}
+
+	var ids []sql.NullInt32
+	for _, v := range locationTree(locations).Subtree(int32(i)) {
+		ids = append(ids, sql.NullInt32{Int32: v, Valid: true})
+	}
+
+	d, err := q.GetEquipmentByLocations(r.Context(), ids)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "GET /api/v1/equipment/location/{id}")
+		return
+	}
+
+	b, err := newBookValuer(r.Context(), q, today())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/equipment/location/{id}")
+		return
+	}
+
+	all := r.FormValue("all") == "true"
+	e := []models.Equipment{}
+	for _, v := range d {
+		if all || v.Status == sqlc.SerialNumbersStatusActive {
+			e = append(e, b.equipment(v))
+		}
+	}
+
+	respondEquipment(w, r, q, e, "GET /api/v1/equipment/location/{id}")
+}
+
+// MoveEquipment move equipment to a location
+//
+//	@Summary		move equipment to a location
+//	@Description	move equipment to a location and record the move
+//	@Tags			location
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"equipment id"	minimum(1)
+//	@Param			location	query		int		true	"location id"	minimum(1)
+//	@Param			reason		query		string	false	"why the equipment moved"	maxlength(255)
+//	@Success		200			{object}	models.JsonResponse
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/equipment/{id}/location [patch]
+func (h *EquipmentHandler) MoveEquipment(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "PATCH /api/v1/equipment/{id}/location?location={location_id}&reason={reason}")
+		return
+	}
+
+	l, err := strconv.Atoi(r.FormValue("location"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "location id is not a number", "PATCH /api/v1/equipment/{id}/location?location={location_id}&reason={reason}")
+		return
+	}
+
+	reason := r.FormValue("reason")
+	if len(reason) > maxReasonLength {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "reason cannot be longer than 255 characters", "PATCH /api/v1/equipment/{id}/location?location={location_id}&reason={reason}")
+		return
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		return moveEquipment(r.Context(), q, int32(i), int32(l), reason)
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment/{id}/location?location={location_id}&reason={reason}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to move equipment", "PATCH /api/v1/equipment/{id}/location?location={location_id}&reason={reason}")
+		return
+	}
+
+	msg := fmt.Sprintf("equipment with id: %v moved to location %v", i, l)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, msg)
+}
+
+// moveEquipment moves equipment id to location to and records the move, q should be
+// bound to a transaction
+func moveEquipment(ctx context.Context, q *sqlc.Queries, id, to int32, reason string) error {
+	e, err := q.GetEquipmentByAutoIDForUpdate(ctx, id)
+	if err == sql.ErrNoRows {
+		return statusError{http.StatusBadRequest, "equipment id does not exist"}
+	} else if err != nil {
+		return err
+	}
+
+	_, err = q.GetLocationByID(ctx, to)
+	if err == sql.ErrNoRows {
+		return statusError{http.StatusBadRequest, "location id does not exist in database"}
+	} else if err != nil {
+		return err
+	}
+	if e.LocationID.Valid && e.LocationID.Int32 == to {
+		return nil
+	}
+
+	dest := sql.NullInt32{Int32: to, Valid: true}
+	if err := q.UpdateEquipmentLocation(ctx, sqlc.UpdateEquipmentLocationParams{AutoID: id, LocationID: dest}); err != nil {
+		return err
+	}
+	return q.CreateLocationMove(ctx, sqlc.CreateLocationMoveParams{
+		EquipmentID:    id,
+		FromLocationID: e.LocationID,
+		ToLocationID:   dest,
+		Reason:         reason,
+	})
+}
+
+// GetEquipmentLocationMoves get the location history of equipment
+//
+//	@Summary		get the location history of equipment
+//	@Description	get every location move of equipment, oldest first
+//	@Tags			location
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"equipment id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.LocationMove}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/location/equipment/{id} [get]
+func (h *EquipmentHandler) GetEquipmentLocationMoves(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/location/equipment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "GET /api/v1/location/equipment/{id}")
+		return
+	}
+
+	_, err = q.GetEquipmentByAutoID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id does not exist", "GET /api/v1/location/equipment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for equipment", "GET /api/v1/location/equipment/{id}")
+		return
+	}
+
+	d, err := q.GetLocationMoves(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "failed to query database for location moves", "GET /api/v1/location/equipment/{id}")
+		return
+	}
+
+	m := []models.LocationMove{}
+	for _, v := range d {
+		m = append(m, models.LocationMove{
+			ID:          v.ID,
+			EquipmentID: v.EquipmentID,
+			From:        nullInt32(v.FromLocationID),
+			To:          nullInt32(v.ToLocationID),
+			Reason:      v.Reason,
+			CreatedAt:   v.CreatedAt,
+		})
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, m)
+}
@@ -0,0 +1,607 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+// defaultReportDays is how far back the maintenance report looks when from is left out
+const defaultReportDays = 365
+
+func maintenanceTicketFromRow(v sqlc.MaintenanceTicket) models.MaintenanceTicket {
+	t := models.MaintenanceTicket{
+		ID:          v.ID,
+		EquipmentID: v.EquipmentID,
+		Issue:       v.Issue,
+		Vendor:      v.Vendor,
+		OpenedAt:    v.OpenedAt.Format(dateLayout),
+		ClosedAt:    nullDate(v.ClosedAt),
+		Cost:        nullCost(v.Cost),
+		ReturnState: v.ReturnState,
+		CreatedAt:   v.CreatedAt,
+	}
+	if v.Outcome.Valid {
+		o := string(v.Outcome.MaintenanceTicketsOutcome)
+		t.Outcome = &o
+	}
+	return t
+}
+
+// parseOutcome returns the outcome named s
+func parseOutcome(s string) (sqlc.MaintenanceTicketsOutcome, error) {
+	switch o := sqlc.MaintenanceTicketsOutcome(s); o {
+	case sqlc.MaintenanceTicketsOutcomeRepaired, sqlc.MaintenanceTicketsOutcomeReplaced, sqlc.MaintenanceTicketsOutcomeUnrepairable:
+		return o, nil
+	}
+	return "", statusError{http.StatusBadRequest, "outcome must be one of repaired, replaced or unrepairable"}
+}
+
+// closeState returns the lifecycle state equipment moves to when its ticket closes with
+// outcome, repaired equipment goes back to where it was before the repair and equipment
+// that was replaced or can't be repaired is retired
+func closeState(t sqlc.MaintenanceTicket, outcome sqlc.MaintenanceTicketsOutcome) lifecycle.State {
+	if outcome != sqlc.MaintenanceTicketsOutcomeRepaired {
+		return lifecycle.Retired
+	}
+	s, err := lifecycle.Parse(t.ReturnState)
+	if err != nil || s == lifecycle.InRepair {
+		return lifecycle.InStock
+	}
+	return s
+}
+
+// checkTicketText checks the free text fields of a ticket
+func checkTicketText(issue, vendor string) error {
+	if issue == "" {
+		return statusError{http.StatusBadRequest, "missing issue"}
+	}
+	if utf8.RuneCountInString(issue) > maxReasonLength {
+		return statusError{http.StatusBadRequest, "issue cannot be longer than 255 characters"}
+	}
+	if utf8.RuneCountInString(vendor) > maxVendorLength {
+		return statusError{http.StatusBadRequest, fmt.Sprintf("vendor cannot be longer than %d characters", maxVendorLength)}
+	}
+	return nil
+}
+
+// GetMaintenanceTickets get all maintenance tickets
+//
+//	@Summary		get all maintenance tickets
+//	@Description	get all maintenance tickets, oldest first
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			open	query		bool	false	"set to true to only get open tickets"
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.MaintenanceTicket}
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/maintenance [get]
+func (h *EquipmentHandler) GetMaintenanceTickets(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/maintenance")
+		return
+	}
+
+	var d []sqlc.MaintenanceTicket
+	if r.FormValue("open") == "true" {
+		d, err = q.GetOpenMaintenanceTickets(r.Context())
+	} else {
+		d, err = q.GetMaintenanceTickets(r.Context())
+	}
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for maintenance tickets", "GET /api/v1/maintenance")
+		return
+	}
+
+	t := []models.MaintenanceTicket{}
+	for _, v := range d {
+		t = append(t, maintenanceTicketFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, t)
+}
+
+// GetMaintenanceTicket get maintenance ticket by id
+//
+//	@Summary		get maintenance ticket by id
+//	@Description	get maintenance ticket by id
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"maintenance ticket id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=models.MaintenanceTicket}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/maintenance/{id} [get]
+func (h *EquipmentHandler) GetMaintenanceTicket(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/maintenance/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "GET /api/v1/maintenance/{id}")
+		return
+	}
+
+	d, err := q.GetMaintenanceTicketByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "maintenance ticket id does not exist", "GET /api/v1/maintenance/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for maintenance ticket", "GET /api/v1/maintenance/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, maintenanceTicketFromRow(d))
+}
+
+// GetEquipmentMaintenance get the maintenance history of equipment
+//
+//	@Summary		get the maintenance history of equipment
+//	@Description	get every maintenance ticket of equipment, oldest first
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"equipment id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.MaintenanceTicket}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/maintenance/equipment/{id} [get]
+func (h *EquipmentHandler) GetEquipmentMaintenance(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/maintenance/equipment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "GET /api/v1/maintenance/equipment/{id}")
+		return
+	}
+
+	_, err = q.GetEquipmentByAutoID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id does not exist", "GET /api/v1/maintenance/equipment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/maintenance/equipment/{id}")
+		return
+	}
+
+	d, err := q.GetMaintenanceTicketsByEquipment(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for maintenance tickets", "GET /api/v1/maintenance/equipment/{id}")
+		return
+	}
+
+	t := []models.MaintenanceTicket{}
+	for _, v := range d {
+		t = append(t, maintenanceTicketFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, t)
+}
+
+// OpenMaintenanceTicket open a maintenance ticket for equipment
+//
+//	@Summary		open a maintenance ticket for equipment
+//	@Description	open a maintenance ticket for equipment and move it to in_repair. Equipment can only have one open ticket
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"equipment id"						minimum(1)
+//	@Param			issue		query		string	true	"what is wrong with the equipment"	maxlength(255)
+//	@Param			vendor		query		string	false	"who is doing the repair"			maxlength(100)
+//	@Param			opened_at	query		string	false	"day the ticket was opened like 2024-05-01, defaults to today"
+//	@Param			cost		query		string	false	"what the repair costs, like 149.00"
+//	@Success		200			{object}	models.JsonResponse{MSG=models.MaintenanceTicket}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		409			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/equipment/{id}/maintenance [post]
+func (h *EquipmentHandler) OpenMaintenanceTicket(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "POST /api/v1/equipment/{id}/maintenance?issue={issue}")
+		return
+	}
+
+	issue := strings.TrimSpace(r.FormValue("issue"))
+	vendor := strings.TrimSpace(r.FormValue("vendor"))
+	if err := checkTicketText(issue, vendor); err != nil {
+		se, _ := asStatusError(err)
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment/{id}/maintenance?issue={issue}")
+		return
+	}
+	opened, err := parseDate("opened_at", strings.TrimSpace(r.FormValue("opened_at")))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment/{id}/maintenance?issue={issue}")
+		return
+	}
+	if !opened.Valid {
+		opened = sql.NullTime{Time: today(), Valid: true}
+	}
+	cost, err := parseCost(strings.TrimSpace(r.FormValue("cost")))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment/{id}/maintenance?issue={issue}")
+		return
+	}
+
+	var t sqlc.MaintenanceTicket
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		e, err := q.GetEquipmentByAutoIDForUpdate(r.Context(), int32(i))
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "equipment id does not exist"}
+		} else if err != nil {
+			return err
+		}
+
+		open, err := q.GetOpenMaintenanceTicketByEquipment(r.Context(), int32(i))
+		if err == nil {
+			return statusError{http.StatusConflict, fmt.Sprintf("equipment already has open maintenance ticket %v", open.ID)}
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		id, err := q.CreateMaintenanceTicket(r.Context(), sqlc.CreateMaintenanceTicketParams{
+			EquipmentID: int32(i),
+			Issue:       issue,
+			Vendor:      vendor,
+			OpenedAt:    opened.Time,
+			Cost:        cost,
+			ReturnState: string(e.LifecycleState),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = h.transition(r.Context(), q, int32(i), lifecycle.InRepair, fmt.Sprintf("maintenance ticket %v opened", id))
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			return statusError{http.StatusConflict, "cannot open ticket, " + err.Error()}
+		} else if err != nil {
+			return err
+		}
+
+		t, err = q.GetMaintenanceTicketByID(r.Context(), int32(id))
+		return err
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment/{id}/maintenance?issue={issue}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to open maintenance ticket", "POST /api/v1/equipment/{id}/maintenance?issue={issue}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, maintenanceTicketFromRow(t))
+}
+
+// UpdateMaintenanceTicket update a maintenance ticket
+//
+//	@Summary		update a maintenance ticket
+//	@Description	update the issue, vendor or cost of a maintenance ticket. Only the fields given are changed, give cost with an empty value to clear it
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"maintenance ticket id"				minimum(1)
+//	@Param			issue	query		string	false	"what is wrong with the equipment"	maxlength(255)
+//	@Param			vendor	query		string	false	"who is doing the repair"			maxlength(100)
+//	@Param			cost	query		string	false	"what the repair costs, like 149.00"
+//	@Success		200		{object}	models.JsonResponse{MSG=models.MaintenanceTicket}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/maintenance/{id} [patch]
+func (h *EquipmentHandler) UpdateMaintenanceTicket(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "PATCH /api/v1/maintenance/{id}")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "invalid form", "PATCH /api/v1/maintenance/{id}")
+		return
+	}
+
+	var t sqlc.MaintenanceTicket
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		t, err = q.GetMaintenanceTicketByIDForUpdate(r.Context(), int32(i))
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "maintenance ticket id does not exist"}
+		} else if err != nil {
+			return err
+		}
+
+		if r.Form.Has("issue") {
+			t.Issue = strings.TrimSpace(r.Form.Get("issue"))
+		}
+		if r.Form.Has("vendor") {
+			t.Vendor = strings.TrimSpace(r.Form.Get("vendor"))
+		}
+		if err := checkTicketText(t.Issue, t.Vendor); err != nil {
+			return err
+		}
+		if r.Form.Has("cost") {
+			if t.Cost, err = parseCost(strings.TrimSpace(r.Form.Get("cost"))); err != nil {
+				return err
+			}
+		}
+
+		return q.UpdateMaintenanceTicket(r.Context(), sqlc.UpdateMaintenanceTicketParams{ID: t.ID, Issue: t.Issue, Vendor: t.Vendor, Cost: t.Cost})
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/maintenance/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update maintenance ticket in database", "PATCH /api/v1/maintenance/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, maintenanceTicketFromRow(t))
+}
+
+// CloseMaintenanceTicket close a maintenance ticket
+//
+//	@Summary		close a maintenance ticket
+//	@Description	close a maintenance ticket. Equipment still in_repair moves back to the state it was in before the ticket opened when it was repaired, and is retired when it was replaced or unrepairable
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"maintenance ticket id"	minimum(1)
+//	@Param			outcome		query		string	true	"how the ticket ended"	Enums(repaired, replaced, unrepairable)
+//	@Param			closed_at	query		string	false	"day the ticket was closed like 2024-05-09, defaults to today"
+//	@Param			cost		query		string	false	"what the repair cost, like 149.00, leave out to keep the cost on the ticket"
+//	@Success		200			{object}	models.JsonResponse{MSG=models.MaintenanceTicket}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		409			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/maintenance/{id}/close [post]
+func (h *EquipmentHandler) CloseMaintenanceTicket(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "POST /api/v1/maintenance/{id}/close?outcome={outcome}")
+		return
+	}
+
+	outcome, err := parseOutcome(r.FormValue("outcome"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/maintenance/{id}/close?outcome={outcome}")
+		return
+	}
+	closed, err := parseDate("closed_at", strings.TrimSpace(r.FormValue("closed_at")))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/maintenance/{id}/close?outcome={outcome}")
+		return
+	}
+	if !closed.Valid {
+		closed = sql.NullTime{Time: today(), Valid: true}
+	}
+
+	var t sqlc.MaintenanceTicket
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		t, err = q.GetMaintenanceTicketByIDForUpdate(r.Context(), int32(i))
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "maintenance ticket id does not exist"}
+		} else if err != nil {
+			return err
+		}
+		if t.ClosedAt.Valid {
+			return statusError{http.StatusConflict, "maintenance ticket is already closed"}
+		}
+		if closed.Time.Before(t.OpenedAt) {
+			return statusError{http.StatusBadRequest, "closed_at cannot be before the ticket was opened"}
+		}
+		if r.Form.Has("cost") {
+			if t.Cost, err = parseCost(strings.TrimSpace(r.Form.Get("cost"))); err != nil {
+				return err
+			}
+		}
+		t.ClosedAt = closed
+		t.Outcome = sqlc.NullMaintenanceTicketsOutcome{MaintenanceTicketsOutcome: outcome, Valid: true}
+
+		err = q.CloseMaintenanceTicket(r.Context(), sqlc.CloseMaintenanceTicketParams{ID: t.ID, ClosedAt: t.ClosedAt, Outcome: t.Outcome, Cost: t.Cost})
+		if err != nil {
+			return err
+		}
+
+		// NOTE: equipment moved out of in_repair by hand while the ticket was open stays where it is
+		e, err := q.GetEquipmentByAutoIDForUpdate(r.Context(), t.EquipmentID)
+		if err != nil {
+			return err
+		}
+		if lifecycle.State(e.LifecycleState) != lifecycle.InRepair {
+			return nil
+		}
+		_, err = h.transition(r.Context(), q, t.EquipmentID, closeState(t, outcome), fmt.Sprintf("maintenance ticket %v closed %v", t.ID, outcome))
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			return statusError{http.StatusConflict, "cannot close ticket, " + err.Error()}
+		}
+		return err
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/maintenance/{id}/close?outcome={outcome}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to close maintenance ticket", "POST /api/v1/maintenance/{id}/close?outcome={outcome}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, maintenanceTicketFromRow(t))
+}
+
+// maintenanceTally adds up the tickets of one manufacturer or device type
+type maintenanceTally struct {
+	report    models.MaintenanceReport
+	equipment map[int32]bool
+	cost      int64
+	days      int
+	closed    int
+}
+
+// maintenanceReport adds up the tickets opened between from and to, grouped by manufacturer
+// or device type
+func maintenanceReport(ctx context.Context, q *sqlc.Queries, group string, from, to sql.NullTime) ([]models.MaintenanceReport, error) {
+	tallies := map[int32]*maintenanceTally{}
+	tally := func(id int32) *maintenanceTally {
+		t, ok := tallies[id]
+		if !ok {
+			t = &maintenanceTally{report: models.MaintenanceReport{ID: id}, equipment: map[int32]bool{}}
+			tallies[id] = t
+		}
+		return t
+	}
+	key := func(device, manufacturer int32) int32 {
+		if group == "device" {
+			return device
+		}
+		return manufacturer
+	}
+
+	counts, err := q.GetEquipmentCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range counts {
+		tally(key(v.DeviceTypeID, v.ManufacturerID)).report.Equipment += v.Count
+	}
+
+	tickets, err := q.GetMaintenanceTicketsOpenedBetween(ctx, sqlc.GetMaintenanceTicketsOpenedBetweenParams{From: from.Time, To: to.Time})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range tickets {
+		t := tally(key(v.DeviceTypeID, v.ManufacturerID))
+		mt := v.MaintenanceTicket
+		t.report.Tickets++
+		t.equipment[mt.EquipmentID] = true
+		if mt.Cost.Valid {
+			t.cost += cents(mt.Cost.String)
+		}
+		if mt.ClosedAt.Valid {
+			t.closed++
+			t.days += int(mt.ClosedAt.Time.Sub(mt.OpenedAt).Hours() / 24)
+		} else {
+			t.report.OpenTickets++
+		}
+	}
+
+	names := map[int32]string{}
+	if group == "device" {
+		d, err := q.GetDeviceTypes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range d {
+			names[v.ID] = v.Name
+		}
+	} else {
+		m, err := q.GetManufacturers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range m {
+			names[v.ID] = v.Name
+		}
+	}
+
+	reports := make([]models.MaintenanceReport, 0, len(tallies))
+	for id, t := range tallies {
+		rep := t.report
+		rep.Name = names[id]
+		rep.RepairedEquipment = len(t.equipment)
+		rep.TotalCost = json.Number(formatCents(t.cost))
+		if rep.Equipment > 0 {
+			rep.TicketsPerEquipment = math.Round(float64(rep.Tickets)/float64(rep.Equipment)*1000) / 1000
+		}
+		if t.closed > 0 {
+			avg := math.Round(float64(t.days)/float64(t.closed)*10) / 10
+			rep.AverageRepairDays = &avg
+		}
+		reports = append(reports, rep)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].TicketsPerEquipment != reports[j].TicketsPerEquipment {
+			return reports[i].TicketsPerEquipment > reports[j].TicketsPerEquipment
+		}
+		return reports[i].ID < reports[j].ID
+	})
+	return reports, nil
+}
+
+// GetMaintenanceReport get how often equipment is repaired
+//
+//	@Summary		get how often equipment is repaired
+//	@Description	get the number of maintenance tickets opened between from and to per manufacturer or device type, with the number of equipment each has, what the repairs cost and how long they took. The most repaired come first
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			group	query		string	false	"what to group the tickets by"	Enums(manufacturer, device)	default(manufacturer)
+//	@Param			from	query		string	false	"first day tickets were opened on like 2024-01-01, defaults to a year before to"
+//	@Param			to		query		string	false	"last day tickets were opened on like 2024-12-31, defaults to today"
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.MaintenanceReport}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/maintenance/report [get]
+func (h *EquipmentHandler) GetMaintenanceReport(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/maintenance/report?group={group}&from={from}&to={to}")
+		return
+	}
+
+	group := r.FormValue("group")
+	if group == "" {
+		group = "manufacturer"
+	}
+	if group != "manufacturer" && group != "device" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "group must be manufacturer or device", "GET /api/v1/maintenance/report?group={group}&from={from}&to={to}")
+		return
+	}
+
+	to, err := parseDate("to", r.FormValue("to"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/maintenance/report?group={group}&from={from}&to={to}")
+		return
+	}
+	if !to.Valid {
+		to = sql.NullTime{Time: today(), Valid: true}
+	}
+	from, err := parseDate("from", r.FormValue("from"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/maintenance/report?group={group}&from={from}&to={to}")
+		return
+	}
+	if !from.Valid {
+		from = sql.NullTime{Time: to.Time.AddDate(0, 0, -defaultReportDays), Valid: true}
+	}
+	if to.Time.Before(from.Time) {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "to cannot be before from", "GET /api/v1/maintenance/report?group={group}&from={from}&to={to}")
+		return
+	}
+
+	reports, err := maintenanceReport(r.Context(), q, group, from, to)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for maintenance tickets", "GET /api/v1/maintenance/report?group={group}&from={from}&to={to}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, reports)
+}
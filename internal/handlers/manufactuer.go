@@ -13,38 +13,86 @@ import (
 	"github.com/coltonmosier/api-v1/internal/sqlc"
 )
 
-type ManufactuerHandler struct{}
+type ManufactuerHandler struct {
+	// BaseURL is the url the API is reachable on, used for internal lookups
+	BaseURL string
+}
 
 // GetManufacturers Getting all manufacturers
 //
 //	@Summary		get all manufacturers
-//	@Description	get all manufacturers from the database
+//	@Description	get manufacturers from the database, filtered by status and name and paged with limit and offset. X-Total-Count holds how many manufacturers match across all pages
 //	@Tags			manufacturer
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Manufacturer}
-//	@Failure		500	{object}	models.JsonResponse
+//	@Param			status	query		string	false	"manufacturer status"	Enums(active, inactive)
+//	@Param			name	query		string	false	"part of the manufacturer name"
+//	@Param			limit	query		int		false	"most manufacturers to return, every one when left out"	minimum(1)	maximum(1000)
+//	@Param			offset	query		int		false	"manufacturers to skip"	minimum(0)
+//	@Param			counts	query		bool	false	"include how much equipment of every manufacturer is active and inactive"
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.Manufacturer}
+//	@Header			200		{integer}	X-Total-Count	"manufacturers matching the filters"
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
 //	@Router			/manufacturer [get]
 func (h *ManufactuerHandler) GetManufacturers(w http.ResponseWriter, r *http.Request) {
-	var out []models.Manufacturer
+	out := []models.Manufacturer{}
 	q, err := database.InitEquipmentDatabase()
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/manufacturer")
 		return
 	}
-	d, err := q.GetManufacturersActive(r.Context())
+
+	p, err := parseListParams(r)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/manufacturer")
+		return
+	}
+	status := sqlc.NullManufacturerStatus{ManufacturerStatus: sqlc.ManufacturerStatus(p.status), Valid: p.status != ""}
+
+	d, err := q.ListManufacturers(r.Context(), sqlc.ListManufacturersParams{Status: status, Name: p.name, Limit: p.limit, Offset: p.offset})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for manufacturers", "GET /api/v1/manufacturer")
+		return
+	}
+	total, err := q.CountManufacturers(r.Context(), sqlc.CountManufacturersParams{Status: status, Name: p.name})
 	if err != nil {
-		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/manufacturer")
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for manufacturers", "GET /api/v1/manufacturer")
 		return
 	}
+
+	counts := map[int32]*models.EquipmentCounts{}
+	if p.counts && len(d) > 0 {
+		ids := make([]int32, len(d))
+		for i, v := range d {
+			ids[i] = v.ID
+		}
+		c, err := q.CountEquipmentByManufacturer(r.Context(), ids)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment counts", "GET /api/v1/manufacturer")
+			return
+		}
+		for _, v := range c {
+			counts[v.ID] = &models.EquipmentCounts{Active: v.Active, Inactive: v.Inactive}
+		}
+	}
+
 	for _, v := range d {
-		out = append(out, models.Manufacturer{
+		m := models.Manufacturer{
 			ID:     v.ID,
 			Name:   v.Name,
 			Status: string(v.Status),
-		})
+		}
+		if p.counts {
+			m.Equipment = counts[v.ID]
+			if m.Equipment == nil {
+				m.Equipment = &models.EquipmentCounts{}
+			}
+		}
+		out = append(out, m)
 	}
 
+	setTotalCount(w, total)
 	helpers.JsonResponseSuccess(w, http.StatusOK, out)
 }
 
@@ -124,7 +172,7 @@ func (h *ManufactuerHandler) UpdateManufacturerName(w http.ResponseWriter, r *ht
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/manufacturer/" + id)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + id)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+id, "PATCH /api/v1/manufacturer/{id}/name?name={newName}")
 		return
@@ -191,7 +239,7 @@ func (h *ManufactuerHandler) UpdateManufacturerStatus(w http.ResponseWriter, r *
 		return
 	}
 
-	resp, err := http.Get("http://localhost:8081/api/v1/manufacturer/" + id)
+	resp, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer/" + id)
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer/"+id, "PATCH /api/v1/manufacturer/{id}/status?status={newStatus}")
 		return
@@ -262,7 +310,7 @@ func (h *ManufactuerHandler) CreateManufacturer(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	req, err := http.Get("http://localhost:8081/api/v1/manufacturer")
+	req, err := helpers.Get(r.Context(), h.BaseURL + "/api/v1/manufacturer")
 	if err != nil {
 		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to get response from /api/v1/manufacturer", "POST /api/v1/manufacturer?name={newName}")
 		return
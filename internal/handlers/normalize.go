@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/serial"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+func normalizerFromRow(v sqlc.SerialNormalization) serial.Normalizer {
+	return serial.Normalizer{Trim: v.Trim, CaseFold: v.CaseFold, Separators: v.Separators}
+}
+
+// serialNormalizers returns the normalization of every manufacturer
+func serialNormalizers(ctx context.Context, q *sqlc.Queries) (serial.Normalizers, error) {
+	ns := serial.Normalizers{Default: serial.DefaultNormalizer, Manufacturers: map[int32]serial.Normalizer{}}
+	rows, err := q.GetSerialNormalizations(ctx)
+	if err != nil {
+		return ns, err
+	}
+	for _, v := range rows {
+		ns.Manufacturers[v.ManufacturerID] = normalizerFromRow(v)
+	}
+	return ns, nil
+}
+
+// normalizerFor returns the normalization of a manufacturer
+func normalizerFor(ctx context.Context, q *sqlc.Queries, manufacturerID int32) (serial.Normalizer, error) {
+	v, err := q.GetSerialNormalization(ctx, manufacturerID)
+	if err == sql.ErrNoRows {
+		return serial.DefaultNormalizer, nil
+	} else if err != nil {
+		return serial.Normalizer{}, err
+	}
+	return normalizerFromRow(v), nil
+}
+
+// canonicalSerial returns the canonical form sn is stored with for a manufacturer, or a
+// statusError when nothing is left of sn once it is normalized
+func canonicalSerial(ctx context.Context, q *sqlc.Queries, sn string, manufacturerID int32) (string, error) {
+	n, err := normalizerFor(ctx, q, manufacturerID)
+	if err != nil {
+		return "", err
+	}
+	c := n.Canonical(sn)
+	if c == "" {
+		return "", statusError{http.StatusBadRequest, "serial number is empty once normalized"}
+	}
+	return c, nil
+}
+
+// findBySerial returns the equipment sn is the serial number of under its manufacturer's
+// normalization, sql.ErrNoRows when there is none
+func findBySerial(ctx context.Context, q *sqlc.Queries, ns serial.Normalizers, sn string) (sqlc.SerialNumber, error) {
+	rows, err := q.GetEquipmentBySerialCanonicals(ctx, ns.Candidates(sn))
+	if err != nil {
+		return sqlc.SerialNumber{}, err
+	}
+	// NOTE: canonical serial numbers are unique, but under different normalizations two
+	// manufacturers' equipment can both match, the oldest wins
+	for _, v := range rows {
+		if ns.Matches(sn, v.ManufacturerID, v.SerialCanonical) {
+			return v, nil
+		}
+	}
+	return sqlc.SerialNumber{}, sql.ErrNoRows
+}
+
+// likeSerial returns LIKE patterns for serial numbers containing sn as typed and in its
+// canonical form under n
+func likeSerial(n serial.Normalizer, sn string) (string, string) {
+	c := n.Canonical(sn)
+	if c == "" {
+		// NOTE: "%%" would match every serial number
+		c = sn
+	}
+	return "%" + sn + "%", "%" + c + "%"
+}
+
+// likeSerials returns a LIKE pattern for serial numbers containing sn as typed and a regular
+// expression for canonical serial numbers containing sn in the canonical form of any
+// manufacturer's normalization
+func likeSerials(ns serial.Normalizers, sn string) (string, string) {
+	var alts []string
+	for _, c := range ns.Candidates(sn) {
+		if c != "" {
+			alts = append(alts, regexp.QuoteMeta(c))
+		}
+	}
+	if len(alts) == 0 {
+		// NOTE: an empty pattern would match every serial number
+		alts = append(alts, regexp.QuoteMeta(sn))
+	}
+	return "%" + sn + "%", strings.Join(alts, "|")
+}
+
+func serialNormalizationModel(manufacturerID int32, n serial.Normalizer, isDefault bool) models.SerialNormalization {
+	return models.SerialNormalization{
+		ManufacturerID: manufacturerID,
+		Trim:           n.Trim,
+		CaseFold:       n.CaseFold,
+		Separators:     n.Separators,
+		Default:        isDefault,
+	}
+}
+
+// GetSerialNormalization get serial number normalization of a manufacturer
+//
+//	@Summary		get serial number normalization of a manufacturer
+//	@Description	get how serial numbers of a manufacturer are made canonical for lookups and uniqueness checks
+//	@Tags			manufacturer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"manufacturer id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=models.SerialNormalization}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/manufacturer/{id}/serial-normalization [get]
+func (h *ManufactuerHandler) GetSerialNormalization(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id is not a number", "GET /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	}
+
+	_, err = q.GetManufacturerById(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id does not exist in database", "GET /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for manufacturer", "GET /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	}
+
+	v, err := q.GetSerialNormalization(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseSuccess(w, http.StatusOK, serialNormalizationModel(int32(i), serial.DefaultNormalizer, true))
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, serialNormalizationModel(int32(i), normalizerFromRow(v), false))
+}
+
+// UpdateSerialNormalization update serial number normalization of a manufacturer
+//
+//	@Summary		update serial number normalization of a manufacturer
+//	@Description	change how serial numbers of a manufacturer are made canonical, only the given fields change. The canonical serial number of every equipment of the manufacturer is recomputed and the change is rejected with the ids of the equipment when two serial numbers would become the same
+//	@Tags			manufacturer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"manufacturer id"	minimum(1)
+//	@Param			trim		query		bool	false	"remove leading and trailing whitespace"
+//	@Param			case_fold	query		bool	false	"upper case letters"
+//	@Param			separators	query		string	false	"characters to remove from anywhere in the serial number"
+//	@Success		200			{object}	models.JsonResponse{MSG=models.SerialNormalization}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		409			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/manufacturer/{id}/serial-normalization [patch]
+func (h *ManufactuerHandler) UpdateSerialNormalization(w http.ResponseWriter, r *http.Request) {
+	_, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "PATCH /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id is not a number", "PATCH /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	}
+	id := int32(i)
+
+	r.ParseForm()
+	var n serial.Normalizer
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		_, err := q.GetManufacturerById(r.Context(), id)
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "manufacturer id does not exist in database"}
+		} else if err != nil {
+			return err
+		}
+
+		n, err = normalizerFor(r.Context(), q, id)
+		if err != nil {
+			return err
+		}
+
+		flags := []struct {
+			name  string
+			value *bool
+		}{
+			{"trim", &n.Trim},
+			{"case_fold", &n.CaseFold},
+		}
+		for _, f := range flags {
+			if !r.Form.Has(f.name) {
+				continue
+			}
+			b, err := strconv.ParseBool(r.Form.Get(f.name))
+			if err != nil {
+				return statusError{http.StatusBadRequest, f.name + " must be true or false"}
+			}
+			*f.value = b
+		}
+		if r.Form.Has("separators") {
+			n.Separators = r.Form.Get("separators")
+		}
+		if err := n.Check(); err != nil {
+			return statusError{http.StatusBadRequest, err.Error()}
+		}
+
+		err = q.SetSerialNormalization(r.Context(), sqlc.SetSerialNormalizationParams{
+			ManufacturerID: id,
+			Trim:           n.Trim,
+			CaseFold:       n.CaseFold,
+			Separators:     n.Separators,
+		})
+		if err != nil {
+			return err
+		}
+		return recanonicalize(r.Context(), q, id, n)
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update serial normalization in database", "PATCH /api/v1/manufacturer/{id}/serial-normalization")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, serialNormalizationModel(id, n, false))
+}
+
+// recanonicalize stores the canonical serial number under n of every equipment of a
+// manufacturer, returning a statusError naming the equipment when two serial numbers would
+// become the same, q should be bound to a transaction
+func recanonicalize(ctx context.Context, q *sqlc.Queries, manufacturerID int32, n serial.Normalizer) error {
+	rows, err := q.GetSerialNumbersByManufacturer(ctx, manufacturerID)
+	if err != nil {
+		return err
+	}
+
+	var conflicts []string
+	owners := map[string][]int32{}
+	var order []string
+	old := map[string]int32{}
+	var changed []sqlc.UpdateSerialCanonicalParams
+	for _, v := range rows {
+		old[v.SerialCanonical] = v.AutoID
+		c := n.Canonical(v.SerialNumber)
+		if c == "" {
+			conflicts = append(conflicts, fmt.Sprintf("serial number of equipment %v would be empty once normalized", v.AutoID))
+			continue
+		}
+		if _, ok := owners[c]; !ok {
+			order = append(order, c)
+		}
+		owners[c] = append(owners[c], v.AutoID)
+		if c != v.SerialCanonical {
+			changed = append(changed, sqlc.UpdateSerialCanonicalParams{SerialCanonical: c, AutoID: v.AutoID})
+		}
+	}
+	for _, c := range order {
+		if ids := owners[c]; len(ids) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("serial numbers of equipment %v would all normalize to %q", ids, c))
+		}
+	}
+
+	if len(changed) > 0 {
+		canonicals := make([]string, 0, len(changed))
+		for _, v := range changed {
+			canonicals = append(canonicals, v.SerialCanonical)
+		}
+		taken, err := q.GetEquipmentBySerialCanonicals(ctx, canonicals)
+		if err != nil {
+			return err
+		}
+		for _, v := range taken {
+			if v.ManufacturerID != manufacturerID {
+				conflicts = append(conflicts, fmt.Sprintf("serial number of equipment %v would normalize to %q which equipment %v of manufacturer %v already has", owners[v.SerialCanonical][0], v.SerialCanonical, v.AutoID, v.ManufacturerID))
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return statusError{http.StatusConflict, strings.Join(conflicts, "; ")}
+	}
+
+	// NOTE: serial_canonical is unique, equipment whose canonical serial number another one
+	// takes over is moved out of the way first so the order of the updates doesn't matter
+	var moved []sqlc.UpdateSerialCanonicalParams
+	for _, v := range changed {
+		if id, ok := old[v.SerialCanonical]; ok && id != v.AutoID {
+			moved = append(moved, sqlc.UpdateSerialCanonicalParams{SerialCanonical: fmt.Sprintf("\x00%d", id), AutoID: id})
+		}
+	}
+	if len(moved) > 0 {
+		temporary := make([]string, 0, len(moved))
+		for _, v := range moved {
+			temporary = append(temporary, v.SerialCanonical)
+		}
+		taken, err := q.GetEquipmentBySerialCanonicals(ctx, temporary)
+		if err != nil {
+			return err
+		}
+		if len(taken) > 0 {
+			return fmt.Errorf("temporary canonical serial number %q is taken by equipment %v", taken[0].SerialCanonical, taken[0].AutoID)
+		}
+	}
+	for _, v := range append(moved, changed...) {
+		if err := q.UpdateSerialCanonical(ctx, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,444 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+type ProductModelHandler struct{}
+
+func productModelFromRow(v sqlc.ProductModel) models.ProductModel {
+	return models.ProductModel{
+		ID:             v.ID,
+		ManufacturerID: v.ManufacturerID,
+		DeviceTypeID:   v.DeviceTypeID,
+		ModelNumber:    v.ModelNumber,
+		Name:           v.Name,
+		Specs:          v.Specs,
+	}
+}
+
+// checkProductModel returns a statusError unless product model id exists and is made by
+// manufacturerID for deviceTypeID
+func checkProductModel(ctx context.Context, q *sqlc.Queries, id, deviceTypeID, manufacturerID int32) error {
+	m, err := q.GetProductModelByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return statusError{http.StatusBadRequest, "product model id does not exist in database"}
+	} else if err != nil {
+		return err
+	}
+	if m.ManufacturerID != manufacturerID {
+		return statusError{http.StatusBadRequest, fmt.Sprintf("product model %v is made by manufacturer %v, not %v", id, m.ManufacturerID, manufacturerID)}
+	}
+	if m.DeviceTypeID != deviceTypeID {
+		return statusError{http.StatusBadRequest, fmt.Sprintf("product model %v is a device %v, not %v", id, m.DeviceTypeID, deviceTypeID)}
+	}
+	return nil
+}
+
+// parseSpecs returns specs when it is a JSON object, or an empty object when specs is empty
+func parseSpecs(specs string) (json.RawMessage, error) {
+	if specs == "" {
+		return json.RawMessage("{}"), nil
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(specs), &obj); err != nil {
+		return nil, fmt.Errorf("specs must be a JSON object")
+	}
+	return json.RawMessage(specs), nil
+}
+
+// GetProductModels get all product models
+//
+//	@Summary		get all product models
+//	@Description	get all product models, optionally only those of a manufacturer or device type
+//	@Tags			product model
+//	@Accept			json
+//	@Produce		json
+//	@Param			manufacturer	query		int	false	"manufacturer id"	minimum(1)
+//	@Param			device			query		int	false	"device id"			minimum(1)
+//	@Success		200				{object}	models.JsonResponse{MSG=[]models.ProductModel}
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/product-model [get]
+func (h *ProductModelHandler) GetProductModels(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}")
+		return
+	}
+
+	var arg sqlc.GetProductModelsParams
+	if mid := r.FormValue("manufacturer"); mid != "" {
+		m, err := strconv.Atoi(mid)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id is not a number", "GET /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}")
+			return
+		}
+		arg.ManufacturerID = sql.NullInt32{Int32: int32(m), Valid: true}
+	}
+	if did := r.FormValue("device"); did != "" {
+		d, err := strconv.Atoi(did)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "GET /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}")
+			return
+		}
+		arg.DeviceTypeID = sql.NullInt32{Int32: int32(d), Valid: true}
+	}
+
+	d, err := q.GetProductModels(r.Context(), arg)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}")
+		return
+	}
+
+	out := []models.ProductModel{}
+	for _, v := range d {
+		out = append(out, productModelFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, out)
+}
+
+// GetProductModelByID get a product model by ID
+//
+//	@Summary		get a product model by ID
+//	@Description	get a product model by ID from the database
+//	@Tags			product model
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"product model id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=models.ProductModel}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/product-model/{id} [get]
+func (h *ProductModelHandler) GetProductModelByID(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/product-model/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "product model id is not a number", "GET /api/v1/product-model/{id}")
+		return
+	}
+
+	d, err := q.GetProductModelByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "product model id does not exist in database", "GET /api/v1/product-model/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "GET /api/v1/product-model/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, productModelFromRow(d))
+}
+
+// CreateProductModel create a product model
+//
+//	@Summary		create product model
+//	@Description	create a product model of an active manufacturer and device type
+//	@Tags			product model
+//	@Accept			json
+//	@Produce		json
+//	@Param			manufacturer	query		int		true	"manufacturer id"	minimum(1)
+//	@Param			device			query		int		true	"device id"			minimum(1)
+//	@Param			model_number	query		string	true	"model number, unique per manufacturer"	maxlength(100)
+//	@Param			name			query		string	false	"marketing name"	maxlength(100)
+//	@Param			specs			query		string	false	"specifications as a JSON object"
+//	@Success		200				{object}	models.JsonResponse{MSG=models.ProductModel}
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/product-model [post]
+func (h *ProductModelHandler) CreateProductModel(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+
+	m, err := strconv.Atoi(r.FormValue("manufacturer"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id is not a number", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+	d, err := strconv.Atoi(r.FormValue("device"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+
+	modelNumber := r.FormValue("model_number")
+	if modelNumber == "" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing model number", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+	name := r.FormValue("name")
+	if len(modelNumber) > 100 || len(name) > 100 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "model number and name cannot be longer than 100 characters", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+	specs, err := parseSpecs(r.FormValue("specs"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+
+	manufacturer, err := q.GetManufacturerById(r.Context(), int32(m))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id does not exist in database", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+	if manufacturer.Status == sqlc.ManufacturerStatusInactive {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "cannot create, manufacturer is inactive", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+
+	device, err := q.GetDeviceTypeById(r.Context(), int32(d))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id does not exist in database", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+	if device.Status == sqlc.DeviceTypeStatusInactive {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "cannot create, device is inactive", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+
+	_, err = q.GetProductModelByModelNumber(r.Context(), sqlc.GetProductModelByModelNumberParams{ManufacturerID: int32(m), ModelNumber: modelNumber})
+	if err == nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "product model already exists for manufacturer", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	} else if err != sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+
+	id, err := q.CreateProductModel(r.Context(), sqlc.CreateProductModelParams{
+		ManufacturerID: int32(m),
+		DeviceTypeID:   int32(d),
+		ModelNumber:    modelNumber,
+		Name:           name,
+		Specs:          specs,
+	})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create product model in database", "POST /api/v1/product-model?manufacturer={manufacturer_id}&device={device_id}&model_number={model_number}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, models.ProductModel{
+		ID:             int32(id),
+		ManufacturerID: int32(m),
+		DeviceTypeID:   int32(d),
+		ModelNumber:    modelNumber,
+		Name:           name,
+		Specs:          specs,
+	})
+}
+
+// UpdateProductModel update a product model
+//
+//	@Summary		update product model
+//	@Description	update the model number, name or specs of a product model, parameters left out keep their value. The manufacturer and device type cannot change since equipment is matched against them
+//	@Tags			product model
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int		true	"product model id"	minimum(1)
+//	@Param			model_number	query		string	false	"model number, unique per manufacturer"	maxlength(100)
+//	@Param			name			query		string	false	"marketing name"	maxlength(100)
+//	@Param			specs			query		string	false	"specifications as a JSON object"
+//	@Success		200				{object}	models.JsonResponse{MSG=models.ProductModel}
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/product-model/{id} [patch]
+func (h *ProductModelHandler) UpdateProductModel(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "PATCH /api/v1/product-model/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "product model id is not a number", "PATCH /api/v1/product-model/{id}")
+		return
+	}
+
+	d, err := q.GetProductModelByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "product model id does not exist in database", "PATCH /api/v1/product-model/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "PATCH /api/v1/product-model/{id}")
+		return
+	}
+
+	r.ParseForm()
+	if r.Form.Has("model_number") {
+		d.ModelNumber = r.FormValue("model_number")
+		if d.ModelNumber == "" {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "model number cannot be empty", "PATCH /api/v1/product-model/{id}")
+			return
+		}
+		other, err := q.GetProductModelByModelNumber(r.Context(), sqlc.GetProductModelByModelNumberParams{ManufacturerID: d.ManufacturerID, ModelNumber: d.ModelNumber})
+		if err == nil && other.ID != d.ID {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "product model already exists for manufacturer", "PATCH /api/v1/product-model/{id}")
+			return
+		} else if err != nil && err != sql.ErrNoRows {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "PATCH /api/v1/product-model/{id}")
+			return
+		}
+	}
+	if r.Form.Has("name") {
+		d.Name = r.FormValue("name")
+	}
+	if len(d.ModelNumber) > 100 || len(d.Name) > 100 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "model number and name cannot be longer than 100 characters", "PATCH /api/v1/product-model/{id}")
+		return
+	}
+	if r.Form.Has("specs") {
+		d.Specs, err = parseSpecs(r.FormValue("specs"))
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "PATCH /api/v1/product-model/{id}")
+			return
+		}
+	}
+
+	err = q.UpdateProductModel(r.Context(), sqlc.UpdateProductModelParams{ID: d.ID, ModelNumber: d.ModelNumber, Name: d.Name, Specs: d.Specs})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update product model in database", "PATCH /api/v1/product-model/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, productModelFromRow(d))
+}
+
+// DeleteProductModel delete a product model
+//
+//	@Summary		delete product model
+//	@Description	delete a product model no equipment references
+//	@Tags			product model
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"product model id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		409	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/product-model/{id} [delete]
+func (h *ProductModelHandler) DeleteProductModel(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "DELETE /api/v1/product-model/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "product model id is not a number", "DELETE /api/v1/product-model/{id}")
+		return
+	}
+
+	_, err = q.GetProductModelByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "product model id does not exist in database", "DELETE /api/v1/product-model/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "DELETE /api/v1/product-model/{id}")
+		return
+	}
+
+	n, err := q.CountEquipmentByProductModel(r.Context(), sql.NullInt32{Int32: int32(i), Valid: true})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "something went wrong with query "+err.Error(), "DELETE /api/v1/product-model/{id}")
+		return
+	}
+	if n > 0 {
+		helpers.JsonResponseError(w, http.StatusConflict, fmt.Sprintf("cannot delete, %v equipment reference the product model", n), "DELETE /api/v1/product-model/{id}")
+		return
+	}
+
+	err = q.DeleteProductModel(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to delete product model in database", "DELETE /api/v1/product-model/{id}")
+		return
+	}
+
+	msg := fmt.Sprintf("product model with id: %v deleted", i)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, msg)
+}
+
+// UpdateEquipmentProductModel set the product model of equipment
+//
+//	@Summary		set the product model of equipment
+//	@Description	set the product model of equipment, the model has to match the equipment's manufacturer and device type. Leave model out to clear it
+//	@Tags			product model
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int	true	"equipment id"		minimum(1)
+//	@Param			model	query		int	false	"product model id"	minimum(1)
+//	@Success		200		{object}	models.JsonResponse
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/equipment/{id}/product-model [patch]
+func (h *EquipmentHandler) UpdateEquipmentProductModel(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "PATCH /api/v1/equipment/{id}/product-model?model={model_id}")
+		return
+	}
+
+	var model sql.NullInt32
+	if mid := r.FormValue("model"); mid != "" {
+		m, err := strconv.Atoi(mid)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "product model id is not a number", "PATCH /api/v1/equipment/{id}/product-model?model={model_id}")
+			return
+		}
+		model = sql.NullInt32{Int32: int32(m), Valid: true}
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		e, err := q.GetEquipmentByAutoIDForUpdate(r.Context(), int32(i))
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "equipment id does not exist"}
+		} else if err != nil {
+			return err
+		}
+		if model.Valid {
+			if err := checkProductModel(r.Context(), q, model.Int32, e.DeviceTypeID, e.ManufacturerID); err != nil {
+				return err
+			}
+		}
+		return q.UpdateEquipmentProductModel(r.Context(), sqlc.UpdateEquipmentProductModelParams{AutoID: int32(i), ProductModelID: model})
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment/{id}/product-model?model={model_id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update equipment in database", "PATCH /api/v1/equipment/{id}/product-model?model={model_id}")
+		return
+	}
+
+	msg := fmt.Sprintf("equipment with id: %v updated product model", i)
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, msg)
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+const (
+	// dateLayout is how purchase and warranty dates are written
+	dateLayout = "2006-01-02"
+	// defaultWarrantyDays is how far ahead the warranty report looks when days is left out
+	defaultWarrantyDays = 30
+	// maxWarrantyDays is how far ahead the warranty report can look
+	maxWarrantyDays        = 3650
+	maxVendorLength        = 100
+	maxPurchaseOrderLength = 50
+)
+
+// purchaseColumns are the purchase and warranty fields, named like the json and csv columns
+var purchaseColumns = []string{"purchase_date", "vendor", "purchase_order", "cost", "warranty_start", "warranty_end"}
+
+// costPattern matches a cost that fits decimal(12,2)
+var costPattern = regexp.MustCompile(`^[0-9]{1,10}(\.[0-9]{1,2})?$`)
+
+// purchase is the purchase and warranty information of equipment
+type purchase struct {
+	PurchaseDate  sql.NullTime
+	Vendor        string
+	PurchaseOrder string
+	Cost          sql.NullString
+	WarrantyStart sql.NullTime
+	WarrantyEnd   sql.NullTime
+}
+
+func purchaseFromRow(v sqlc.SerialNumber) purchase {
+	return purchase{
+		PurchaseDate:  v.PurchaseDate,
+		Vendor:        v.Vendor,
+		PurchaseOrder: v.PurchaseOrder,
+		Cost:          v.Cost,
+		WarrantyStart: v.WarrantyStart,
+		WarrantyEnd:   v.WarrantyEnd,
+	}
+}
+
+// nullDate returns nil for a NULL date so it encodes as null
+func nullDate(v sql.NullTime) *string {
+	if !v.Valid {
+		return nil
+	}
+	s := v.Time.Format(dateLayout)
+	return &s
+}
+
+// nullCost returns nil for a NULL cost so it encodes as null
+func nullCost(v sql.NullString) *json.Number {
+	if !v.Valid {
+		return nil
+	}
+	n := json.Number(v.String)
+	return &n
+}
+
+func parseDate(name, v string) (sql.NullTime, error) {
+	if v == "" {
+		return sql.NullTime{}, nil
+	}
+	t, err := time.Parse(dateLayout, v)
+	if err != nil {
+		return sql.NullTime{}, statusError{http.StatusBadRequest, name + " is not a date like 2024-03-18"}
+	}
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
+
+// parseCost checks v fits decimal(12,2), an empty v is NULL
+func parseCost(v string) (sql.NullString, error) {
+	if v == "" {
+		return sql.NullString{}, nil
+	}
+	if !costPattern.MatchString(v) {
+		return sql.NullString{}, statusError{http.StatusBadRequest, "cost must be a positive amount with at most 2 decimals"}
+	}
+	return sql.NullString{String: v, Valid: true}, nil
+}
+
+// cents returns a decimal(12,2) amount read from the database in cents
+func cents(v string) int64 {
+	whole, frac, _ := strings.Cut(v, ".")
+	c, _ := strconv.ParseInt(whole, 10, 64)
+	frac = (frac + "00")[:2]
+	f, _ := strconv.ParseInt(frac, 10, 64)
+	return c*100 + f
+}
+
+// formatCents writes an amount in cents the way decimal(12,2) columns are read
+func formatCents(c int64) string {
+	return fmt.Sprintf("%d.%02d", c/100, c%100)
+}
+
+// set changes the fields get returns a value for, an empty value clears the field
+func (p *purchase) set(get func(name string) (string, bool)) error {
+	var err error
+	for _, name := range purchaseColumns {
+		v, ok := get(name)
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		switch name {
+		case "purchase_date":
+			p.PurchaseDate, err = parseDate(name, v)
+		case "warranty_start":
+			p.WarrantyStart, err = parseDate(name, v)
+		case "warranty_end":
+			p.WarrantyEnd, err = parseDate(name, v)
+		case "vendor":
+			if utf8.RuneCountInString(v) > maxVendorLength {
+				return statusError{http.StatusBadRequest, fmt.Sprintf("vendor cannot be longer than %d characters", maxVendorLength)}
+			}
+			p.Vendor = v
+		case "purchase_order":
+			if utf8.RuneCountInString(v) > maxPurchaseOrderLength {
+				return statusError{http.StatusBadRequest, fmt.Sprintf("purchase_order cannot be longer than %d characters", maxPurchaseOrderLength)}
+			}
+			p.PurchaseOrder = v
+		case "cost":
+			p.Cost, err = parseCost(v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if p.WarrantyStart.Valid && p.WarrantyEnd.Valid && p.WarrantyEnd.Time.Before(p.WarrantyStart.Time) {
+		return statusError{http.StatusBadRequest, "warranty_end cannot be before warranty_start"}
+	}
+	return nil
+}
+
+// today returns the current date at midnight UTC, the way DATE columns are read
+func today() time.Time {
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// UpdateEquipmentPurchase set the purchase and warranty information of equipment
+//
+//	@Summary		set the purchase and warranty information of equipment
+//	@Description	set the purchase and warranty information of equipment. Only the fields given are changed, give a field with an empty value to clear it. Dates are written like 2024-03-18
+//	@Tags			equipment
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int		true	"equipment id"	minimum(1)
+//	@Param			purchase_date	query		string	false	"date the equipment was bought"
+//	@Param			vendor			query		string	false	"who the equipment was bought from"	maxlength(100)
+//	@Param			purchase_order	query		string	false	"purchase order number"				maxlength(50)
+//	@Param			cost			query		string	false	"what the equipment cost, like 1299.99"
+//	@Param			warranty_start	query		string	false	"first day of the warranty"
+//	@Param			warranty_end	query		string	false	"last day of the warranty"
+//	@Param			expand			query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields			query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Success		200				{object}	models.JsonResponse{MSG=models.Equipment}
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/equipment/{id}/purchase [patch]
+func (h *EquipmentHandler) UpdateEquipmentPurchase(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "PATCH /api/v1/equipment/{id}/purchase")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "PATCH /api/v1/equipment/{id}/purchase")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "invalid form", "PATCH /api/v1/equipment/{id}/purchase")
+		return
+	}
+	// NOTE: checked before anything is written so a bad expand or fields doesn't fail
+	// the request after the update
+	_, err = parseEquipmentView(r)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment/{id}/purchase")
+		return
+	}
+
+	var e sqlc.SerialNumber
+	var b bookValuer
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		e, err = q.GetEquipmentByAutoIDForUpdate(r.Context(), int32(i))
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "equipment id does not exist"}
+		} else if err != nil {
+			return err
+		}
+		p := purchaseFromRow(e)
+		err = p.set(func(name string) (string, bool) {
+			return r.Form.Get(name), r.Form.Has(name)
+		})
+		if err != nil {
+			return err
+		}
+		err = q.UpdateEquipmentPurchase(r.Context(), sqlc.UpdateEquipmentPurchaseParams{
+			AutoID:        int32(i),
+			PurchaseDate:  p.PurchaseDate,
+			Vendor:        p.Vendor,
+			PurchaseOrder: p.PurchaseOrder,
+			Cost:          p.Cost,
+			WarrantyStart: p.WarrantyStart,
+			WarrantyEnd:   p.WarrantyEnd,
+		})
+		if err != nil {
+			return err
+		}
+		e.PurchaseDate, e.Vendor, e.PurchaseOrder = p.PurchaseDate, p.Vendor, p.PurchaseOrder
+		e.Cost, e.WarrantyStart, e.WarrantyEnd = p.Cost, p.WarrantyStart, p.WarrantyEnd
+		b, err = newBookValuer(r.Context(), q, today())
+		return err
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/equipment/{id}/purchase")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update equipment in database", "PATCH /api/v1/equipment/{id}/purchase")
+		return
+	}
+
+	respondEquipmentOne(w, r, q, b.equipment(e), "PATCH /api/v1/equipment/{id}/purchase")
+}
+
+// GetWarrantyExpiring get equipment whose warranty expires soon
+//
+//	@Summary		get equipment whose warranty expires soon
+//	@Description	get equipment whose warranty ends between today and days from now, grouped by manufacturer with the soonest expiring equipment first
+//	@Tags			warranty
+//	@Accept			json
+//	@Produce		json
+//	@Param			days	query		int		false	"number of days ahead to look"	minimum(0)	maximum(3650)	default(30)
+//	@Param			all		query		bool	false	"set to true to include inactive equipment"
+//	@Success		200		{object}	models.JsonResponse{MSG=[]models.WarrantyExpiryGroup}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/warranty/expiring [get]
+func (h *EquipmentHandler) GetWarrantyExpiring(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/warranty/expiring?days={days}")
+		return
+	}
+
+	days := defaultWarrantyDays
+	if v := r.FormValue("days"); v != "" {
+		days, err = strconv.Atoi(v)
+		if err != nil || days < 0 || days > maxWarrantyDays {
+			helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("days must be a number from 0 to %d", maxWarrantyDays), "GET /api/v1/warranty/expiring?days={days}")
+			return
+		}
+	}
+	all := r.FormValue("all") == "true"
+
+	from := today()
+	rows, err := q.GetEquipmentWarrantyExpiring(r.Context(), sqlc.GetEquipmentWarrantyExpiringParams{
+		From: sql.NullTime{Time: from, Valid: true},
+		To:   sql.NullTime{Time: from.AddDate(0, 0, days), Valid: true},
+	})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for warranties", "GET /api/v1/warranty/expiring?days={days}")
+		return
+	}
+
+	b, err := newBookValuer(r.Context(), q, from)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for depreciation schedules", "GET /api/v1/warranty/expiring?days={days}")
+		return
+	}
+
+	groups := []models.WarrantyExpiryGroup{}
+	for _, v := range rows {
+		e := v.SerialNumber
+		if !all && e.Status != sqlc.SerialNumbersStatusActive {
+			continue
+		}
+		// NOTE: rows are ordered by manufacturer so each group is contiguous
+		if n := len(groups); n == 0 || groups[n-1].ManufacturerID != e.ManufacturerID {
+			groups = append(groups, models.WarrantyExpiryGroup{
+				ManufacturerID: e.ManufacturerID,
+				Manufacturer:   v.ManufacturerName,
+				Equipment:      []models.WarrantyExpiry{},
+			})
+		}
+		g := &groups[len(groups)-1]
+		g.Equipment = append(g.Equipment, models.WarrantyExpiry{
+			Equipment: b.equipment(e),
+			DaysLeft:  int(e.WarrantyEnd.Time.Sub(from).Hours() / 24),
+		})
+		g.Count++
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, groups)
+}
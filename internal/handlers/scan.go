@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+// maxScanLength is the longest code a scan can send
+const maxScanLength = 2048
+
+// symbologyIdentifier matches the AIM prefix some scanners put before the code, like
+// ]C0 for Code128 or ]Q1 for QR
+var symbologyIdentifier = regexp.MustCompile(`^\][A-Za-z][0-9A-Za-z]`)
+
+// nullTime returns nil for a NULL timestamp so it encodes as null
+func nullTime(v sql.NullTime) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Time
+}
+
+// cleanScan removes what scanners add around a code, the symbology prefix, the
+// terminating CR or tab and group separators
+func cleanScan(code string) string {
+	code = strings.TrimSpace(code)
+	code = symbologyIdentifier.ReplaceAllString(code, "")
+	code = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, code)
+	return strings.TrimSpace(code)
+}
+
+// resolveScan returns the equipment code was printed for and how it was found. Label
+// urls are tried first, then serial numbers and last auto_ids, so a serial number made
+// of digits wins over an auto_id
+func resolveScan(ctx context.Context, q *sqlc.Queries, code string) (sqlc.SerialNumber, string, error) {
+	if strings.Contains(code, "://") {
+		u, err := url.Parse(code)
+		if err != nil || !strings.HasSuffix(u.Path, "/api/v1/equipment/id") {
+			return sqlc.SerialNumber{}, "", statusError{http.StatusBadRequest, "scanned url is not an equipment label url"}
+		}
+		id, err := strconv.Atoi(u.Query().Get("id"))
+		if err != nil {
+			return sqlc.SerialNumber{}, "", statusError{http.StatusBadRequest, "scanned url is not an equipment label url"}
+		}
+		e, err := q.GetEquipmentByAutoID(ctx, int32(id))
+		if err == sql.ErrNoRows {
+			return e, "", statusError{http.StatusBadRequest, "equipment id does not exist in database"}
+		}
+		return e, "url", err
+	}
+
+	ns, err := serialNormalizers(ctx, q)
+	if err != nil {
+		return sqlc.SerialNumber{}, "", err
+	}
+	e, err := findBySerial(ctx, q, ns, code)
+	if err == nil {
+		return e, "serial_number", nil
+	} else if err != sql.ErrNoRows {
+		return e, "", err
+	}
+
+	if id, err := strconv.ParseInt(code, 10, 32); err == nil && id > 0 {
+		e, err := q.GetEquipmentByAutoID(ctx, int32(id))
+		if err == nil {
+			return e, "auto_id", nil
+		} else if err != sql.ErrNoRows {
+			return e, "", err
+		}
+	}
+	return sqlc.SerialNumber{}, "", statusError{http.StatusBadRequest, "no equipment matches the scanned code"}
+}
+
+// Scan resolve a scanned code to equipment
+//
+//	@Summary		resolve a scanned code to equipment
+//	@Description	resolve the raw string a barcode reader sends, a serial number, an auto_id or the url of a QR label, to the equipment with its device type and manufacturer, and record when it was last seen. An action can be performed in the same call: checkin closes the open assignment, seen marks the equipment seen at location and moves it there, status moves it to the lifecycle state in state
+//	@Tags			scan
+//	@Accept			json
+//	@Produce		json
+//	@Param			code		query		string	true	"the scanned code"	maxlength(2048)
+//	@Param			action		query		string	false	"action to perform on the equipment"	Enums(checkin, seen, status)
+//	@Param			location	query		int		false	"location id the equipment is seen at, required by seen"	minimum(1)
+//	@Param			state		query		string	false	"lifecycle state to move to, required by status"	Enums(received, in_stock, deployed, in_repair, lost, retired, disposed)
+//	@Param			note		query		string	false	"check in note or reason recorded for the move"	maxlength(255)
+//	@Success		200			{object}	models.JsonResponse{MSG=models.ScanResult}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		409			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/scan [post]
+func (h *EquipmentHandler) Scan(w http.ResponseWriter, r *http.Request) {
+	code := cleanScan(r.FormValue("code"))
+	if code == "" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing code", "POST /api/v1/scan?code={code}&action={action}")
+		return
+	}
+	if len(code) > maxScanLength {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "code cannot be longer than 2048 characters", "POST /api/v1/scan?code={code}&action={action}")
+		return
+	}
+
+	note := r.FormValue("note")
+	if len(note) > maxReasonLength {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "note cannot be longer than 255 characters", "POST /api/v1/scan?code={code}&action={action}")
+		return
+	}
+	reason := note
+	if reason == "" {
+		reason = "scanned"
+	}
+
+	action := r.FormValue("action")
+	var location sql.NullInt32
+	var state lifecycle.State
+	switch action {
+	case "", "checkin":
+	case "seen":
+		l, err := strconv.Atoi(r.FormValue("location"))
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "location id is not a number", "POST /api/v1/scan?code={code}&action={action}")
+			return
+		}
+		location = sql.NullInt32{Int32: int32(l), Valid: true}
+	case "status":
+		st, err := lifecycle.Parse(r.FormValue("state"))
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "POST /api/v1/scan?code={code}&action={action}")
+			return
+		}
+		state = st
+	default:
+		helpers.JsonResponseError(w, http.StatusBadRequest, "action must be one of checkin, seen or status", "POST /api/v1/scan?code={code}&action={action}")
+		return
+	}
+
+	res := models.ScanResult{Code: code, Action: action}
+	var e sqlc.SerialNumber
+	var d sqlc.DeviceType
+	var m sqlc.Manufacturer
+	var b bookValuer
+	err := database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		found, matchedBy, err := resolveScan(r.Context(), q, code)
+		if err != nil {
+			return err
+		}
+		res.MatchedBy = matchedBy
+		id := found.AutoID
+
+		switch action {
+		case "checkin":
+			err = h.checkIn(r.Context(), q, id, note)
+		case "seen":
+			err = moveEquipment(r.Context(), q, id, location.Int32, reason)
+		case "status":
+			_, err = h.transition(r.Context(), q, id, state, reason)
+			if errors.Is(err, lifecycle.ErrIllegalTransition) {
+				err = statusError{http.StatusConflict, "cannot change status, " + err.Error()}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		err = q.UpdateEquipmentLastSeen(r.Context(), sqlc.UpdateEquipmentLastSeenParams{AutoID: id, LocationID: location})
+		if err != nil {
+			return err
+		}
+
+		if e, err = q.GetEquipmentByAutoID(r.Context(), id); err != nil {
+			return err
+		}
+		if d, err = q.GetDeviceTypeById(r.Context(), e.DeviceTypeID); err != nil {
+			return err
+		}
+		if m, err = q.GetManufacturerById(r.Context(), e.ManufacturerID); err != nil {
+			return err
+		}
+		b, err = newBookValuer(r.Context(), q, today())
+		return err
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/scan?code={code}&action={action}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to resolve scanned code", "POST /api/v1/scan?code={code}&action={action}")
+		return
+	}
+
+	res.Equipment = b.equipment(e)
+	res.DeviceType = models.DeviceType{ID: d.ID, Name: d.Name, Status: string(d.Status)}
+	res.Manufacturer = models.Manufacturer{ID: m.ID, Name: m.Name, Status: string(m.Status)}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, res)
+}
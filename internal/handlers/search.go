@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
+	"github.com/coltonmosier/api-v1/internal/logging"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+// attributeFilterPrefix marks query parameters that filter on attribute values,
+// like attr.imei=356938035643809
+const attributeFilterPrefix = "attr."
+
+const (
+	// maxSearchResults is the most equipment a search returns
+	maxSearchResults = 1000
+	// exportPageSize is how many equipment an export reads from the database at once
+	exportPageSize = 1000
+)
+
+// equipmentSearch is a parsed set of equipment filters
+type equipmentSearch struct {
+	params sqlc.SearchEquipmentParams
+	// attrs are attribute values equipment has to have, by attribute name, as they were
+	// given. searchEquipment normalizes them into params
+	attrs map[string]string
+	// sn is part of the serial number equipment has to have, as it was given.
+	// searchEquipment turns it into patterns in params
+	sn string
+	// done is set once searchEquipment has returned the last page
+	done bool
+}
+
+// parseEquipmentSearch reads the filters shared by search and export from the query string
+func parseEquipmentSearch(r *http.Request) (equipmentSearch, error) {
+	s := equipmentSearch{attrs: map[string]string{}}
+	p := &s.params
+
+	ids := []struct {
+		name  string
+		value *sql.NullInt32
+	}{
+		{"device", &p.DeviceTypeID},
+		{"manufacturer", &p.ManufacturerID},
+		{"location", &p.LocationID},
+		{"model", &p.ProductModelID},
+	}
+	for _, v := range ids {
+		raw := r.FormValue(v.name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return s, fmt.Errorf("%s id is not a number", v.name)
+		}
+		*v.value = sql.NullInt32{Int32: int32(n), Valid: true}
+	}
+
+	if status := r.FormValue("status"); status != "" {
+		if status != "active" && status != "inactive" {
+			return s, fmt.Errorf("status must be either active or inactive")
+		}
+		p.Status = sqlc.NullSerialNumbersStatus{SerialNumbersStatus: sqlc.SerialNumbersStatus(status), Valid: true}
+	}
+	if state := r.FormValue("state"); state != "" {
+		st, err := lifecycle.Parse(state)
+		if err != nil {
+			return s, err
+		}
+		p.LifecycleState = sqlc.NullSerialNumbersLifecycleState{SerialNumbersLifecycleState: sqlc.SerialNumbersLifecycleState(st), Valid: true}
+	}
+	s.sn = r.FormValue("sn")
+
+	r.ParseForm()
+	tags, err := parseTagNames(r.Form["tag"])
+	if err != nil {
+		return s, err
+	}
+	if len(tags) > 0 {
+		p.TagNames = tags
+		p.TagCount = sql.NullInt32{Int32: int32(len(tags)), Valid: true}
+	}
+
+	for key := range r.Form {
+		if name, ok := strings.CutPrefix(key, attributeFilterPrefix); ok {
+			s.attrs[name] = r.Form.Get(key)
+		}
+	}
+	return s, nil
+}
+
+// filterAttributes turns the attribute filters of s into the pairs of attribute id and
+// value SearchEquipment matches. A filter value is normalized by every definition with
+// its name, definitions it isn't valid for are left out so they can't match
+func filterAttributes(ctx context.Context, q *sqlc.Queries, s *equipmentSearch) error {
+	names := make([]string, 0, len(s.attrs))
+	for name := range s.attrs {
+		names = append(names, name)
+	}
+	defs, err := q.GetDeviceTypeAttributesByName(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	p := &s.params
+	pairs := []string{}
+	for _, v := range defs {
+		value, err := definitionFromRow(v).Normalize(s.attrs[v.Name])
+		if err != nil {
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("%d=%s", v.ID, value))
+		p.AttributeIds = append(p.AttributeIds, v.ID)
+		if !slices.Contains(p.AttributeValues, value) {
+			p.AttributeValues = append(p.AttributeValues, value)
+		}
+	}
+	b, err := json.Marshal(pairs)
+	if err != nil {
+		return err
+	}
+	p.AttributePairs = sql.NullString{String: string(b), Valid: true}
+	p.AttributeCount = int64(len(names))
+	return nil
+}
+
+// searchEquipment returns the next page of at most limit equipment matching s with their
+// attribute values, s.done is set when there are no more pages
+func searchEquipment(ctx context.Context, q *sqlc.Queries, s *equipmentSearch, limit int32) ([]models.Equipment, error) {
+	if len(s.attrs) > 0 && !s.params.AttributePairs.Valid {
+		if err := filterAttributes(ctx, q, s); err != nil {
+			return nil, err
+		}
+	}
+	if s.sn != "" && !s.params.SerialNumber.Valid {
+		ns, err := serialNormalizers(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		like, pattern := likeSerials(ns, s.sn)
+		s.params.SerialNumber = sql.NullString{String: like, Valid: true}
+		s.params.CanonicalPattern = sql.NullString{String: pattern, Valid: true}
+	}
+	s.params.Limit = limit
+	d, err := q.SearchEquipment(ctx, s.params)
+	if err != nil {
+		return nil, err
+	}
+	if len(d) > 0 {
+		s.params.AfterID = d[len(d)-1].AutoID
+	}
+	s.done = len(d) < int(limit)
+
+	ids := make([]int32, 0, len(d))
+	for _, v := range d {
+		ids = append(ids, v.AutoID)
+	}
+	values, err := equipmentAttributes(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+	b, err := newBookValuer(ctx, q, today())
+	if err != nil {
+		return nil, err
+	}
+
+	e := []models.Equipment{}
+	for _, v := range d {
+		out := b.equipment(v)
+		out.Attributes = values[v.AutoID]
+		if out.Attributes == nil {
+			out.Attributes = map[string]string{}
+		}
+		e = append(e, out)
+	}
+	return e, nil
+}
+
+// SearchEquipment search equipment
+//
+//	@Summary		search equipment
+//	@Description	search equipment by any combination of filters, attribute values are filtered with attr.{name}={value}, normalized by the attribute definition so attr.weight=1.0 matches a float attribute stored as 1. Results are ordered by id and paged with limit and after_id, X-Next-After-Id holds the after_id of the next page while there may be more
+//	@Tags			equipment
+//	@Accept			json
+//	@Produce		json
+//	@Param			device			query		int		false	"device id"	minimum(1)
+//	@Param			manufacturer	query		int		false	"manufacturer id"	minimum(1)
+//	@Param			location		query		int		false	"location id, only equipment directly at it"	minimum(1)
+//	@Param			model			query		int		false	"product model id"	minimum(1)
+//	@Param			status			query		string	false	"equipment status"	Enums(active, inactive)
+//	@Param			state			query		string	false	"lifecycle state"	Enums(received, in_stock, deployed, in_repair, lost, retired, disposed)
+//	@Param			sn				query		string	false	"part of the serial number, as typed or in canonical form"
+//	@Param			attr.{name}		query		string	false	"attribute value, for example attr.imei=356938035643809"
+//	@Param			tag				query		[]string	false	"tags equipment has to carry, repeated or comma separated"	collectionFormat(multi)
+//	@Param			expand			query		[]string	false	"related resources to embed, repeated or comma separated"	collectionFormat(multi)	Enums(device_type, manufacturer)
+//	@Param			fields			query		[]string	false	"equipment fields to keep, repeated or comma separated, every field when left out"	collectionFormat(multi)
+//	@Param			limit			query		int		false	"equipment per page"	minimum(1)	maximum(1000)	default(1000)
+//	@Param			after_id		query		int		false	"only equipment with a greater id, the X-Next-After-Id of the previous page"	minimum(0)
+//	@Success		200				{object}	models.JsonResponse{MSG=[]models.Equipment}
+//	@Header			200				{integer}	X-Next-After-Id	"after_id of the next page, left out on the last page"
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/equipment/search [get]
+func (h *EquipmentHandler) SearchEquipment(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/equipment/search")
+		return
+	}
+
+	s, err := parseEquipmentSearch(r)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "GET /api/v1/equipment/search")
+		return
+	}
+
+	limit := int32(maxSearchResults)
+	if raw := r.FormValue("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxSearchResults {
+			helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("limit must be a number from 1 to %d", maxSearchResults), "GET /api/v1/equipment/search")
+			return
+		}
+		limit = int32(n)
+	}
+	if raw := r.FormValue("after_id"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 || n > math.MaxInt32 {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "after_id must be a number of at least 0", "GET /api/v1/equipment/search")
+			return
+		}
+		s.params.AfterID = int32(n)
+	}
+
+	e, err := searchEquipment(r.Context(), q, &s, limit)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/equipment/search")
+		return
+	}
+
+	// NOTE: a full page only may be followed by another one, the client asks for it with
+	// after_id set to the last id of this one
+	if !s.done {
+		w.Header().Set("X-Next-After-Id", strconv.FormatInt(int64(s.params.AfterID), 10))
+	}
+	respondEquipment(w, r, q, e, "GET /api/v1/equipment/search")
+}
+
+// ExportEquipment export equipment as csv
+//
+//	@Summary		export equipment as csv
+//	@Description	export every equipment matching the search filters as csv, with the purchase, warranty and book value columns and a column per attribute of the device types it can be
+//	@Tags			equipment
+//	@Accept			json
+//	@Produce		text/csv
+//	@Param			device			query		int		false	"device id"	minimum(1)
+//	@Param			manufacturer	query		int		false	"manufacturer id"	minimum(1)
+//	@Param			location		query		int		false	"location id, only equipment directly at it"	minimum(1)
+//	@Param			model			query		int		false	"product model id"	minimum(1)
+//	@Param			status			query		string	false	"equipment status"	Enums(active, inactive)
+//	@Param			state			query		string	false	"lifecycle state"	Enums(received, in_stock, deployed, in_repair, lost, retired, disposed)
+//	@Param			sn				query		string	false	"part of the serial number, as typed or in canonical form"
+//	@Param			attr.{name}		query		string	false	"attribute value, for example attr.imei=356938035643809"
+//	@Param			tag				query		[]string	false	"tags equipment has to carry, repeated or comma separated"	collectionFormat(multi)
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/equipment/export [get]
+func (h *EquipmentHandler) ExportEquipment(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/equipment/export")
+		return
+	}
+
+	s, err := parseEquipmentSearch(r)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "GET /api/v1/equipment/export")
+		return
+	}
+
+	attrs, err := q.GetAttributeNames(r.Context(), sqlc.GetAttributeNamesParams{DeviceTypeID: s.params.DeviceTypeID})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for attributes", "GET /api/v1/equipment/export")
+		return
+	}
+	e, err := searchEquipment(r.Context(), q, &s, exportPageSize)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/equipment/export")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="equipment.csv"`)
+	cw := csv.NewWriter(w)
+	header := []string{"auto_id", "serial_number", "device_type_id", "manufacturer_id", "product_model_id", "location_id", "status", "lifecycle_state"}
+	header = append(header, purchaseColumns...)
+	header = append(header, "book_value")
+	for _, name := range attrs {
+		header = append(header, attributeFilterPrefix+name)
+	}
+	cw.Write(header)
+	for {
+		for _, v := range e {
+			row := []string{
+				strconv.Itoa(int(v.AutoID)),
+				v.SerialNumber,
+				strconv.Itoa(int(v.DeviceTypeID)),
+				strconv.Itoa(int(v.ManufacturerID)),
+				optionalID(v.ProductModelID),
+				optionalID(v.LocationID),
+				v.Status,
+				v.LifecycleState,
+				optionalString(v.PurchaseDate),
+				v.Vendor,
+				v.PurchaseOrder,
+				optionalString((*string)(v.Cost)),
+				optionalString(v.WarrantyStart),
+				optionalString(v.WarrantyEnd),
+				optionalString((*string)(v.BookValue)),
+			}
+			for _, name := range attrs {
+				row = append(row, v.Attributes[name])
+			}
+			cw.Write(row)
+		}
+		cw.Flush()
+		if s.done {
+			return
+		}
+		// NOTE: the header is already sent, a failed page can only cut the export short
+		if e, err = searchEquipment(r.Context(), q, &s, exportPageSize); err != nil {
+			logging.FromContext(r.Context()).Error("failed to export equipment", slog.String("error", err.Error()))
+			return
+		}
+	}
+}
+
+// optionalID formats a nullable id for csv, empty when null
+func optionalID(id *int32) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*id))
+}
+
+// optionalString formats a nullable value for csv, empty when null
+func optionalString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
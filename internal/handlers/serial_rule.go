@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/serial"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+type SerialRuleHandler struct{}
+
+func serialRuleFromRow(v sqlc.SerialNumberRule) serial.Rule {
+	return serial.Rule{
+		ID:             v.ID,
+		Name:           v.Name,
+		ManufacturerID: nullInt32(v.ManufacturerID),
+		DeviceTypeID:   nullInt32(v.DeviceTypeID),
+		Pattern:        v.Pattern,
+		MinLength:      int(v.MinLength),
+		MaxLength:      int(v.MaxLength),
+		Prefix:         v.Prefix,
+		Checksum:       serial.Checksum(v.Checksum),
+	}
+}
+
+func serialNumberRuleFromRow(v sqlc.SerialNumberRule) models.SerialNumberRule {
+	return models.SerialNumberRule{
+		ID:             v.ID,
+		Name:           v.Name,
+		ManufacturerID: nullInt32(v.ManufacturerID),
+		DeviceTypeID:   nullInt32(v.DeviceTypeID),
+		Pattern:        v.Pattern,
+		MinLength:      v.MinLength,
+		MaxLength:      v.MaxLength,
+		Prefix:         v.Prefix,
+		Checksum:       string(v.Checksum),
+	}
+}
+
+// validateSerialNumber returns the rules sn fails for equipment of the manufacturer and device type
+func validateSerialNumber(ctx context.Context, q *sqlc.Queries, sn string, manufacturerID, deviceTypeID int32) ([]serial.Failure, error) {
+	rows, err := q.GetSerialNumberRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]serial.Rule, 0, len(rows))
+	for _, v := range rows {
+		rules = append(rules, serialRuleFromRow(v))
+	}
+	return serial.Validate(rules, sn, manufacturerID, deviceTypeID), nil
+}
+
+// checkSerialNumber returns a statusError naming every rule sn fails, or nil when it follows them all
+func checkSerialNumber(ctx context.Context, q *sqlc.Queries, sn string, manufacturerID, deviceTypeID int32) error {
+	failures, err := validateSerialNumber(ctx, q, sn, manufacturerID, deviceTypeID)
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	reasons := make([]string, 0, len(failures))
+	for _, f := range failures {
+		reasons = append(reasons, fmt.Sprintf("rule %s: %s", f.Rule, f.Reason))
+	}
+	return statusError{http.StatusBadRequest, strings.Join(reasons, "; ")}
+}
+
+// GetSerialNumberRules get all serial number rules
+//
+//	@Summary		get all serial number rules
+//	@Description	get every serial number rule, a rule without a manufacturer or device applies to all of them. A serial number is only checked against the most specific rules in scope, rules for its manufacturer and device override rules for its manufacturer, which override rules for its device, which override rules for everything
+//	@Tags			serial rule
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.SerialNumberRule}
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/serial-rule [get]
+func (h *SerialRuleHandler) GetSerialNumberRules(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/serial-rule")
+		return
+	}
+
+	d, err := q.GetSerialNumberRules(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial number rules", "GET /api/v1/serial-rule")
+		return
+	}
+
+	rules := []models.SerialNumberRule{}
+	for _, v := range d {
+		rules = append(rules, serialNumberRuleFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, rules)
+}
+
+// CreateSerialNumberRule create serial number rule
+//
+//	@Summary		create serial number rule
+//	@Description	create a serial number rule, equipment created or updated afterwards has to follow it unless a more specific rule is in scope, existing serial numbers are not checked
+//	@Tags			serial rule
+//	@Accept			json
+//	@Produce		json
+//	@Param			name			query		string	true	"rule name"
+//	@Param			manufacturer	query		int		false	"only apply to this manufacturer"	minimum(1)
+//	@Param			device			query		int		false	"only apply to this device"			minimum(1)
+//	@Param			pattern			query		string	false	"regular expression the whole serial number has to match"
+//	@Param			min_length		query		int		false	"shortest serial number allowed"	minimum(0)
+//	@Param			max_length		query		int		false	"longest serial number allowed"		minimum(0)	maximum(68)
+//	@Param			prefix			query		string	false	"prefix serial numbers have to start with"
+//	@Param			checksum		query		string	false	"check digit scheme, computed over the serial number after the prefix"	Enums(none, luhn)
+//	@Success		200				{object}	models.JsonResponse{MSG=models.SerialNumberRule}
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/serial-rule [post]
+func (h *SerialRuleHandler) CreateSerialNumberRule(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/serial-rule?name={name}")
+		return
+	}
+
+	rule := serial.Rule{
+		Name:     r.FormValue("name"),
+		Pattern:  r.FormValue("pattern"),
+		Prefix:   r.FormValue("prefix"),
+		Checksum: serial.Checksum(r.FormValue("checksum")),
+	}
+	if rule.Checksum == "" {
+		rule.Checksum = serial.None
+	}
+	if len(rule.Name) > 100 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "name cannot be longer than 100 characters", "POST /api/v1/serial-rule?name={name}")
+		return
+	}
+	if len(rule.Pattern) > 255 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "pattern cannot be longer than 255 characters", "POST /api/v1/serial-rule?name={name}")
+		return
+	}
+
+	lengths := []struct {
+		name  string
+		value *int
+	}{
+		{"min_length", &rule.MinLength},
+		{"max_length", &rule.MaxLength},
+	}
+	for _, v := range lengths {
+		raw := r.FormValue(v.name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, v.name+" is not a number", "POST /api/v1/serial-rule?name={name}")
+			return
+		}
+		*v.value = n
+	}
+
+	var mid, did sql.NullInt32
+	if raw := r.FormValue("manufacturer"); raw != "" {
+		m, err := strconv.Atoi(raw)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id is not a number", "POST /api/v1/serial-rule?name={name}")
+			return
+		}
+		_, err = q.GetManufacturerById(r.Context(), int32(m))
+		if err == sql.ErrNoRows {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id does not exist in database", "POST /api/v1/serial-rule?name={name}")
+			return
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for manufacturer", "POST /api/v1/serial-rule?name={name}")
+			return
+		}
+		mid = sql.NullInt32{Int32: int32(m), Valid: true}
+	}
+	if raw := r.FormValue("device"); raw != "" {
+		d, err := strconv.Atoi(raw)
+		if err != nil {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "POST /api/v1/serial-rule?name={name}")
+			return
+		}
+		_, err = q.GetDeviceTypeById(r.Context(), int32(d))
+		if err == sql.ErrNoRows {
+			helpers.JsonResponseError(w, http.StatusBadRequest, "device id does not exist in database", "POST /api/v1/serial-rule?name={name}")
+			return
+		} else if err != nil {
+			helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for device", "POST /api/v1/serial-rule?name={name}")
+			return
+		}
+		did = sql.NullInt32{Int32: int32(d), Valid: true}
+	}
+	rule.ManufacturerID = nullInt32(mid)
+	rule.DeviceTypeID = nullInt32(did)
+
+	if err := rule.Check(); err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, err.Error(), "POST /api/v1/serial-rule?name={name}")
+		return
+	}
+
+	arg := sqlc.CreateSerialNumberRuleParams{
+		Name:           rule.Name,
+		ManufacturerID: mid,
+		DeviceTypeID:   did,
+		Pattern:        rule.Pattern,
+		MinLength:      int32(rule.MinLength),
+		MaxLength:      int32(rule.MaxLength),
+		Prefix:         rule.Prefix,
+		Checksum:       sqlc.SerialNumberRulesChecksum(rule.Checksum),
+	}
+	id, err := q.CreateSerialNumberRule(r.Context(), arg)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create serial number rule in database", "POST /api/v1/serial-rule?name={name}")
+		return
+	}
+
+	d, err := q.GetSerialNumberRuleByID(r.Context(), int32(id))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial number rule", "POST /api/v1/serial-rule?name={name}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, serialNumberRuleFromRow(d))
+}
+
+// DeleteSerialNumberRule delete serial number rule
+//
+//	@Summary		delete serial number rule
+//	@Description	delete a serial number rule, serial numbers no longer have to follow it
+//	@Tags			serial rule
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"rule id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/serial-rule/{id} [delete]
+func (h *SerialRuleHandler) DeleteSerialNumberRule(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "DELETE /api/v1/serial-rule/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "rule id is not a number", "DELETE /api/v1/serial-rule/{id}")
+		return
+	}
+
+	_, err = q.GetSerialNumberRuleByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "serial number rule does not exist in database", "DELETE /api/v1/serial-rule/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial number rule", "DELETE /api/v1/serial-rule/{id}")
+		return
+	}
+
+	err = q.DeleteSerialNumberRule(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to delete serial number rule from database", "DELETE /api/v1/serial-rule/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, fmt.Sprintf("serial number rule with id: %v deleted", i))
+}
+
+// ValidateSerialNumber validate serial number
+//
+//	@Summary		validate serial number
+//	@Description	check a serial number against the most specific rules for a manufacturer and device without creating anything, reports every rule it fails and the canonical form it would be stored with
+//	@Tags			serial rule
+//	@Accept			json
+//	@Produce		json
+//	@Param			sn				query		string	true	"serial number"
+//	@Param			manufacturer	query		int		true	"manufacturer id"	minimum(1)
+//	@Param			device			query		int		true	"device id"			minimum(1)
+//	@Success		200				{object}	models.JsonResponse{MSG=models.SerialNumberValidation}
+//	@Failure		400				{object}	models.JsonResponse
+//	@Failure		500				{object}	models.JsonResponse
+//	@Router			/serial-rule/validate [get]
+func (h *SerialRuleHandler) ValidateSerialNumber(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/serial-rule/validate?sn={sn}&manufacturer={manufacturer_id}&device={device_id}")
+		return
+	}
+
+	sn := r.FormValue("sn")
+	if sn == "" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing serial number", "GET /api/v1/serial-rule/validate?sn={sn}&manufacturer={manufacturer_id}&device={device_id}")
+		return
+	}
+	m, err := strconv.Atoi(r.FormValue("manufacturer"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "manufacturer id is not a number", "GET /api/v1/serial-rule/validate?sn={sn}&manufacturer={manufacturer_id}&device={device_id}")
+		return
+	}
+	d, err := strconv.Atoi(r.FormValue("device"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "device id is not a number", "GET /api/v1/serial-rule/validate?sn={sn}&manufacturer={manufacturer_id}&device={device_id}")
+		return
+	}
+
+	failures, err := validateSerialNumber(r.Context(), q, sn, int32(m), int32(d))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial number rules", "GET /api/v1/serial-rule/validate?sn={sn}&manufacturer={manufacturer_id}&device={device_id}")
+		return
+	}
+
+	canonical, err := canonicalSerial(r.Context(), q, sn, int32(m))
+	if se, ok := asStatusError(err); ok {
+		failures = append(failures, serial.Failure{Rule: "normalization", Reason: se.msg})
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for serial normalization", "GET /api/v1/serial-rule/validate?sn={sn}&manufacturer={manufacturer_id}&device={device_id}")
+		return
+	}
+
+	v := models.SerialNumberValidation{SerialNumber: sn, Canonical: canonical, Valid: len(failures) == 0, Failures: []models.SerialNumberRuleFailure{}}
+	for _, f := range failures {
+		v.Failures = append(v.Failures, models.SerialNumberRuleFailure{RuleID: f.RuleID, Rule: f.Rule, Reason: f.Reason})
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, v)
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+const (
+	// defaultStatsDays is how far back growth looks when from is left out
+	defaultStatsDays = 365
+	// maxGrowthPeriods is the most periods growth can be split into
+	maxGrowthPeriods = 1000
+)
+
+// periodStart returns the first day of the interval period t falls in, weeks start on
+// monday
+func periodStart(interval string, t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch interval {
+	case "week":
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	case "month":
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// nextPeriod returns the first day of the interval period after the one starting at t
+func nextPeriod(interval string, t time.Time) time.Time {
+	switch interval {
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "month":
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// equipmentGrowth returns the equipment added in every interval period from the period
+// from falls in up to the one to falls in
+func equipmentGrowth(ctx context.Context, q *sqlc.Queries, interval string, from, to time.Time) ([]models.GrowthPeriod, error) {
+	start := periodStart(interval, from)
+	end := to.AddDate(0, 0, 1)
+
+	total, err := q.CountEquipmentCreatedBefore(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	days, err := q.CountEquipmentCreatedByDay(ctx, sqlc.CountEquipmentCreatedByDayParams{From: start, To: end})
+	if err != nil {
+		return nil, err
+	}
+	added := map[time.Time]int64{}
+	for _, v := range days {
+		day, err := time.Parse(dateLayout, v.Day)
+		if err != nil {
+			return nil, err
+		}
+		added[periodStart(interval, day)] += v.Total
+	}
+
+	growth := []models.GrowthPeriod{}
+	for p := start; p.Before(end); p = nextPeriod(interval, p) {
+		total += added[p]
+		growth = append(growth, models.GrowthPeriod{Start: p.Format(dateLayout), Added: added[p], Total: total})
+	}
+	return growth, nil
+}
+
+// inventoryStats totals equipment by status, device type and manufacturer from one
+// grouped query
+func inventoryStats(ctx context.Context, q *sqlc.Queries) (models.InventoryStats, error) {
+	s := models.InventoryStats{
+		ByDeviceType:             []models.GroupCount{},
+		ByManufacturer:           []models.GroupCount{},
+		ByDeviceTypeManufacturer: []models.DeviceManufacturerCount{},
+	}
+	rows, err := q.CountEquipmentByDeviceTypeAndManufacturer(ctx)
+	if err != nil {
+		return s, err
+	}
+
+	var active, inactive int64
+	devices := map[int32]*models.GroupCount{}
+	manufacturers := map[int32]*models.GroupCount{}
+	for _, v := range rows {
+		total := v.Active + v.Inactive
+		active += v.Active
+		inactive += v.Inactive
+		s.ByDeviceTypeManufacturer = append(s.ByDeviceTypeManufacturer, models.DeviceManufacturerCount{
+			DeviceTypeID:   v.DeviceTypeID,
+			DeviceType:     v.DeviceType,
+			ManufacturerID: v.ManufacturerID,
+			Manufacturer:   v.Manufacturer,
+			Active:         v.Active,
+			Inactive:       v.Inactive,
+			Total:          total,
+		})
+
+		d, ok := devices[v.DeviceTypeID]
+		if !ok {
+			d = &models.GroupCount{ID: v.DeviceTypeID, Name: v.DeviceType}
+			devices[v.DeviceTypeID] = d
+		}
+		d.Active += v.Active
+		d.Inactive += v.Inactive
+		d.Total += total
+
+		m, ok := manufacturers[v.ManufacturerID]
+		if !ok {
+			m = &models.GroupCount{ID: v.ManufacturerID, Name: v.Manufacturer}
+			manufacturers[v.ManufacturerID] = m
+		}
+		m.Active += v.Active
+		m.Inactive += v.Inactive
+		m.Total += total
+	}
+
+	s.Total = active + inactive
+	s.ByStatus = []models.StatusCount{{Status: "active", Total: active}, {Status: "inactive", Total: inactive}}
+	for _, v := range devices {
+		s.ByDeviceType = append(s.ByDeviceType, *v)
+	}
+	for _, v := range manufacturers {
+		s.ByManufacturer = append(s.ByManufacturer, *v)
+	}
+	byID := func(a, b models.GroupCount) int { return int(a.ID - b.ID) }
+	slices.SortFunc(s.ByDeviceType, byID)
+	slices.SortFunc(s.ByManufacturer, byID)
+	return s, nil
+}
+
+// GetStats get inventory statistics
+//
+//	@Summary		get inventory statistics
+//	@Description	get equipment totals by status, device type, manufacturer and device type and manufacturer pair, and how much equipment was added in every day, week or month from from to to. Periods are whole, so growth starts on the first day of the period from falls in. Equipment that existed before creation times were recorded counts as added on the day recording started
+//	@Tags			stats
+//	@Accept			json
+//	@Produce		json
+//	@Param			interval	query		string	false	"length of the growth periods"	Enums(day, week, month)	default(month)
+//	@Param			from		query		string	false	"first day of growth, defaults to a year before to"	format(date)
+//	@Param			to			query		string	false	"last day of growth, defaults to today"	format(date)
+//	@Success		200			{object}	models.JsonResponse{MSG=models.InventoryStats}
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/stats [get]
+func (h *EquipmentHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/stats?interval={interval}&from={from}&to={to}")
+		return
+	}
+
+	interval := r.FormValue("interval")
+	if interval == "" {
+		interval = "month"
+	}
+	if interval != "day" && interval != "week" && interval != "month" {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "interval must be day, week or month", "GET /api/v1/stats?interval={interval}&from={from}&to={to}")
+		return
+	}
+
+	to, err := parseDate("to", r.FormValue("to"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/stats?interval={interval}&from={from}&to={to}")
+		return
+	}
+	if !to.Valid {
+		to = sql.NullTime{Time: today(), Valid: true}
+	}
+	from, err := parseDate("from", r.FormValue("from"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "GET /api/v1/stats?interval={interval}&from={from}&to={to}")
+		return
+	}
+	if !from.Valid {
+		from = sql.NullTime{Time: to.Time.AddDate(0, 0, -defaultStatsDays), Valid: true}
+	}
+	if to.Time.Before(from.Time) {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "to cannot be before from", "GET /api/v1/stats?interval={interval}&from={from}&to={to}")
+		return
+	}
+	periods := 0
+	for p := periodStart(interval, from.Time); !p.After(to.Time); p = nextPeriod(interval, p) {
+		if periods++; periods > maxGrowthPeriods {
+			helpers.JsonResponseError(w, http.StatusBadRequest, fmt.Sprintf("from and to cannot span more than %d periods, use a longer interval", maxGrowthPeriods), "GET /api/v1/stats?interval={interval}&from={from}&to={to}")
+			return
+		}
+	}
+
+	s, err := inventoryStats(r.Context(), q)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment totals", "GET /api/v1/stats?interval={interval}&from={from}&to={to}")
+		return
+	}
+	s.Interval = interval
+	s.Growth, err = equipmentGrowth(r.Context(), q, interval, from.Time, to.Time)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment growth", "GET /api/v1/stats?interval={interval}&from={from}&to={to}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, s)
+}
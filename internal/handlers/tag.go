@@ -0,0 +1,547 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/coltonmosier/api-v1/internal/database"
+	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/models"
+	"github.com/coltonmosier/api-v1/internal/sqlc"
+)
+
+const (
+	// maxTagLength is the size of tags.name
+	maxTagLength = 50
+	// maxTagsPerRequest is the most tags a request can add, remove or filter on
+	maxTagsPerRequest = 20
+	// maxBulkTagEquipment is the most equipment a bulk tag request can change
+	maxBulkTagEquipment = 1000
+)
+
+// tagPattern matches a tag name once lower cased
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+type TagHandler struct{}
+
+func tagFromRow(v sqlc.Tag) models.Tag {
+	return models.Tag{ID: v.ID, Name: v.Name}
+}
+
+// parseTagName lower cases name and checks it can be used as a tag
+func parseTagName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", statusError{http.StatusBadRequest, "missing tag name"}
+	}
+	if len(name) > maxTagLength {
+		return "", statusError{http.StatusBadRequest, fmt.Sprintf("tag %s cannot be longer than %d characters", name, maxTagLength)}
+	}
+	if !tagPattern.MatchString(name) {
+		return "", statusError{http.StatusBadRequest, fmt.Sprintf("tag %s can only have letters, digits, dots, dashes and underscores and has to start with a letter or digit", name)}
+	}
+	return name, nil
+}
+
+// parseTagNames reads tag names from values, which can each be a comma separated list.
+// Duplicates are dropped
+func parseTagNames(values []string) ([]string, error) {
+	var names []string
+	for _, v := range values {
+		for _, raw := range strings.Split(v, ",") {
+			name, err := parseTagName(raw)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) > maxTagsPerRequest {
+		return nil, statusError{http.StatusBadRequest, fmt.Sprintf("cannot use more than %d tags at once", maxTagsPerRequest)}
+	}
+	return names, nil
+}
+
+// parseEquipmentIDs reads equipment ids from values, which can each be a comma separated list.
+// Duplicates are dropped
+func parseEquipmentIDs(values []string) ([]int32, error) {
+	var ids []int32
+	for _, v := range values {
+		for _, raw := range strings.Split(v, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, statusError{http.StatusBadRequest, "equipment id is not a number"}
+			}
+			if !slices.Contains(ids, int32(n)) {
+				ids = append(ids, int32(n))
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, statusError{http.StatusBadRequest, "missing equipment id"}
+	}
+	if len(ids) > maxBulkTagEquipment {
+		return nil, statusError{http.StatusBadRequest, fmt.Sprintf("cannot tag more than %d equipment at once", maxBulkTagEquipment)}
+	}
+	return ids, nil
+}
+
+// ensureTags returns the tags named, creating the ones that don't exist yet. q should be
+// bound to a transaction
+func ensureTags(ctx context.Context, q *sqlc.Queries, names []string) ([]sqlc.Tag, error) {
+	tags, err := q.GetTagsByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if slices.ContainsFunc(tags, func(t sqlc.Tag) bool { return t.Name == name }) {
+			continue
+		}
+		id, err := q.CreateTag(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, sqlc.Tag{ID: int32(id), Name: name})
+	}
+	return tags, nil
+}
+
+// existingTags returns the tags named, failing when one doesn't exist
+func existingTags(ctx context.Context, q *sqlc.Queries, names []string) ([]sqlc.Tag, error) {
+	tags, err := q.GetTagsByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(tags, func(t sqlc.Tag) bool { return t.Name == name }) {
+			return nil, statusError{http.StatusBadRequest, "tag " + name + " does not exist in database"}
+		}
+	}
+	return tags, nil
+}
+
+// checkEquipmentExist fails when any of ids isn't equipment
+func checkEquipmentExist(ctx context.Context, q *sqlc.Queries, ids []int32) error {
+	d, err := q.GetEquipmentByAutoIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(d) == len(ids) {
+		return nil
+	}
+	var missing []string
+	for _, id := range ids {
+		if !slices.ContainsFunc(d, func(e sqlc.SerialNumber) bool { return e.AutoID == id }) {
+			missing = append(missing, strconv.Itoa(int(id)))
+		}
+	}
+	return statusError{http.StatusBadRequest, "equipment ids do not exist: " + strings.Join(missing, ", ")}
+}
+
+// GetTags get all tags
+//
+//	@Summary		get all tags
+//	@Description	get all tags with the number of equipment carrying each, by name
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.TagUsage}
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/tag [get]
+func (h *TagHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/tag")
+		return
+	}
+
+	d, err := q.GetTags(r.Context())
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for tags", "GET /api/v1/tag")
+		return
+	}
+
+	t := []models.TagUsage{}
+	for _, v := range d {
+		t = append(t, models.TagUsage{Tag: models.Tag{ID: v.ID, Name: v.Name}, Count: v.Count})
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, t)
+}
+
+// CreateTag create tag
+//
+//	@Summary		create tag
+//	@Description	create a tag, tags are also created when first added to equipment. Names are lower cased
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	query		string	true	"tag name"	maxlength(50)
+//	@Success		200		{object}	models.JsonResponse{MSG=models.Tag}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		409		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/tag [post]
+func (h *TagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "POST /api/v1/tag?name={name}")
+		return
+	}
+
+	name, err := parseTagName(r.FormValue("name"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/tag?name={name}")
+		return
+	}
+
+	taken, err := q.GetTagsByNames(r.Context(), []string{name})
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for tags", "POST /api/v1/tag?name={name}")
+		return
+	} else if len(taken) > 0 {
+		helpers.JsonResponseError(w, http.StatusConflict, "tag already exists in database", "POST /api/v1/tag?name={name}")
+		return
+	}
+
+	id, err := q.CreateTag(r.Context(), name)
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to create tag in database", "POST /api/v1/tag?name={name}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, models.Tag{ID: int32(id), Name: name})
+}
+
+// UpdateTag rename tag
+//
+//	@Summary		rename tag
+//	@Description	rename a tag, the equipment carrying it keeps it
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"tag id"	minimum(1)
+//	@Param			name	query		string	true	"new tag name"	maxlength(50)
+//	@Success		200		{object}	models.JsonResponse{MSG=models.Tag}
+//	@Failure		400		{object}	models.JsonResponse
+//	@Failure		409		{object}	models.JsonResponse
+//	@Failure		500		{object}	models.JsonResponse
+//	@Router			/tag/{id} [patch]
+func (h *TagHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "tag id is not a number", "PATCH /api/v1/tag/{id}?name={name}")
+		return
+	}
+
+	name, err := parseTagName(r.FormValue("name"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/tag/{id}?name={name}")
+		return
+	}
+
+	err = database.WithTx(r.Context(), func(q *sqlc.Queries) error {
+		_, err := q.GetTagByID(r.Context(), int32(i))
+		if err == sql.ErrNoRows {
+			return statusError{http.StatusBadRequest, "tag id does not exist in database"}
+		} else if err != nil {
+			return err
+		}
+		taken, err := q.GetTagsByNames(r.Context(), []string{name})
+		if err != nil {
+			return err
+		}
+		if len(taken) > 0 && taken[0].ID != int32(i) {
+			return statusError{http.StatusConflict, "tag already exists in database"}
+		}
+		return q.UpdateTag(r.Context(), sqlc.UpdateTagParams{ID: int32(i), Name: name})
+	})
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "PATCH /api/v1/tag/{id}?name={name}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update tag in database", "PATCH /api/v1/tag/{id}?name={name}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, models.Tag{ID: int32(i), Name: name})
+}
+
+// DeleteTag delete tag
+//
+//	@Summary		delete tag
+//	@Description	delete a tag and take it off every equipment carrying it
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"tag id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/tag/{id} [delete]
+func (h *TagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "DELETE /api/v1/tag/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "tag id is not a number", "DELETE /api/v1/tag/{id}")
+		return
+	}
+
+	_, err = q.GetTagByID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "tag id does not exist in database", "DELETE /api/v1/tag/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for tag", "DELETE /api/v1/tag/{id}")
+		return
+	}
+
+	if err := q.DeleteTag(r.Context(), int32(i)); err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to delete tag from database", "DELETE /api/v1/tag/{id}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, fmt.Sprintf("tag with id: %v deleted", i))
+}
+
+// GetEquipmentTags get the tags of equipment
+//
+//	@Summary		get the tags of equipment
+//	@Description	get the tags of equipment, by name
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"equipment id"	minimum(1)
+//	@Success		200	{object}	models.JsonResponse{MSG=[]models.Tag}
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/tag/equipment/{id} [get]
+func (h *EquipmentHandler) GetEquipmentTags(w http.ResponseWriter, r *http.Request) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "could not connect to database", "GET /api/v1/tag/equipment/{id}")
+		return
+	}
+
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "GET /api/v1/tag/equipment/{id}")
+		return
+	}
+
+	_, err = q.GetEquipmentByAutoID(r.Context(), int32(i))
+	if err == sql.ErrNoRows {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "equipment id does not exist", "GET /api/v1/tag/equipment/{id}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for equipment", "GET /api/v1/tag/equipment/{id}")
+		return
+	}
+
+	d, err := q.GetEquipmentTags(r.Context(), int32(i))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to query database for tags", "GET /api/v1/tag/equipment/{id}")
+		return
+	}
+
+	t := []models.Tag{}
+	for _, v := range d {
+		t = append(t, tagFromRow(v))
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, t)
+}
+
+// tagEquipment adds or removes every tag named to every equipment in ids, tags are created
+// when added for the first time
+func tagEquipment(ctx context.Context, ids []int32, names []string, add bool) error {
+	return database.WithTx(ctx, func(q *sqlc.Queries) error {
+		if err := checkEquipmentExist(ctx, q, ids); err != nil {
+			return err
+		}
+		var tags []sqlc.Tag
+		var err error
+		if add {
+			tags, err = ensureTags(ctx, q, names)
+		} else {
+			tags, err = existingTags(ctx, q, names)
+		}
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			for _, t := range tags {
+				if add {
+					err = q.AddEquipmentTag(ctx, sqlc.AddEquipmentTagParams{EquipmentID: id, TagID: t.ID})
+				} else {
+					err = q.RemoveEquipmentTag(ctx, sqlc.RemoveEquipmentTagParams{EquipmentID: id, TagID: t.ID})
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// AddEquipmentTags tag equipment
+//
+//	@Summary		tag equipment
+//	@Description	add tags to equipment, tags that don't exist yet are created. Tags the equipment already has are left alone
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int			true	"equipment id"	minimum(1)
+//	@Param			tag	query		[]string	true	"tag names, repeated or comma separated"	collectionFormat(multi)
+//	@Success		200	{object}	models.JsonResponse
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/equipment/{id}/tags [post]
+func (h *EquipmentHandler) AddEquipmentTags(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "POST /api/v1/equipment/{id}/tags?tag={tag}")
+		return
+	}
+
+	r.ParseForm()
+	names, err := parseTagNames(r.Form["tag"])
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment/{id}/tags?tag={tag}")
+		return
+	}
+	if len(names) == 0 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing tag name", "POST /api/v1/equipment/{id}/tags?tag={tag}")
+		return
+	}
+
+	err = tagEquipment(r.Context(), []int32{int32(i)}, names, true)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "POST /api/v1/equipment/{id}/tags?tag={tag}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to tag equipment", "POST /api/v1/equipment/{id}/tags?tag={tag}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, fmt.Sprintf("equipment with id: %v tagged %v", i, strings.Join(names, ", ")))
+}
+
+// RemoveEquipmentTag untag equipment
+//
+//	@Summary		untag equipment
+//	@Description	take a tag off equipment, the tag itself is kept
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int		true	"equipment id"	minimum(1)
+//	@Param			tag	path		string	true	"tag name"
+//	@Success		200	{object}	models.JsonResponse
+//	@Failure		400	{object}	models.JsonResponse
+//	@Failure		500	{object}	models.JsonResponse
+//	@Router			/equipment/{id}/tags/{tag} [delete]
+func (h *EquipmentHandler) RemoveEquipmentTag(w http.ResponseWriter, r *http.Request) {
+	i, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "id is not a number", "DELETE /api/v1/equipment/{id}/tags/{tag}")
+		return
+	}
+
+	name, err := parseTagName(r.PathValue("tag"))
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "DELETE /api/v1/equipment/{id}/tags/{tag}")
+		return
+	}
+
+	err = tagEquipment(r.Context(), []int32{int32(i)}, []string{name}, false)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, "DELETE /api/v1/equipment/{id}/tags/{tag}")
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to untag equipment", "DELETE /api/v1/equipment/{id}/tags/{tag}")
+		return
+	}
+
+	helpers.JsonResponseSuccess(w, http.StatusOK, fmt.Sprintf("equipment with id: %v untagged %v", i, name))
+}
+
+// BulkTagEquipment tag many equipment
+//
+//	@Summary		tag many equipment
+//	@Description	add every tag to every equipment, tags that don't exist yet are created. Nothing changes unless every equipment exists
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			equipment	query		[]int		true	"equipment ids, repeated or comma separated"	collectionFormat(multi)
+//	@Param			tag			query		[]string	true	"tag names, repeated or comma separated"		collectionFormat(multi)
+//	@Success		200			{object}	models.JsonResponse
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/equipment/tags [post]
+func (h *EquipmentHandler) BulkTagEquipment(w http.ResponseWriter, r *http.Request) {
+	h.bulkTag(w, r, true, "POST /api/v1/equipment/tags?equipment={ids}&tag={tag}")
+}
+
+// BulkUntagEquipment untag many equipment
+//
+//	@Summary		untag many equipment
+//	@Description	take every tag off every equipment. Nothing changes unless every equipment and tag exists
+//	@Tags			tag
+//	@Accept			json
+//	@Produce		json
+//	@Param			equipment	query		[]int		true	"equipment ids, repeated or comma separated"	collectionFormat(multi)
+//	@Param			tag			query		[]string	true	"tag names, repeated or comma separated"		collectionFormat(multi)
+//	@Success		200			{object}	models.JsonResponse
+//	@Failure		400			{object}	models.JsonResponse
+//	@Failure		500			{object}	models.JsonResponse
+//	@Router			/equipment/tags [delete]
+func (h *EquipmentHandler) BulkUntagEquipment(w http.ResponseWriter, r *http.Request) {
+	h.bulkTag(w, r, false, "DELETE /api/v1/equipment/tags?equipment={ids}&tag={tag}")
+}
+
+func (h *EquipmentHandler) bulkTag(w http.ResponseWriter, r *http.Request, add bool, action string) {
+	r.ParseForm()
+	ids, err := parseEquipmentIDs(r.Form["equipment"])
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, action)
+		return
+	}
+	names, err := parseTagNames(r.Form["tag"])
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, action)
+		return
+	}
+	if len(names) == 0 {
+		helpers.JsonResponseError(w, http.StatusBadRequest, "missing tag name", action)
+		return
+	}
+
+	err = tagEquipment(r.Context(), ids, names, add)
+	if se, ok := asStatusError(err); ok {
+		helpers.JsonResponseError(w, se.code, se.msg, action)
+		return
+	} else if err != nil {
+		helpers.JsonResponseError(w, http.StatusInternalServerError, "failed to update equipment tags", action)
+		return
+	}
+
+	verb := "added to"
+	if !add {
+		verb = "removed from"
+	}
+	helpers.JsonResponseSuccess(w, http.StatusOK, fmt.Sprintf("%d tags %s %d equipment", len(names), verb, len(ids)))
+}
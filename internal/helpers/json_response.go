@@ -1,8 +1,9 @@
 package helpers
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/coltonmosier/api-v1/internal/models"
@@ -19,10 +20,11 @@ func JsonResponseSuccess(w http.ResponseWriter, status int, message models.Messa
 	}
 	output, err := json.Marshal(out)
 	if err != nil {
-		log.Println("Error marshalling JSON")
+		slog.Error("Error marshalling JSON", slog.String("error", err.Error()))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	w.Write(output)
 	return
@@ -31,18 +33,35 @@ func JsonResponseSuccess(w http.ResponseWriter, status int, message models.Messa
 // JsonResponseError returns nothing
 // JsonResponseError takes in a http.ResponseWriter, status int, message string, action string
 // uses the paramaters to construct an error response for handlers
+// the request id set by the middleware on the response headers is added to the body and
+// the error is logged with it
 func JsonResponseError(w http.ResponseWriter, status int, message models.Message, action string) {
+	id := w.Header().Get("X-Request-ID")
 	out := models.JsonResponse{
-		Status:  "ERROR",
-		Message: message,
-		Action:  action,
+		Status:    "ERROR",
+		Message:   message,
+		Action:    action,
+		RequestID: id,
+	}
+
+	level := slog.LevelInfo
+	if status >= http.StatusInternalServerError {
+		level = slog.LevelError
 	}
+	slog.Default().Log(context.Background(), level, "request failed",
+		slog.String("request_id", id),
+		slog.Int("status", status),
+		slog.Any("message", message),
+		slog.String("action", action),
+	)
+
 	output, err := json.Marshal(out)
 	if err != nil {
-		log.Println("Error marshalling JSON")
+		slog.Error("Error marshalling JSON", slog.String("error", err.Error()))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	w.Write(output)
 }
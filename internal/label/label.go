@@ -0,0 +1,156 @@
+package label
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+// Symbology is the kind of code printed on a label
+type Symbology string
+
+const (
+	// Code128 is a linear barcode most handheld scanners read
+	Code128 Symbology = "code128"
+	// QR is a square code phone cameras read, it fits longer content like urls
+	QR Symbology = "qr"
+)
+
+// MaxScale is the most pixels a module can be drawn with
+const MaxScale = 20
+
+// barHeight is how many modules tall linear codes are drawn
+const barHeight = 25
+
+// Parse returns the symbology named s
+func Parse(s string) (Symbology, error) {
+	switch sym := Symbology(s); sym {
+	case Code128, QR:
+		return sym, nil
+	}
+	return "", fmt.Errorf("symbology %q must be code128 or qr", s)
+}
+
+// Code is content encoded in a symbology, ready to be drawn
+type Code struct {
+	Symbology Symbology
+	bc        barcode.Barcode
+}
+
+// Encode returns content encoded as sym
+func Encode(sym Symbology, content string) (Code, error) {
+	var bc barcode.Barcode
+	var err error
+	switch sym {
+	case Code128:
+		bc, err = code128.Encode(content)
+	case QR:
+		bc, err = qr.Encode(content, qr.M, qr.Auto)
+	default:
+		return Code{}, fmt.Errorf("unknown symbology %q", sym)
+	}
+	if err != nil {
+		return Code{}, fmt.Errorf("cannot encode as %s: %w", sym, err)
+	}
+	return Code{Symbology: sym, bc: bc}, nil
+}
+
+// Modules returns the width of the code in modules, without the quiet zone
+func (c Code) Modules() int {
+	return c.bc.Bounds().Dx()
+}
+
+// linear reports whether c is a one dimensional barcode
+func (c Code) linear() bool {
+	return c.bc.Metadata().Dimensions == 1
+}
+
+// quietZone is the blank margin in modules scanners need around the code
+func (c Code) quietZone() int {
+	if c.linear() {
+		return 10
+	}
+	return 4
+}
+
+// dark reports whether the module at x, y is drawn, linear codes only have y 0
+func (c Code) dark(x, y int) bool {
+	r, _, _, _ := c.bc.At(x, y).RGBA()
+	return r == 0
+}
+
+// size returns the width and height of c in modules, quiet zone included
+func (c Code) size() (int, int) {
+	q := c.quietZone()
+	w := c.Modules() + 2*q
+	if c.linear() {
+		return w, barHeight
+	}
+	return w, w
+}
+
+// Image draws c with scale pixels per module
+func (c Code) Image(scale int) image.Image {
+	w, h := c.size()
+	q := c.quietZone()
+	img := image.NewPaletted(image.Rect(0, 0, w*scale, h*scale), color.Palette{color.White, color.Black})
+	for y := 0; y < h*scale; y++ {
+		my := y/scale - q
+		if c.linear() {
+			my = 0
+		}
+		for x := 0; x < w*scale; x++ {
+			mx := x/scale - q
+			if mx >= 0 && mx < c.Modules() && my >= 0 && my < c.bc.Bounds().Dy() && c.dark(mx, my) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	return img
+}
+
+// PNG writes c as a png with scale pixels per module
+func (c Code) PNG(w io.Writer, scale int) error {
+	return png.Encode(w, c.Image(scale))
+}
+
+// SVG writes c as an svg with scale pixels per module, runs of dark modules are
+// merged into one rect to keep the file small
+func (c Code) SVG(w io.Writer, scale int) error {
+	width, height := c.size()
+	q := c.quietZone()
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, width*scale, height*scale, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, width, height)
+	rows, rowHeight := c.bc.Bounds().Dy(), 1
+	if c.linear() {
+		rows, rowHeight = 1, height
+	}
+	for y := 0; y < rows; y++ {
+		top := y + q
+		if c.linear() {
+			top = 0
+		}
+		for x := 0; x < c.Modules(); {
+			if !c.dark(x, y) {
+				x++
+				continue
+			}
+			start := x
+			for x < c.Modules() && c.dark(x, y) {
+				x++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv%dh-%dz", start+q, top, x-start, rowHeight, x-start)
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
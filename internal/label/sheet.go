@@ -0,0 +1,109 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Label is what is printed for one equipment
+type Label struct {
+	Code Code
+	// Lines are printed beside a QR code or under a barcode, the first one in bold
+	Lines []string
+}
+
+// Layout is a sheet of label stock, sizes are in millimetres
+type Layout struct {
+	Name string
+	// PageSize is the fpdf name of the page size
+	PageSize       string
+	Cols, Rows     int
+	Left, Top      float64
+	Width, Height  float64
+	PitchX, PitchY float64
+}
+
+// PerPage returns the number of labels on a sheet
+func (l Layout) PerPage() int {
+	return l.Cols * l.Rows
+}
+
+// Layouts are the label sheets a PDF can be laid out for, by name
+var Layouts = map[string]Layout{
+	// Avery 5160 and compatible, 30 labels of 2.625 x 1 inch
+	"letter": {Name: "letter", PageSize: "Letter", Cols: 3, Rows: 10, Left: 4.76, Top: 12.7, Width: 66.68, Height: 25.4, PitchX: 69.85, PitchY: 25.4},
+	// Avery L7160 and compatible, 21 labels of 63.5 x 38.1 mm
+	"a4": {Name: "a4", PageSize: "A4", Cols: 3, Rows: 7, Left: 7.21, Top: 15.15, Width: 63.5, Height: 38.1, PitchX: 66.04, PitchY: 38.1},
+}
+
+// padding is the blank space kept inside each label
+const padding = 2.0
+
+// Sheet writes labels as a PDF laid out for l, the first skip labels of the first page
+// are left blank so a partly used sheet can be printed on again
+func Sheet(w io.Writer, labels []Label, l Layout, skip int) error {
+	pdf := fpdf.New("P", "mm", l.PageSize, "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	for i, lb := range labels {
+		pos := (i + skip) % l.PerPage()
+		if i == 0 || pos == 0 {
+			pdf.AddPage()
+		}
+		x := l.Left + float64(pos%l.Cols)*l.PitchX
+		y := l.Top + float64(pos/l.Cols)*l.PitchY
+
+		var img bytes.Buffer
+		if err := lb.Code.PNG(&img, 2); err != nil {
+			return err
+		}
+		name := fmt.Sprintf("code-%d", i)
+		pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, &img)
+
+		var textX, textY, textW float64
+		if lb.Code.linear() {
+			h := (l.Height - 2*padding) * 0.55
+			pdf.ImageOptions(name, x+padding, y+padding, l.Width-2*padding, h, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+			textX, textY, textW = x+padding, y+padding+h+0.5, l.Width-2*padding
+		} else {
+			size := l.Height - 2*padding
+			pdf.ImageOptions(name, x+padding, y+padding, size, size, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+			textX, textY, textW = x+2*padding+size, y+padding+1, l.Width-3*padding-size
+		}
+
+		for j, line := range lb.Lines {
+			if j == 0 {
+				pdf.SetFont("Helvetica", "B", 8)
+			} else {
+				pdf.SetFont("Helvetica", "", 7)
+			}
+			if textY+3.5 > y+l.Height {
+				break
+			}
+			pdf.SetXY(textX, textY)
+			pdf.CellFormat(textW, 3.5, fit(pdf, tr(line), textW), "", 0, "L", false, 0, "")
+			textY += 3.5
+		}
+	}
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+	return pdf.Output(w)
+}
+
+// fit shortens s until it is narrower than width in the current font
+func fit(pdf *fpdf.Fpdf, s string, width float64) string {
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	for s != "" && pdf.GetStringWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+	return strings.TrimSpace(s) + "..."
+}
@@ -0,0 +1,57 @@
+package label
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ZPL label size in dots, 2 x 1 inch at 203 dpi
+const (
+	zplWidth  = 406
+	zplHeight = 203
+	zplMargin = 20
+)
+
+// zplEscaper hex escapes the characters ZPL treats as commands inside ^FD, it is
+// used with ^FH_
+var zplEscaper = strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E")
+
+// ZPL writes labels for 2 x 1 inch stock on a 203 dpi thermal printer, one ^XA ^XZ
+// block per label. The printer draws the codes itself from the content
+func ZPL(w io.Writer, labels []Label) error {
+	var b strings.Builder
+	for _, lb := range labels {
+		b.WriteString("^XA^CI28^PW406^LL203\n")
+		content := zplEscaper.Replace(lb.Code.bc.Content())
+
+		textX, textY, textW := zplMargin, 0, zplWidth-2*zplMargin
+		if lb.Code.linear() {
+			by := max(1, min(3, textW/lb.Code.Modules()))
+			fmt.Fprintf(&b, "^FO%d,%d^BY%d^BCN,80,N,N,N^FH_^FD%s^FS\n", zplMargin, zplMargin, by, content)
+			textY = zplMargin + 88
+		} else {
+			mag := max(1, min(10, (zplHeight-2*zplMargin)/lb.Code.Modules()))
+			// NOTE: MA, picks error correction level M and automatic input mode
+			fmt.Fprintf(&b, "^FO%d,%d^BQN,2,%d^FH_^FDMA,%s^FS\n", zplMargin-10, zplMargin-10, mag, content)
+			textX = zplMargin + lb.Code.Modules()*mag + 10
+			textY = zplMargin
+			textW = zplWidth - zplMargin - textX
+		}
+
+		for i, line := range lb.Lines {
+			size := 22
+			if i == 0 {
+				size = 28
+			}
+			if textY+size > zplHeight {
+				break
+			}
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,1,0,L,0^FH_^FD%s^FS\n", textX, textY, size, size, textW, zplEscaper.Replace(line))
+			textY += size + 6
+		}
+		b.WriteString("^XZ\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,120 @@
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// State is where a piece of equipment is in its life
+type State string
+
+const (
+	Received State = "received"
+	InStock  State = "in_stock"
+	Deployed State = "deployed"
+	InRepair State = "in_repair"
+	Lost     State = "lost"
+	Retired  State = "retired"
+	Disposed State = "disposed"
+)
+
+// States is every lifecycle state in the order equipment usually moves through them
+var States = []State{Received, InStock, Deployed, InRepair, Lost, Retired, Disposed}
+
+// ErrIllegalTransition is returned by Machine.Check for moves the lifecycle does not allow
+var ErrIllegalTransition = errors.New("illegal lifecycle transition")
+
+// Parse returns the State named s
+func Parse(s string) (State, error) {
+	for _, v := range States {
+		if string(v) == s {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unknown lifecycle state %q", s)
+}
+
+// Legacy returns the active/inactive status older clients see for s, equipment that is
+// lost, retired or disposed is inactive
+func (s State) Legacy() string {
+	switch s {
+	case Lost, Retired, Disposed:
+		return "inactive"
+	}
+	return "active"
+}
+
+// FromLegacy returns the state a legacy status update moves equipment in state from to,
+// or from itself when the status already matches
+func FromLegacy(from State, status string) State {
+	if from.Legacy() == status {
+		return from
+	}
+	if status == "inactive" {
+		return Retired
+	}
+	return InStock
+}
+
+// Machine holds the transitions the lifecycle allows
+type Machine struct {
+	next map[State][]State
+}
+
+// New returns a Machine allowing the transitions, keyed by the state they leave from.
+// Every state has to be known, states without an entry are terminal
+func New(transitions map[string][]string) (*Machine, error) {
+	m := &Machine{next: map[State][]State{}}
+	for from, tos := range transitions {
+		f, err := Parse(from)
+		if err != nil {
+			return nil, err
+		}
+		for _, to := range tos {
+			t, err := Parse(to)
+			if err != nil {
+				return nil, fmt.Errorf("transition from %s: %w", from, err)
+			}
+			if t == f {
+				return nil, fmt.Errorf("transition from %s to itself is not allowed", from)
+			}
+			m.next[f] = append(m.next[f], t)
+		}
+	}
+	return m, nil
+}
+
+// Allowed returns the states equipment in from can move to
+func (m *Machine) Allowed(from State) []State {
+	return m.next[from]
+}
+
+// Check returns an ErrIllegalTransition when equipment can't move from one state to another
+func (m *Machine) Check(from, to State) error {
+	for _, v := range m.next[from] {
+		if v == to {
+			return nil
+		}
+	}
+	allowed := m.next[from]
+	if len(allowed) == 0 {
+		return fmt.Errorf("%w: %s is a final state", ErrIllegalTransition, from)
+	}
+	return fmt.Errorf("%w: %s can only move to %v", ErrIllegalTransition, from, allowed)
+}
+
+// Transitions returns every state with the states it can move to, final states map to
+// an empty list
+func (m *Machine) Transitions() map[string][]string {
+	t := make(map[string][]string, len(States))
+	for _, from := range States {
+		tos := make([]string, 0, len(m.next[from]))
+		for _, to := range m.next[from] {
+			tos = append(tos, string(to))
+		}
+		sort.Strings(tos)
+		t[string(from)] = tos
+	}
+	return t
+}
@@ -0,0 +1,114 @@
+package location
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is the level of a location in the tree
+type Kind string
+
+const (
+	Site     Kind = "site"
+	Building Kind = "building"
+	Room     Kind = "room"
+	Rack     Kind = "rack"
+	Shelf    Kind = "shelf"
+)
+
+// parents maps a kind to the kinds it can be placed in, sites are roots
+var parents = map[Kind][]Kind{
+	Site:     nil,
+	Building: {Site},
+	Room:     {Building},
+	Rack:     {Room},
+	Shelf:    {Room, Rack},
+}
+
+// ParseKind returns the Kind named s
+func ParseKind(s string) (Kind, error) {
+	k := Kind(s)
+	if _, ok := parents[k]; !ok {
+		return "", fmt.Errorf("unknown location kind %q, must be site, building, room, rack or shelf", s)
+	}
+	return k, nil
+}
+
+// CheckParent returns an error when a location of kind can't be placed in a location of
+// kind parent, parent is empty for a location without one
+func CheckParent(kind, parent Kind) error {
+	allowed := parents[kind]
+	if parent == "" {
+		if len(allowed) == 0 {
+			return nil
+		}
+		return fmt.Errorf("a %s must be placed in a %s", kind, join(allowed))
+	}
+	for _, v := range allowed {
+		if v == parent {
+			return nil
+		}
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("a %s cannot have a parent", kind)
+	}
+	return fmt.Errorf("a %s cannot be placed in a %s, only in a %s", kind, parent, join(allowed))
+}
+
+// join lists kinds as "room or rack"
+func join(kinds []Kind) string {
+	s := make([]string, len(kinds))
+	for i, k := range kinds {
+		s[i] = string(k)
+	}
+	return strings.Join(s, " or ")
+}
+
+// Node is a location and the id of its parent, zero for roots
+type Node struct {
+	ID     int32
+	Parent int32
+}
+
+// Tree indexes locations by parent so subtrees can be walked without a query per level
+type Tree struct {
+	parent   map[int32]int32
+	children map[int32][]int32
+}
+
+// NewTree returns the tree of nodes
+func NewTree(nodes []Node) *Tree {
+	t := &Tree{parent: map[int32]int32{}, children: map[int32][]int32{}}
+	for _, n := range nodes {
+		t.parent[n.ID] = n.Parent
+		t.children[n.Parent] = append(t.children[n.Parent], n.ID)
+	}
+	return t
+}
+
+// Subtree returns id followed by every location below it
+func (t *Tree) Subtree(id int32) []int32 {
+	out := []int32{id}
+	for i := 0; i < len(out); i++ {
+		out = append(out, t.children[out[i]]...)
+	}
+	return out
+}
+
+// RollUp returns, for every location, its own count plus the counts of every location
+// below it
+func (t *Tree) RollUp(counts map[int32]int64) map[int32]int64 {
+	total := make(map[int32]int64, len(t.parent))
+	for id := range t.parent {
+		total[id] = 0
+	}
+	for id, n := range counts {
+		// NOTE: walk up from each counted location, guarding against a cycle in bad data
+		seen := map[int32]bool{}
+		for cur := id; cur != 0 && !seen[cur]; cur = t.parent[cur] {
+			seen[cur] = true
+			total[cur] += n
+		}
+	}
+	return total
+}
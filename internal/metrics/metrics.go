@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds every metric the API exposes on /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of http requests by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of http requests by method and route pattern.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	QueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Latency of database queries by sqlc query name and outcome.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"query", "outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		QueryDuration,
+	)
+}
+
+// Handler returns the /metrics handler
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveQuery records how long a query took, errors other than no rows count as failures
+func ObserveQuery(name string, d time.Duration, err error) {
+	outcome := "success"
+	if err != nil && err != sql.ErrNoRows {
+		outcome = "error"
+	}
+	QueryDuration.WithLabelValues(name, outcome).Observe(d.Seconds())
+}
+
+var (
+	dbMu    sync.Mutex
+	dbStats = map[string]prometheus.Collector{}
+)
+
+// RegisterDB exposes the pool stats of db under the name label, replacing the
+// collector of a previous pool with the same name
+func RegisterDB(name string, db *sql.DB) {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+	if old, ok := dbStats[name]; ok {
+		Registry.Unregister(old)
+	}
+	c := collectors.NewDBStatsCollector(db, name)
+	Registry.MustRegister(c)
+	dbStats[name] = c
+}
+
+// InventoryCount is the number of equipment with a status and device type
+type InventoryCount struct {
+	Status     string
+	DeviceType string
+	Total      int64
+}
+
+// inventoryCollector queries the inventory gauges on every scrape
+type inventoryCollector struct {
+	desc  *prometheus.Desc
+	count func(context.Context) ([]InventoryCount, error)
+}
+
+// RegisterInventory exposes equipment_inventory gauges computed by count on every scrape
+func RegisterInventory(count func(context.Context) ([]InventoryCount, error)) {
+	Registry.MustRegister(&inventoryCollector{
+		desc: prometheus.NewDesc("equipment_inventory",
+			"Number of equipment by status and device type.",
+			[]string{"status", "device_type"}, nil),
+		count: count,
+	})
+}
+
+func (c *inventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *inventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	counts, err := c.count(ctx)
+	if err != nil {
+		// NOTE: a down database shouldn't break the rest of the scrape
+		slog.Warn("could not collect inventory metrics", slog.String("error", err.Error()))
+		return
+	}
+	for _, v := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(v.Total), v.Status, v.DeviceType)
+	}
+}
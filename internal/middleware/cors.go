@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coltonmosier/api-v1/internal/helpers"
+)
+
+// CorsOptions holds the cross origin policy applied by CorsMiddleware
+type CorsOptions struct {
+	// AllowedOrigins is the list of origins allowed to call the API, "*" allows any origin
+	AllowedOrigins []string
+	// AllowedMethods is the list of methods a cross origin request may use
+	AllowedMethods []string
+	// AllowedHeaders is the list of request headers a cross origin request may send, "*" allows any header
+	AllowedHeaders []string
+	// AllowCredentials sets Access-Control-Allow-Credentials on allowed requests, it is
+	// ignored when AllowedOrigins holds "*"
+	AllowCredentials bool
+	// MaxAge is how long a browser may cache a preflight response, zero leaves it to the browser
+	MaxAge time.Duration
+}
+
+// CorsMiddleware returns nothing
+// CorsMiddleware takes in the router and the cors options and applies the policy
+// before handing the request to the router. Preflight requests are answered here
+// using the methods actually registered on the router for the requested path.
+func CorsMiddleware(mux *http.ServeMux, opts CorsOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+
+		if r.Method == http.MethodOptions {
+			methods := routeMethods(mux, r, opts.AllowedMethods)
+			if len(methods) == 0 {
+				helpers.JsonResponseError(w, http.StatusNotFound, "endpoint not found", "none")
+				return
+			}
+			w.Header().Set("Allow", strings.Join(append(methods, http.MethodOptions), ", "))
+
+			reqMethod := r.Header.Get("Access-Control-Request-Method")
+			if origin == "" || reqMethod == "" {
+				// NOTE: not a preflight, just tell the caller what the route supports
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			if !opts.originAllowed(origin) {
+				helpers.JsonResponseError(w, http.StatusForbidden, "origin "+origin+" is not allowed", "none")
+				return
+			}
+			if !contains(methods, reqMethod) {
+				helpers.JsonResponseError(w, http.StatusMethodNotAllowed, "method "+reqMethod+" is not allowed on "+r.URL.Path, "OPTIONS "+r.URL.Path)
+				return
+			}
+			reqHeaders := r.Header.Get("Access-Control-Request-Headers")
+			if !opts.headersAllowed(reqHeaders) {
+				helpers.JsonResponseError(w, http.StatusForbidden, "request headers "+reqHeaders+" are not allowed", "none")
+				return
+			}
+
+			opts.setOrigin(w, origin)
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+			if reqHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", reqHeaders)
+			}
+			if opts.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if origin != "" {
+			w.Header().Add("Vary", "Origin")
+			if opts.originAllowed(origin) {
+				opts.setOrigin(w, origin)
+			}
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// routeMethods returns the methods out of allowed that the router has a route for on r's path
+func routeMethods(mux *http.ServeMux, r *http.Request, allowed []string) []string {
+	var out []string
+	for _, m := range allowed {
+		probe := r.Clone(r.Context())
+		probe.Method = m
+		_, pattern := mux.Handler(probe)
+		// NOTE: "/" is the catch all for bad endpoints so it does not count as a route
+		if pattern == "" || pattern == "/" {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func (o CorsOptions) originAllowed(origin string) bool {
+	for _, v := range o.AllowedOrigins {
+		if v == "*" || strings.EqualFold(v, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o CorsOptions) headersAllowed(headers string) bool {
+	if headers == "" {
+		return true
+	}
+	for _, h := range strings.Split(headers, ",") {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue
+		}
+		ok := false
+		for _, v := range o.AllowedHeaders {
+			if v == "*" || strings.EqualFold(v, h) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// setOrigin writes the allow origin headers. A wildcard is never combined with
+// credentials, config validation rejects it and it is not honoured here either so any
+// site cannot make credentialed calls
+func (o CorsOptions) setOrigin(w http.ResponseWriter, origin string) {
+	if contains(o.AllowedOrigins, "*") {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if o.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,12 @@
 package middleware
 
 import (
-	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
-	//"github.com/coltonmosier/api-v1/internal/database"
+
+	"github.com/coltonmosier/api-v1/internal/logging"
 )
 
 type wrappedWriter struct {
@@ -19,35 +19,26 @@ func (w *wrappedWriter) WriteHeader(status int) {
 	w.ResponseWriter.WriteHeader(status)
 }
 
-
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// NOTE: I have to do this here bc it was overwriting the Content-Type header in the response
 		start := time.Now()
-		w.Header().Add("Content-Type", "application/json")
-		w.Header().Add("Access-Control-Allow-Origin", "*")
-		w.Header().Add("Access-Control-Allow-Methods", "GET, POST, PATCH, OPTIONS")
 		wr := &wrappedWriter{w, http.StatusOK}
-		if r.Method == http.MethodOptions {
-			wr.WriteHeader(http.StatusOK)
-			return
-		}
 		next.ServeHTTP(wr, r)
 		ip := strings.Split(r.Header.Get("X-Real-IP"), ":")[0]
 		if ip == "" {
 			ip = strings.Split(r.RemoteAddr, ":")[0]
 		}
-		msg := fmt.Sprintf("%s %d %s %s %v\n", ip, wr.status, r.Method, r.RequestURI, time.Since(start))
-		log.Print(msg)
-
-		// NOTE: This is for logging to the db
-
-		//ipnodots := strings.Replace(ip, ".", "", -1)
-		//query := "INSERT INTO logs (ip, method, url) VALUES ($1, $2, $3)"
-		//_, err := LogDB.Exec(query, ip, r.Method, r.RequestURI)
-		//if err != nil {
-		//    log.Fatal("Error inserting log into database")
-		//}
 
+		level := slog.LevelInfo
+		if wr.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logging.FromContext(r.Context()).LogAttrs(r.Context(), level, "request",
+			slog.String("ip", ip),
+			slog.Int("status", wr.status),
+			slog.String("method", r.Method),
+			slog.String("uri", r.RequestURI),
+			slog.Duration("duration", time.Since(start)),
+		)
 	})
 }
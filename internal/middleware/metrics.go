@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coltonmosier/api-v1/internal/metrics"
+)
+
+// MetricsMiddleware returns nothing
+// MetricsMiddleware counts and times every request by the route pattern the router
+// matched, like /api/v1/equipment/device/{id}, so raw urls don't blow up the labels
+func MetricsMiddleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		wr := &wrappedWriter{w, http.StatusOK}
+		next.ServeHTTP(wr, r)
+
+		route := RoutePattern(mux, r)
+		metrics.HTTPRequests.WithLabelValues(r.Method, route, strconv.Itoa(wr.status)).Inc()
+		metrics.HTTPDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// RoutePattern returns the path of the pattern mux routes r to, or "unmatched" when
+// only the catch all "/" matches
+func RoutePattern(mux *http.ServeMux, r *http.Request) string {
+	_, pattern := mux.Handler(r)
+	if pattern == "" || pattern == "/" {
+		return "unmatched"
+	}
+	// NOTE: patterns look like "GET /api/v1/device/{id}", the method is its own label
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		return path
+	}
+	return pattern
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+
+	"github.com/coltonmosier/api-v1/internal/logging"
+)
+
+// RequestIDHeader is the header a request id is read from and echoed back in
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware returns nothing
+// RequestIDMiddleware propagates the caller's X-Request-ID, or generates one, echoes it in
+// the response and puts it and a logger tagged with it in the request context
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := logging.WithRequestID(r.Context(), id)
+		ctx = logging.WithLogger(ctx, slog.Default().With(slog.String("request_id", id)))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// validRequestID only accepts short ids made of characters that are safe to log and echo
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/coltonmosier/api-v1/internal/logging"
+	"github.com/coltonmosier/api-v1/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware returns nothing
+// TracingMiddleware continues the caller's trace from the traceparent header, or starts a
+// new one, with a server span named after the route pattern. The trace id is added to the
+// request logger so log lines can be matched to traces
+func TracingMiddleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		route := RoutePattern(mux, r)
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("request_id", logging.RequestID(ctx)),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.IsValid() {
+			ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With(slog.String("trace_id", sc.TraceID().String())))
+		}
+
+		wr := &wrappedWriter{w, http.StatusOK}
+		next.ServeHTTP(wr, r.WithContext(ctx))
+
+		span.SetAttributes(attribute.Int("http.response.status_code", wr.status))
+		if wr.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(wr.status))
+		}
+	})
+}
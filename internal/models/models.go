@@ -1,5 +1,10 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // @description DeviceType is a struct for device type
 type DeviceType struct {
 	// ID is an int32 for device type id
@@ -8,6 +13,8 @@ type DeviceType struct {
 	Name string `json:"name" example:"computer"`
 	// Status is a string for device type status either active or inactive
 	Status string `json:"status" example:"active"`
+	// Equipment counts the equipment of the device type, only included with counts=true
+	Equipment *EquipmentCounts `json:"equipment,omitempty"`
 }
 
 // @description Manufacturer is a struct for manufacturer
@@ -18,6 +25,16 @@ type Manufacturer struct {
 	Name string `json:"name"    example:"Apple"`
 	// Status is a string for manufacturer status either active or inactive
 	Status string `json:"status"  example:"active"`
+	// Equipment counts the equipment of the manufacturer, only included with counts=true
+	Equipment *EquipmentCounts `json:"equipment,omitempty"`
+}
+
+// @description EquipmentCounts is how much equipment of a device type or manufacturer is active and inactive
+type EquipmentCounts struct {
+	// Active is the number of active equipment
+	Active int64 `json:"active" example:"42"`
+	// Inactive is the number of inactive equipment
+	Inactive int64 `json:"inactive" example:"3"`
 }
 
 // @description Equipment is a struct for equipment
@@ -27,6 +44,246 @@ type Equipment struct {
 	ManufacturerID int32  `json:"manufacturer_id" example:"1"`       // ManufacturerID is an int32 for manufacturer id
 	SerialNumber   string `json:"serial_number" example:"SN-123456"` // SerialNumber is a string for equipment serial number
 	Status         string `json:"status" example:"active"` // Status is a string for equipment status either active or inactive
+	LifecycleState string `json:"lifecycle_state" example:"in_stock"` // LifecycleState is where the equipment is in its lifecycle, Status is derived from it
+	LocationID     *int32 `json:"location_id" example:"4"` // LocationID is the location the equipment is at, null when unknown
+	ProductModelID *int32 `json:"product_model_id" example:"2"` // ProductModelID is the product model of the equipment, null when unknown
+	PurchaseDate   *string `json:"purchase_date" example:"2024-03-18"` // PurchaseDate is the date the equipment was bought, null when unknown
+	Vendor         string `json:"vendor" example:"CDW"` // Vendor is who the equipment was bought from
+	PurchaseOrder  string `json:"purchase_order" example:"PO-10442"` // PurchaseOrder is the purchase order number the equipment was bought on
+	Cost           *json.Number `json:"cost" swaggertype:"number" example:"1299.99"` // Cost is what the equipment was bought for, null when unknown
+	WarrantyStart  *string `json:"warranty_start" example:"2024-03-18"` // WarrantyStart is the first day of the warranty, null when unknown
+	WarrantyEnd    *string `json:"warranty_end" example:"2027-03-17"` // WarrantyEnd is the last day of the warranty, null when unknown
+	BookValue      *json.Number `json:"book_value" swaggertype:"number" example:"866.66"` // BookValue is what the equipment is worth today after depreciation, null when its cost or purchase date is unknown
+	LastSeenAt     *time.Time `json:"last_seen_at" example:"2024-05-01T15:04:05Z"` // LastSeenAt is when the equipment was last scanned, null when it never was
+	LastSeenLocationID *int32 `json:"last_seen_location_id" example:"4"` // LastSeenLocationID is the location the equipment was last scanned at, null when unknown
+	CreatedAt      time.Time `json:"created_at" example:"2024-03-18T15:04:05Z"` // CreatedAt is when the equipment was added
+	Attributes     map[string]string `json:"attributes,omitempty"` // Attributes are the device type attribute values, only included by search
+	DeviceType     *DeviceType `json:"device_type,omitempty"` // DeviceType is the device type of the equipment, only included with expand=device_type
+	Manufacturer   *Manufacturer `json:"manufacturer,omitempty"` // Manufacturer is the manufacturer of the equipment, only included with expand=manufacturer
+}
+
+// @description EquipmentMatch is equipment found by a fuzzy serial number search
+type EquipmentMatch struct {
+	Equipment
+	// Distance is the number of characters inserted, deleted, changed or swapped to get from the search to the serial number
+	Distance int `json:"distance" example:"1"`
+	// Score is 1 for an exact match down towards 0 as the distance grows relative to the serial number length
+	Score float64 `json:"score" example:"0.9"`
+}
+
+// @description AttributeDefinition is an attribute a device type declares for its equipment
+type AttributeDefinition struct {
+	// ID is an int32 for attribute id
+	ID int32 `json:"id" example:"1"`
+	// DeviceTypeID is the device type declaring the attribute
+	DeviceTypeID int32 `json:"device_type_id" example:"2"`
+	// Name is the attribute name, lowercase letters, digits and underscores
+	Name string `json:"name" example:"imei"`
+	// Type is one of string, int, float, bool or enum
+	Type string `json:"type" example:"string"`
+	// Required is whether every equipment of the device type needs a value
+	Required bool `json:"required" example:"true"`
+	// Enum lists the allowed values of an enum attribute
+	Enum []string `json:"enum" example:"64GB,128GB"`
+	// Pattern is a regular expression string values have to match
+	Pattern string `json:"pattern" example:"^[0-9]{15}$"`
+}
+
+// @description ProductModel is a specific model a manufacturer makes of a device type
+type ProductModel struct {
+	// ID is an int32 for product model id
+	ID int32 `json:"id" example:"2"`
+	// ManufacturerID is the manufacturer that makes the model
+	ManufacturerID int32 `json:"manufacturer_id" example:"1"`
+	// DeviceTypeID is the device type of the model
+	DeviceTypeID int32 `json:"device_type_id" example:"1"`
+	// ModelNumber is the manufacturer's model number, unique per manufacturer
+	ModelNumber string `json:"model_number" example:"LAT-5440"`
+	// Name is a string for the marketing name of the model
+	Name string `json:"name" example:"Latitude 5440"`
+	// Specs is a free form JSON object of the model's specifications
+	Specs json.RawMessage `json:"specs" swaggertype:"object"`
+}
+
+// @description SerialNumberRule is a format serial numbers of a manufacturer and/or device type have to follow
+type SerialNumberRule struct {
+	// ID is an int32 for rule id
+	ID int32 `json:"id" example:"1"`
+	// Name is a string describing the rule
+	Name string `json:"name" example:"apple imei"`
+	// ManufacturerID limits the rule to a manufacturer, null applies it to every manufacturer
+	ManufacturerID *int32 `json:"manufacturer_id" example:"1"`
+	// DeviceTypeID limits the rule to a device type, null applies it to every device type
+	DeviceTypeID *int32 `json:"device_type_id" example:"2"`
+	// Pattern is a regular expression the whole serial number has to match
+	Pattern string `json:"pattern" example:"[0-9]{15}"`
+	// MinLength is the shortest serial number allowed, 0 is no minimum
+	MinLength int32 `json:"min_length" example:"15"`
+	// MaxLength is the longest serial number allowed, 0 is no maximum
+	MaxLength int32 `json:"max_length" example:"15"`
+	// Prefix is what the serial number has to start with
+	Prefix string `json:"prefix" example:"SN-"`
+	// Checksum is either none or luhn
+	Checksum string `json:"checksum" example:"luhn"`
+}
+
+// @description SerialNumberRuleFailure is a rule a serial number does not follow
+type SerialNumberRuleFailure struct {
+	// RuleID is the id of the failed rule, 0 for the built in length limit
+	RuleID int32 `json:"rule_id" example:"1"`
+	// Rule is the name of the failed rule
+	Rule string `json:"rule" example:"default"`
+	// Reason is why the serial number fails the rule
+	Reason string `json:"reason" example:"serial number must start with SN-"`
+}
+
+// @description SerialNumberValidation is the result of checking a serial number against the rules
+type SerialNumberValidation struct {
+	// SerialNumber is the serial number that was checked
+	SerialNumber string `json:"serial_number" example:"SN-123456"`
+	// Canonical is the form the serial number is stored and looked up with
+	Canonical string `json:"canonical" example:"SN123456"`
+	// Valid is true when the serial number follows every rule in scope
+	Valid bool `json:"valid" example:"false"`
+	// Failures is every rule the serial number does not follow
+	Failures []SerialNumberRuleFailure `json:"failures"`
+}
+
+// @description SerialNormalization is how serial numbers of a manufacturer are made canonical
+type SerialNormalization struct {
+	// ManufacturerID is the manufacturer the normalization is for
+	ManufacturerID int32 `json:"manufacturer_id" example:"1"`
+	// Trim removes leading and trailing whitespace
+	Trim bool `json:"trim" example:"true"`
+	// CaseFold upper cases letters
+	CaseFold bool `json:"case_fold" example:"true"`
+	// Separators are characters removed from anywhere in the serial number
+	Separators string `json:"separators" example:" -_./:"`
+	// Default is true when the manufacturer has no normalization of its own
+	Default bool `json:"default" example:"false"`
+}
+
+// @description ImportRowError is why a row of an equipment import was rejected
+type ImportRowError struct {
+	// Row is the line of the csv the error is on, the header is row 1
+	Row int `json:"row" example:"3"`
+	// SerialNumber is the serial number on the row
+	SerialNumber string `json:"serial_number" example:"SN-123456"`
+	// Error is why the row was rejected
+	Error string `json:"error" example:"rule default: serial number must start with SN-"`
+}
+
+// @description LifecycleTransition is a recorded move of equipment between lifecycle states
+type LifecycleTransition struct {
+	// ID is an int32 for the transition id
+	ID int32 `json:"id" example:"1"`
+	// EquipmentID is the auto_id of the equipment that moved
+	EquipmentID int32 `json:"equipment_id" example:"1"`
+	// From is the state the equipment left
+	From string `json:"from" example:"in_stock"`
+	// To is the state the equipment moved to
+	To string `json:"to" example:"deployed"`
+	// Reason is why the equipment moved
+	Reason string `json:"reason" example:"issued to new hire"`
+	// CreatedAt is when the equipment moved
+	CreatedAt time.Time `json:"created_at" example:"2024-05-01T15:04:05Z"`
+}
+
+// @description Assignee is a person or team equipment can be checked out to
+type Assignee struct {
+	// ID is an int32 for assignee id
+	ID int32 `json:"id" example:"1"`
+	// Name is a string for the person or team name
+	Name string `json:"name" example:"Jane Doe"`
+	// Kind is either person or team
+	Kind string `json:"kind" example:"person"`
+	// Email is a string for the contact email, may be empty
+	Email string `json:"email" example:"jane@example.com"`
+	// Status is a string for assignee status either active or inactive
+	Status string `json:"status" example:"active"`
+}
+
+// @description Assignment is a check out of equipment to an assignee
+type Assignment struct {
+	// ID is an int32 for assignment id
+	ID int32 `json:"id" example:"1"`
+	// EquipmentID is the auto_id of the checked out equipment
+	EquipmentID int32 `json:"equipment_id" example:"1"`
+	// AssigneeID is the id of the assignee holding the equipment
+	AssigneeID int32 `json:"assignee_id" example:"1"`
+	// CheckedOutAt is when the equipment was checked out
+	CheckedOutAt time.Time `json:"checked_out_at" example:"2024-05-01T15:04:05Z"`
+	// CheckedInAt is when the equipment was checked back in, null while it is held
+	CheckedInAt *time.Time `json:"checked_in_at" example:"2024-06-01T15:04:05Z"`
+	// Note is a string for the check out note
+	Note string `json:"note" example:"loaner while laptop is repaired"`
+	// CheckinNote is a string for the check in note
+	CheckinNote string `json:"checkin_note" example:"returned with charger"`
+}
+
+// @description Location is a site, building, room, rack or shelf equipment can be at
+type Location struct {
+	// ID is an int32 for location id
+	ID int32 `json:"id" example:"4"`
+	// ParentID is the location this one is in, null for sites
+	ParentID *int32 `json:"parent_id" example:"3"`
+	// Name is a string for location name
+	Name string `json:"name" example:"Rack A1"`
+	// Kind is one of site, building, room, rack or shelf
+	Kind string `json:"kind" example:"rack"`
+}
+
+// @description LocationCount is the number of equipment at a location
+type LocationCount struct {
+	Location
+	// Direct is the equipment at the location itself
+	Direct int64 `json:"direct" example:"3"`
+	// Total is the equipment at the location and every location below it
+	Total int64 `json:"total" example:"12"`
+}
+
+// @description LocationMove is a recorded move of equipment between locations
+type LocationMove struct {
+	// ID is an int32 for the move id
+	ID int32 `json:"id" example:"1"`
+	// EquipmentID is the auto_id of the equipment that moved
+	EquipmentID int32 `json:"equipment_id" example:"1"`
+	// From is the location the equipment left, null when it had none
+	From *int32 `json:"from" example:"3"`
+	// To is the location the equipment moved to, null when it was removed from one
+	To *int32 `json:"to" example:"4"`
+	// Reason is why the equipment moved
+	Reason string `json:"reason" example:"moved to new office"`
+	// CreatedAt is when the equipment moved
+	CreatedAt time.Time `json:"created_at" example:"2024-05-01T15:04:05Z"`
+}
+
+// @description Lifecycle is the lifecycle states and the transitions allowed between them
+type Lifecycle struct {
+	// States is every lifecycle state
+	States []string `json:"states" example:"received,in_stock,deployed,in_repair,lost,retired,disposed"`
+	// Transitions maps a state to the states equipment in it may move to
+	Transitions map[string][]string `json:"transitions"`
+}
+
+// @description HealthCheck is the result of checking a single dependency
+type HealthCheck struct {
+	// Name is the dependency that was checked
+	Name string `json:"name" example:"equipment_database"`
+	// Status is either up or down
+	Status string `json:"status" example:"up"`
+	// Latency is how long the check took
+	Latency string `json:"latency" example:"1.2ms"`
+	// Error is why the check failed, empty when the dependency is up
+	Error string `json:"error,omitempty"`
+}
+
+// @description HealthReport is a struct for the detailed health of the API
+type HealthReport struct {
+	// Status is up when every check is up, otherwise down
+	Status string `json:"status" example:"up"`
+	// Checks is the result for each dependency
+	Checks []HealthCheck `json:"checks"`
 }
 
 // Message is an interface for response message can be string, models.DeviceType, models.Manufacturer, models.Equipment
@@ -40,4 +297,306 @@ type JsonResponse struct {
 	Message Message `json:"MSG"`
 	// Action is a string for response action
     Action string `json:"Action" example:"none"`
+	// RequestID is the X-Request-ID of the request, only set on errors
+	RequestID string `json:"RequestID,omitempty" example:"4f6c1e0a9b2d4c8e8f0a1b2c3d4e5f60"`
+}
+
+// @description WarrantyExpiry is equipment whose warranty is about to expire
+type WarrantyExpiry struct {
+	Equipment
+	// DaysLeft is the number of days from today until the warranty ends
+	DaysLeft int `json:"days_left" example:"12"`
+}
+
+// @description WarrantyExpiryGroup is the equipment of one manufacturer whose warranty is about to expire
+type WarrantyExpiryGroup struct {
+	// ManufacturerID is an int32 for manufacturer id
+	ManufacturerID int32 `json:"manufacturer_id" example:"1"`
+	// Manufacturer is the name of the manufacturer
+	Manufacturer string `json:"manufacturer" example:"Dell"`
+	// Count is the number of equipment in the group
+	Count int `json:"count" example:"3"`
+	// Equipment is the equipment of the manufacturer, soonest expiring first
+	Equipment []WarrantyExpiry `json:"equipment"`
+}
+
+// @description MaintenanceTicket is a repair or maintenance of equipment
+type MaintenanceTicket struct {
+	// ID is an int32 for maintenance ticket id
+	ID int32 `json:"id" example:"1"`
+	// EquipmentID is the auto_id of the equipment being repaired
+	EquipmentID int32 `json:"equipment_id" example:"1"`
+	// Issue is what is wrong with the equipment
+	Issue string `json:"issue" example:"cracked screen"`
+	// Vendor is who is doing the repair
+	Vendor string `json:"vendor" example:"Dell ProSupport"`
+	// OpenedAt is the day the ticket was opened
+	OpenedAt string `json:"opened_at" example:"2024-05-01"`
+	// ClosedAt is the day the ticket was closed, null while it is open
+	ClosedAt *string `json:"closed_at" example:"2024-05-09"`
+	// Cost is what the repair cost, null when unknown
+	Cost *json.Number `json:"cost" swaggertype:"number" example:"149.00"`
+	// Outcome is how the ticket ended, null while it is open
+	Outcome *string `json:"outcome" enums:"repaired,replaced,unrepairable" example:"repaired"`
+	// ReturnState is the lifecycle state the equipment goes back to when it is repaired
+	ReturnState string `json:"return_state" example:"deployed"`
+	// CreatedAt is when the ticket was recorded
+	CreatedAt time.Time `json:"created_at" example:"2024-05-01T15:04:05Z"`
+}
+
+// @description MaintenanceReport is how often the equipment of a manufacturer or device type is repaired
+type MaintenanceReport struct {
+	// ID is the id of the manufacturer or device type
+	ID int32 `json:"id" example:"1"`
+	// Name is the name of the manufacturer or device type
+	Name string `json:"name" example:"Dell"`
+	// Equipment is the number of equipment of the manufacturer or device type
+	Equipment int64 `json:"equipment" example:"40"`
+	// Tickets is the number of tickets opened in the report period
+	Tickets int `json:"tickets" example:"6"`
+	// OpenTickets is the number of those tickets still open
+	OpenTickets int `json:"open_tickets" example:"1"`
+	// RepairedEquipment is the number of different equipment the tickets are for
+	RepairedEquipment int `json:"repaired_equipment" example:"5"`
+	// TicketsPerEquipment is Tickets divided by Equipment
+	TicketsPerEquipment float64 `json:"tickets_per_equipment" example:"0.15"`
+	// TotalCost is the sum of the cost of the tickets
+	TotalCost json.Number `json:"total_cost" swaggertype:"number" example:"894.00"`
+	// AverageRepairDays is the average number of days closed tickets were open, null when none are closed
+	AverageRepairDays *float64 `json:"average_repair_days" example:"4.5"`
+}
+
+// @description DepreciationSchedule is how equipment of a device type loses value
+type DepreciationSchedule struct {
+	// DeviceTypeID is an int32 for device type id
+	DeviceTypeID int32 `json:"device_type_id" example:"1"`
+	// Method is how the value goes down, straight_line loses the same amount every month, declining_balance loses a share of the remaining value every year and none keeps the cost
+	Method string `json:"method" enums:"none,straight_line,declining_balance" example:"straight_line"`
+	// LifeMonths is how long the equipment is used, after that it is worth its salvage value
+	LifeMonths int32 `json:"life_months" example:"36"`
+	// SalvagePercent is the share of the cost the equipment is worth at the end of its life
+	SalvagePercent int32 `json:"salvage_percent" example:"10"`
+	// RatePercent is the share of the remaining value lost every year by declining_balance
+	RatePercent int32 `json:"rate_percent" example:"40"`
+	// Default is true when the device type has no schedule and its equipment does not depreciate
+	Default bool `json:"default" example:"false"`
+}
+
+// @description ValuationReport is the value of the equipment of a device type, manufacturer or location
+type ValuationReport struct {
+	// ID is the id of the device type, manufacturer or location, null for equipment without a location
+	ID *int32 `json:"id" example:"1"`
+	// Name is the name of the device type, manufacturer or location
+	Name string `json:"name" example:"laptop"`
+	// Equipment is the number of equipment valued
+	Equipment int `json:"equipment" example:"40"`
+	// Unpriced is the number of equipment bought by the report date without a cost, they are not valued
+	Unpriced int `json:"unpriced" example:"2"`
+	// Cost is what the valued equipment was bought for
+	Cost json.Number `json:"cost" swaggertype:"number" example:"52000.00"`
+	// BookValue is what the valued equipment is worth on the report date
+	BookValue json.Number `json:"book_value" swaggertype:"number" example:"31200.00"`
+	// Depreciation is Cost minus BookValue
+	Depreciation json.Number `json:"depreciation" swaggertype:"number" example:"20800.00"`
+}
+
+// @description Tag is a free-form label grouping equipment
+type Tag struct {
+	// ID is an int32 for tag id
+	ID int32 `json:"id" example:"1"`
+	// Name is the tag, lower case letters, digits, dots, dashes and underscores
+	Name string `json:"name" example:"loaner-pool"`
+}
+
+// @description TagUsage is a tag with the number of equipment carrying it
+type TagUsage struct {
+	Tag
+	// Count is the number of equipment with the tag
+	Count int64 `json:"count" example:"12"`
+}
+
+// @description Attachment is a file stored with equipment or a manufacturer, like an invoice or a warranty certificate
+type Attachment struct {
+	// ID is an int32 for attachment id
+	ID int32 `json:"id" example:"1"`
+	// EquipmentID is the auto_id of the equipment the file belongs to, null for manufacturer files
+	EquipmentID *int32 `json:"equipment_id" example:"1"`
+	// ManufacturerID is the id of the manufacturer the file belongs to, null for equipment files
+	ManufacturerID *int32 `json:"manufacturer_id" example:"2"`
+	// Kind is what the file is
+	Kind string `json:"kind" enums:"invoice,photo,warranty,other" example:"invoice"`
+	// Filename is the name the file was uploaded with
+	Filename string `json:"filename" example:"invoice-2024-118.pdf"`
+	// ContentType is the MIME type sniffed from the file
+	ContentType string `json:"content_type" example:"application/pdf"`
+	// Size is the size of the file in bytes
+	Size int64 `json:"size" example:"48213"`
+	// Sha256 is the hex encoded SHA-256 checksum of the file
+	Sha256 string `json:"sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	// CreatedAt is when the file was uploaded
+	CreatedAt time.Time `json:"created_at" example:"2024-05-01T15:04:05Z"`
+}
+
+// @description ScanResult is the equipment a scanned code resolved to
+type ScanResult struct {
+	// Code is the scanned code once scanner prefixes, whitespace and control characters are removed
+	Code string `json:"code" example:"SN-123456"`
+	// MatchedBy is how the code was resolved to the equipment
+	MatchedBy string `json:"matched_by" enums:"url,serial_number,auto_id" example:"serial_number"`
+	// Action is the action performed on the equipment, empty when none was asked for
+	Action       string       `json:"action" enums:"checkin,seen,status" example:"seen"`
+	Equipment    Equipment    `json:"equipment"`
+	DeviceType   DeviceType   `json:"device_type"`
+	Manufacturer Manufacturer `json:"manufacturer"`
+}
+
+// @description CountSession is a physical inventory count of a location, a device type or both
+type CountSession struct {
+	// ID is an int32 for count session id
+	ID int32 `json:"id" example:"1"`
+	// Name describes the count
+	Name string `json:"name" example:"HQ spring audit"`
+	// LocationID is the location being counted, sub locations included, null to count every location
+	LocationID *int32 `json:"location_id" example:"4"`
+	// DeviceTypeID is the device type being counted, null to count every device type
+	DeviceTypeID *int32 `json:"device_type_id" example:"1"`
+	// Status is open while codes are being scanned
+	Status string `json:"status" enums:"open,closed" example:"open"`
+	// Scans is the number of distinct codes scanned
+	Scans int64 `json:"scans" example:"112"`
+	// CreatedAt is when the count was opened
+	CreatedAt time.Time `json:"created_at" example:"2024-05-01T15:04:05Z"`
+	// ClosedAt is when the count was closed, null while it is open
+	ClosedAt *time.Time `json:"closed_at" example:"2024-05-02T11:00:00Z"`
+	// AppliedAt is when the results were applied to the equipment, null when they were not
+	AppliedAt *time.Time `json:"applied_at" example:"2024-05-02T11:00:00Z"`
+}
+
+// @description CountScan is a code scanned during a count
+type CountScan struct {
+	// ID is an int32 for count scan id
+	ID int32 `json:"id" example:"1"`
+	// SessionID is the count the code was scanned in
+	SessionID int32 `json:"session_id" example:"1"`
+	// Code is the scanned code
+	Code string `json:"code" example:"SN-123456"`
+	// EquipmentID is the equipment the code resolved to, null when it matches no equipment
+	EquipmentID *int32 `json:"equipment_id" example:"1"`
+	// LocationID is where the code was scanned, null when the count has no location and none was given
+	LocationID *int32 `json:"location_id" example:"4"`
+	// ScannedAt is when the code was last scanned
+	ScannedAt time.Time `json:"scanned_at" example:"2024-05-01T15:04:05Z"`
+}
+
+// @description CountItem is something scanned that the count did not expect where it was found
+type CountItem struct {
+	// Code is the scanned code
+	Code string `json:"code" example:"SN-123456"`
+	// Equipment is what the code resolved to, null when it matches no equipment
+	Equipment *Equipment `json:"equipment"`
+	// ScannedLocationID is where the code was scanned, null when unknown
+	ScannedLocationID *int32 `json:"scanned_location_id" example:"5"`
+	// Reason is why the item was not expected
+	Reason string `json:"reason" enums:"unknown_code,other_device_type,lifecycle_state,other_location" example:"other_location"`
+}
+
+// @description CountReconciliation compares what a count found with what was expected
+type CountReconciliation struct {
+	Session CountSession `json:"session"`
+	// Expected is the number of equipment the count expected to find
+	Expected int `json:"expected" example:"120"`
+	// Found is the number of equipment scanned where they were expected
+	Found int `json:"found" example:"110"`
+	// Missing is expected equipment that was not scanned
+	Missing []Equipment `json:"missing"`
+	// Unexpected is scanned codes that match no equipment or equipment the count doesn't cover
+	Unexpected []CountItem `json:"unexpected"`
+	// Elsewhere is equipment scanned somewhere other than its recorded location
+	Elsewhere []CountItem `json:"elsewhere"`
+}
+
+// @description CountApplyResult is what applying a count changed
+type CountApplyResult struct {
+	// MarkedLost is the auto_ids of missing equipment moved to lost
+	MarkedLost []int32 `json:"marked_lost" example:"3,9"`
+	// Moved is the auto_ids of equipment moved to where they were scanned
+	Moved []int32 `json:"moved" example:"12"`
+	// Skipped is equipment that could not be changed
+	Skipped []CountSkip `json:"skipped"`
+}
+
+// @description CountSkip is equipment a count could not change
+type CountSkip struct {
+	// EquipmentID is the auto_id of the equipment
+	EquipmentID int32 `json:"equipment_id" example:"7"`
+	// Reason is why it was skipped
+	Reason string `json:"reason" example:"illegal lifecycle transition: in_repair can only move to [in_stock deployed retired]"`
+}
+
+// @description InventoryStats are equipment totals for a dashboard
+type InventoryStats struct {
+	// Total is the number of equipment
+	Total int64 `json:"total" example:"120"`
+	// ByStatus totals equipment by status
+	ByStatus []StatusCount `json:"by_status"`
+	// ByDeviceType totals equipment by device type, device types without equipment are left out
+	ByDeviceType []GroupCount `json:"by_device_type"`
+	// ByManufacturer totals equipment by manufacturer, manufacturers without equipment are left out
+	ByManufacturer []GroupCount `json:"by_manufacturer"`
+	// ByDeviceTypeManufacturer totals equipment by every device type and manufacturer pair that has equipment
+	ByDeviceTypeManufacturer []DeviceManufacturerCount `json:"by_device_type_manufacturer"`
+	// Interval is the length of the growth periods, day, week or month
+	Interval string `json:"interval" example:"month"`
+	// Growth is the equipment added in every period from from to to, empty periods included
+	Growth []GrowthPeriod `json:"growth"`
+}
+
+// @description StatusCount is the number of equipment with a status
+type StatusCount struct {
+	// Status is active or inactive
+	Status string `json:"status" example:"active"`
+	// Total is the number of equipment with the status
+	Total int64 `json:"total" example:"112"`
+}
+
+// @description GroupCount is the number of equipment of a device type or manufacturer
+type GroupCount struct {
+	// ID is the device type or manufacturer id
+	ID int32 `json:"id" example:"1"`
+	// Name is the device type or manufacturer name
+	Name string `json:"name" example:"computer"`
+	// Active is the number of active equipment
+	Active int64 `json:"active" example:"40"`
+	// Inactive is the number of inactive equipment
+	Inactive int64 `json:"inactive" example:"2"`
+	// Total is the number of equipment
+	Total int64 `json:"total" example:"42"`
+}
+
+// @description DeviceManufacturerCount is the number of equipment of a device type made by a manufacturer
+type DeviceManufacturerCount struct {
+	// DeviceTypeID is the device type id
+	DeviceTypeID int32 `json:"device_type_id" example:"1"`
+	// DeviceType is the device type name
+	DeviceType string `json:"device_type" example:"computer"`
+	// ManufacturerID is the manufacturer id
+	ManufacturerID int32 `json:"manufacturer_id" example:"1"`
+	// Manufacturer is the manufacturer name
+	Manufacturer string `json:"manufacturer" example:"Apple"`
+	// Active is the number of active equipment
+	Active int64 `json:"active" example:"20"`
+	// Inactive is the number of inactive equipment
+	Inactive int64 `json:"inactive" example:"1"`
+	// Total is the number of equipment
+	Total int64 `json:"total" example:"21"`
+}
+
+// @description GrowthPeriod is the equipment added in a period
+type GrowthPeriod struct {
+	// Start is the first day of the period
+	Start string `json:"start" example:"2024-03-01"`
+	// Added is the number of equipment added in the period
+	Added int64 `json:"added" example:"8"`
+	// Total is the number of equipment added up to the end of the period that still exists
+	Total int64 `json:"total" example:"120"`
 }
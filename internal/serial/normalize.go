@@ -0,0 +1,91 @@
+package serial
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// MaxSeparators is the size of serial_normalizations.separators
+const MaxSeparators = 16
+
+// DefaultNormalizer is how serial numbers of manufacturers without their own
+// normalization are made canonical, so "sn-123 456" and "SN-123456 " both become "SN123456"
+var DefaultNormalizer = Normalizer{Trim: true, CaseFold: true, Separators: " -_./:"}
+
+// Normalizer turns the serial number an operator typed into the canonical form
+// lookups and uniqueness checks compare
+type Normalizer struct {
+	// Trim removes leading and trailing whitespace
+	Trim bool
+	// CaseFold upper cases letters
+	CaseFold bool
+	// Separators are characters removed from anywhere in the serial number
+	Separators string
+}
+
+// Check returns an error when n can't be used
+func (n Normalizer) Check() error {
+	if len(n.Separators) > MaxSeparators {
+		return fmt.Errorf("separators cannot be more than %d characters", MaxSeparators)
+	}
+	for _, c := range n.Separators {
+		if c > unicode.MaxASCII || (!unicode.IsPunct(c) && !unicode.IsSpace(c) && !unicode.IsSymbol(c)) {
+			return fmt.Errorf("separator %q must be ascii punctuation or whitespace", c)
+		}
+	}
+	return nil
+}
+
+// Canonical returns the canonical form of sn
+func (n Normalizer) Canonical(sn string) string {
+	if n.Trim {
+		sn = strings.TrimSpace(sn)
+	}
+	if n.Separators != "" {
+		sn = strings.Map(func(c rune) rune {
+			if strings.ContainsRune(n.Separators, c) {
+				return -1
+			}
+			return c
+		}, sn)
+	}
+	if n.CaseFold {
+		sn = strings.ToUpper(sn)
+	}
+	return sn
+}
+
+// Normalizers is the normalization of every manufacturer
+type Normalizers struct {
+	Default Normalizer
+	// Manufacturers holds the manufacturers that don't use Default
+	Manufacturers map[int32]Normalizer
+}
+
+// For returns the normalizer of a manufacturer
+func (ns Normalizers) For(manufacturerID int32) Normalizer {
+	if n, ok := ns.Manufacturers[manufacturerID]; ok {
+		return n
+	}
+	return ns.Default
+}
+
+// Candidates returns every canonical form sn has under any manufacturer's normalization,
+// for lookups that don't know the manufacturer
+func (ns Normalizers) Candidates(sn string) []string {
+	out := []string{ns.Default.Canonical(sn)}
+	for _, n := range ns.Manufacturers {
+		c := n.Canonical(sn)
+		if !slices.Contains(out, c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Matches reports whether sn is the serial number stored as canonical for a manufacturer
+func (ns Normalizers) Matches(sn string, manufacturerID int32, canonical string) bool {
+	return ns.For(manufacturerID).Canonical(sn) == canonical
+}
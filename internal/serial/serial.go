@@ -0,0 +1,182 @@
+package serial
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxLength is the size of serial_numbers.serial_number, no serial number can be longer
+const MaxLength = 68
+
+// Checksum is a check digit scheme the last character of a serial number has to satisfy,
+// it is computed over the serial number after the rule's prefix
+type Checksum string
+
+const (
+	None Checksum = "none"
+	// Luhn is the mod 10 check digit used by IMEIs
+	Luhn Checksum = "luhn"
+)
+
+// Rule is a format serial numbers have to follow, scoped to a manufacturer, a device
+// type, both or neither. Only the most specific rules in scope are checked, see Validate
+type Rule struct {
+	ID   int32
+	Name string
+	// ManufacturerID limits the rule to a manufacturer, nil applies it to every manufacturer
+	ManufacturerID *int32
+	// DeviceTypeID limits the rule to a device type, nil applies it to every device type
+	DeviceTypeID *int32
+	// Pattern is a regular expression the whole serial number has to match, empty matches anything
+	Pattern string
+	// MinLength and MaxLength bound the length of the serial number, 0 is no bound
+	MinLength int
+	MaxLength int
+	// Prefix is what the serial number has to start with, empty allows anything
+	Prefix   string
+	Checksum Checksum
+}
+
+// Failure is a rule a serial number does not follow and why
+type Failure struct {
+	RuleID int32
+	Rule   string
+	Reason string
+}
+
+// Check returns an error when r can't be used to validate serial numbers
+func (r Rule) Check() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+	if r.MinLength < 0 || r.MaxLength < 0 {
+		return fmt.Errorf("rule %s: lengths cannot be negative", r.Name)
+	}
+	if r.MaxLength > MaxLength {
+		return fmt.Errorf("rule %s: max length cannot be more than %d", r.Name, MaxLength)
+	}
+	if r.MaxLength > 0 && r.MinLength > r.MaxLength {
+		return fmt.Errorf("rule %s: min length %d is more than max length %d", r.Name, r.MinLength, r.MaxLength)
+	}
+	if len(r.Prefix) > MaxLength {
+		return fmt.Errorf("rule %s: prefix cannot be longer than %d characters", r.Name, MaxLength)
+	}
+	switch r.Checksum {
+	case None, Luhn:
+	default:
+		return fmt.Errorf("rule %s: checksum %q must be none or luhn", r.Name, r.Checksum)
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("rule %s: invalid pattern: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// Applies reports whether r is in scope for equipment of the manufacturer and device type
+func (r Rule) Applies(manufacturerID, deviceTypeID int32) bool {
+	if r.ManufacturerID != nil && *r.ManufacturerID != manufacturerID {
+		return false
+	}
+	if r.DeviceTypeID != nil && *r.DeviceTypeID != deviceTypeID {
+		return false
+	}
+	return true
+}
+
+// specificity ranks how narrowly r is scoped, a rule for a manufacturer and device type
+// outranks one for the manufacturer, which outranks one for the device type, which
+// outranks a rule for everything
+func (r Rule) specificity() int {
+	n := 0
+	if r.ManufacturerID != nil {
+		n += 2
+	}
+	if r.DeviceTypeID != nil {
+		n++
+	}
+	return n
+}
+
+// Validate returns why sn does not follow r, or nil when it does
+func (r Rule) Validate(sn string) error {
+	if r.Prefix != "" && !strings.HasPrefix(sn, r.Prefix) {
+		return fmt.Errorf("serial number must start with %s", r.Prefix)
+	}
+	if r.MinLength > 0 && len(sn) < r.MinLength {
+		return fmt.Errorf("serial number must be at least %d characters", r.MinLength)
+	}
+	if r.MaxLength > 0 && len(sn) > r.MaxLength {
+		return fmt.Errorf("serial number cannot be longer than %d characters", r.MaxLength)
+	}
+	if r.Pattern != "" {
+		// NOTE: anchored so a pattern like [0-9]{15} can't match part of a longer serial
+		re, err := regexp.Compile(`^(?:` + r.Pattern + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		if !re.MatchString(sn) {
+			return fmt.Errorf("serial number must match %s", r.Pattern)
+		}
+	}
+	// NOTE: the checksum covers what comes after the prefix, so SN-356938035643808 passes
+	// a luhn rule with prefix SN-
+	if r.Checksum == Luhn && !ValidLuhn(strings.TrimPrefix(sn, r.Prefix)) {
+		return fmt.Errorf("serial number fails the luhn checksum")
+	}
+	return nil
+}
+
+// Validate checks sn against the most specific rules in scope for the manufacturer and
+// device type and returns the ones it fails, none means sn is valid. Rules in scope that
+// are less specific are overridden, so a manufacturer's IMEI rule replaces the global
+// default instead of being checked on top of it
+func Validate(rules []Rule, sn string, manufacturerID, deviceTypeID int32) []Failure {
+	failures := []Failure{}
+	if len(sn) > MaxLength {
+		failures = append(failures, Failure{Rule: "max length", Reason: fmt.Sprintf("serial number cannot be longer than %d characters", MaxLength)})
+	}
+	best := -1
+	for _, r := range rules {
+		if r.Applies(manufacturerID, deviceTypeID) {
+			best = max(best, r.specificity())
+		}
+	}
+	for _, r := range rules {
+		if !r.Applies(manufacturerID, deviceTypeID) || r.specificity() != best {
+			continue
+		}
+		if err := r.Validate(sn); err != nil {
+			failures = append(failures, Failure{RuleID: r.ID, Rule: r.Name, Reason: err.Error()})
+		}
+	}
+	return failures
+}
+
+// ValidLuhn reports whether s is all digits and its last digit is the Luhn check digit
+// of the ones before it
+func ValidLuhn(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
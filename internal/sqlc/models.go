@@ -5,10 +5,270 @@
 package sqlc
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"time"
 )
 
+type AssigneesKind string
+
+const (
+	AssigneesKindPerson AssigneesKind = "person"
+	AssigneesKindTeam   AssigneesKind = "team"
+)
+
+func (e *AssigneesKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AssigneesKind(s)
+	case string:
+		*e = AssigneesKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AssigneesKind: %T", src)
+	}
+	return nil
+}
+
+type NullAssigneesKind struct {
+	AssigneesKind AssigneesKind
+	Valid         bool // Valid is true if AssigneesKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAssigneesKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.AssigneesKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AssigneesKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAssigneesKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AssigneesKind), nil
+}
+
+type AssigneesStatus string
+
+const (
+	AssigneesStatusActive   AssigneesStatus = "active"
+	AssigneesStatusInactive AssigneesStatus = "inactive"
+)
+
+func (e *AssigneesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AssigneesStatus(s)
+	case string:
+		*e = AssigneesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AssigneesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAssigneesStatus struct {
+	AssigneesStatus AssigneesStatus
+	Valid           bool // Valid is true if AssigneesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAssigneesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AssigneesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AssigneesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAssigneesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AssigneesStatus), nil
+}
+
+type AttachmentsKind string
+
+const (
+	AttachmentsKindInvoice  AttachmentsKind = "invoice"
+	AttachmentsKindPhoto    AttachmentsKind = "photo"
+	AttachmentsKindWarranty AttachmentsKind = "warranty"
+	AttachmentsKindOther    AttachmentsKind = "other"
+)
+
+func (e *AttachmentsKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AttachmentsKind(s)
+	case string:
+		*e = AttachmentsKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AttachmentsKind: %T", src)
+	}
+	return nil
+}
+
+type NullAttachmentsKind struct {
+	AttachmentsKind AttachmentsKind
+	Valid           bool // Valid is true if AttachmentsKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAttachmentsKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.AttachmentsKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AttachmentsKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAttachmentsKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AttachmentsKind), nil
+}
+
+type CountSessionsStatus string
+
+const (
+	CountSessionsStatusOpen   CountSessionsStatus = "open"
+	CountSessionsStatusClosed CountSessionsStatus = "closed"
+)
+
+func (e *CountSessionsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CountSessionsStatus(s)
+	case string:
+		*e = CountSessionsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CountSessionsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullCountSessionsStatus struct {
+	CountSessionsStatus CountSessionsStatus
+	Valid               bool // Valid is true if CountSessionsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCountSessionsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.CountSessionsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CountSessionsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCountSessionsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CountSessionsStatus), nil
+}
+
+type DepreciationSchedulesMethod string
+
+const (
+	DepreciationSchedulesMethodStraightLine     DepreciationSchedulesMethod = "straight_line"
+	DepreciationSchedulesMethodDecliningBalance DepreciationSchedulesMethod = "declining_balance"
+)
+
+func (e *DepreciationSchedulesMethod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DepreciationSchedulesMethod(s)
+	case string:
+		*e = DepreciationSchedulesMethod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DepreciationSchedulesMethod: %T", src)
+	}
+	return nil
+}
+
+type NullDepreciationSchedulesMethod struct {
+	DepreciationSchedulesMethod DepreciationSchedulesMethod
+	Valid                       bool // Valid is true if DepreciationSchedulesMethod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDepreciationSchedulesMethod) Scan(value interface{}) error {
+	if value == nil {
+		ns.DepreciationSchedulesMethod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DepreciationSchedulesMethod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDepreciationSchedulesMethod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DepreciationSchedulesMethod), nil
+}
+
+type DeviceTypeAttributesType string
+
+const (
+	DeviceTypeAttributesTypeString DeviceTypeAttributesType = "string"
+	DeviceTypeAttributesTypeInt    DeviceTypeAttributesType = "int"
+	DeviceTypeAttributesTypeFloat  DeviceTypeAttributesType = "float"
+	DeviceTypeAttributesTypeBool   DeviceTypeAttributesType = "bool"
+	DeviceTypeAttributesTypeEnum   DeviceTypeAttributesType = "enum"
+)
+
+func (e *DeviceTypeAttributesType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DeviceTypeAttributesType(s)
+	case string:
+		*e = DeviceTypeAttributesType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DeviceTypeAttributesType: %T", src)
+	}
+	return nil
+}
+
+type NullDeviceTypeAttributesType struct {
+	DeviceTypeAttributesType DeviceTypeAttributesType
+	Valid                    bool // Valid is true if DeviceTypeAttributesType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDeviceTypeAttributesType) Scan(value interface{}) error {
+	if value == nil {
+		ns.DeviceTypeAttributesType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DeviceTypeAttributesType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDeviceTypeAttributesType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DeviceTypeAttributesType), nil
+}
+
 type DeviceTypeStatus string
 
 const (
@@ -51,6 +311,94 @@ func (ns NullDeviceTypeStatus) Value() (driver.Value, error) {
 	return string(ns.DeviceTypeStatus), nil
 }
 
+type LocationsKind string
+
+const (
+	LocationsKindSite     LocationsKind = "site"
+	LocationsKindBuilding LocationsKind = "building"
+	LocationsKindRoom     LocationsKind = "room"
+	LocationsKindRack     LocationsKind = "rack"
+	LocationsKindShelf    LocationsKind = "shelf"
+)
+
+func (e *LocationsKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LocationsKind(s)
+	case string:
+		*e = LocationsKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LocationsKind: %T", src)
+	}
+	return nil
+}
+
+type NullLocationsKind struct {
+	LocationsKind LocationsKind
+	Valid         bool // Valid is true if LocationsKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLocationsKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.LocationsKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LocationsKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLocationsKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LocationsKind), nil
+}
+
+type MaintenanceTicketsOutcome string
+
+const (
+	MaintenanceTicketsOutcomeRepaired     MaintenanceTicketsOutcome = "repaired"
+	MaintenanceTicketsOutcomeReplaced     MaintenanceTicketsOutcome = "replaced"
+	MaintenanceTicketsOutcomeUnrepairable MaintenanceTicketsOutcome = "unrepairable"
+)
+
+func (e *MaintenanceTicketsOutcome) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MaintenanceTicketsOutcome(s)
+	case string:
+		*e = MaintenanceTicketsOutcome(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MaintenanceTicketsOutcome: %T", src)
+	}
+	return nil
+}
+
+type NullMaintenanceTicketsOutcome struct {
+	MaintenanceTicketsOutcome MaintenanceTicketsOutcome
+	Valid                     bool // Valid is true if MaintenanceTicketsOutcome is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMaintenanceTicketsOutcome) Scan(value interface{}) error {
+	if value == nil {
+		ns.MaintenanceTicketsOutcome, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MaintenanceTicketsOutcome.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMaintenanceTicketsOutcome) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MaintenanceTicketsOutcome), nil
+}
+
 type ManufacturerStatus string
 
 const (
@@ -93,6 +441,95 @@ func (ns NullManufacturerStatus) Value() (driver.Value, error) {
 	return string(ns.ManufacturerStatus), nil
 }
 
+type SerialNumberRulesChecksum string
+
+const (
+	SerialNumberRulesChecksumNone SerialNumberRulesChecksum = "none"
+	SerialNumberRulesChecksumLuhn SerialNumberRulesChecksum = "luhn"
+)
+
+func (e *SerialNumberRulesChecksum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SerialNumberRulesChecksum(s)
+	case string:
+		*e = SerialNumberRulesChecksum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SerialNumberRulesChecksum: %T", src)
+	}
+	return nil
+}
+
+type NullSerialNumberRulesChecksum struct {
+	SerialNumberRulesChecksum SerialNumberRulesChecksum
+	Valid                     bool // Valid is true if SerialNumberRulesChecksum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSerialNumberRulesChecksum) Scan(value interface{}) error {
+	if value == nil {
+		ns.SerialNumberRulesChecksum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SerialNumberRulesChecksum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSerialNumberRulesChecksum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SerialNumberRulesChecksum), nil
+}
+
+type SerialNumbersLifecycleState string
+
+const (
+	SerialNumbersLifecycleStateReceived SerialNumbersLifecycleState = "received"
+	SerialNumbersLifecycleStateInStock  SerialNumbersLifecycleState = "in_stock"
+	SerialNumbersLifecycleStateDeployed SerialNumbersLifecycleState = "deployed"
+	SerialNumbersLifecycleStateInRepair SerialNumbersLifecycleState = "in_repair"
+	SerialNumbersLifecycleStateLost     SerialNumbersLifecycleState = "lost"
+	SerialNumbersLifecycleStateRetired  SerialNumbersLifecycleState = "retired"
+	SerialNumbersLifecycleStateDisposed SerialNumbersLifecycleState = "disposed"
+)
+
+func (e *SerialNumbersLifecycleState) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SerialNumbersLifecycleState(s)
+	case string:
+		*e = SerialNumbersLifecycleState(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SerialNumbersLifecycleState: %T", src)
+	}
+	return nil
+}
+
+type NullSerialNumbersLifecycleState struct {
+	SerialNumbersLifecycleState SerialNumbersLifecycleState
+	Valid                       bool // Valid is true if SerialNumbersLifecycleState is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSerialNumbersLifecycleState) Scan(value interface{}) error {
+	if value == nil {
+		ns.SerialNumbersLifecycleState, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SerialNumbersLifecycleState.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSerialNumbersLifecycleState) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SerialNumbersLifecycleState), nil
+}
+
 type SerialNumbersStatus string
 
 const (
@@ -135,22 +572,186 @@ func (ns NullSerialNumbersStatus) Value() (driver.Value, error) {
 	return string(ns.SerialNumbersStatus), nil
 }
 
+type Assignee struct {
+	ID     int32
+	Name   string
+	Kind   AssigneesKind
+	Email  string
+	Status AssigneesStatus
+}
+
+type Attachment struct {
+	ID             int32
+	EquipmentID    sql.NullInt32
+	ManufacturerID sql.NullInt32
+	Kind           AttachmentsKind
+	Filename       string
+	ContentType    string
+	Size           int64
+	Sha256         string
+	StorageKey     string
+	CreatedAt      time.Time
+}
+
+type CountScan struct {
+	ID          int32
+	SessionID   int32
+	Code        string
+	EquipmentID sql.NullInt32
+	LocationID  sql.NullInt32
+	ScannedAt   time.Time
+}
+
+type CountSession struct {
+	ID           int32
+	Name         string
+	LocationID   sql.NullInt32
+	DeviceTypeID sql.NullInt32
+	Status       CountSessionsStatus
+	CreatedAt    time.Time
+	ClosedAt     sql.NullTime
+	AppliedAt    sql.NullTime
+}
+
+type DepreciationSchedule struct {
+	DeviceTypeID   int32
+	Method         DepreciationSchedulesMethod
+	LifeMonths     int32
+	SalvagePercent int32
+	RatePercent    int32
+}
+
 type DeviceType struct {
 	ID     int32
 	Name   string
 	Status DeviceTypeStatus
 }
 
+type DeviceTypeAttribute struct {
+	ID           int32
+	DeviceTypeID int32
+	Name         string
+	Type         DeviceTypeAttributesType
+	Required     bool
+	EnumValues   json.RawMessage
+	Pattern      string
+}
+
+type EquipmentAssignment struct {
+	ID           int32
+	EquipmentID  int32
+	AssigneeID   int32
+	CheckedOutAt time.Time
+	CheckedInAt  sql.NullTime
+	Note         string
+	CheckinNote  string
+}
+
+type EquipmentAttribute struct {
+	EquipmentID int32
+	AttributeID int32
+	Value       string
+}
+
+type EquipmentTag struct {
+	EquipmentID int32
+	TagID       int32
+}
+
+type LifecycleTransition struct {
+	ID          int32
+	EquipmentID int32
+	FromState   string
+	ToState     string
+	Reason      string
+	CreatedAt   time.Time
+}
+
+type Location struct {
+	ID       int32
+	ParentID sql.NullInt32
+	Name     string
+	Kind     LocationsKind
+}
+
+type LocationMove struct {
+	ID             int32
+	EquipmentID    int32
+	FromLocationID sql.NullInt32
+	ToLocationID   sql.NullInt32
+	Reason         string
+	CreatedAt      time.Time
+}
+
+type MaintenanceTicket struct {
+	ID          int32
+	EquipmentID int32
+	Issue       string
+	Vendor      string
+	OpenedAt    time.Time
+	ClosedAt    sql.NullTime
+	Cost        sql.NullString
+	Outcome     NullMaintenanceTicketsOutcome
+	ReturnState string
+	CreatedAt   time.Time
+}
+
 type Manufacturer struct {
 	ID     int32
 	Name   string
 	Status ManufacturerStatus
 }
 
-type SerialNumber struct {
-	AutoID         int32
+type ProductModel struct {
+	ID             int32
+	ManufacturerID int32
 	DeviceTypeID   int32
+	ModelNumber    string
+	Name           string
+	Specs          json.RawMessage
+}
+
+type SerialNormalization struct {
 	ManufacturerID int32
-	SerialNumber   string
-	Status         SerialNumbersStatus
+	Trim           bool
+	CaseFold       bool
+	Separators     string
+}
+
+type SerialNumber struct {
+	AutoID             int32
+	DeviceTypeID       int32
+	ManufacturerID     int32
+	SerialNumber       string
+	Status             SerialNumbersStatus
+	LifecycleState     SerialNumbersLifecycleState
+	LocationID         sql.NullInt32
+	ProductModelID     sql.NullInt32
+	SerialCanonical    string
+	PurchaseDate       sql.NullTime
+	Vendor             string
+	PurchaseOrder      string
+	Cost               sql.NullString
+	WarrantyStart      sql.NullTime
+	WarrantyEnd        sql.NullTime
+	LastSeenAt         sql.NullTime
+	LastSeenLocationID sql.NullInt32
+	CreatedAt          time.Time
+}
+
+type SerialNumberRule struct {
+	ID             int32
+	Name           string
+	ManufacturerID sql.NullInt32
+	DeviceTypeID   sql.NullInt32
+	Pattern        string
+	MinLength      int32
+	MaxLength      int32
+	Prefix         string
+	Checksum       SerialNumberRulesChecksum
+}
+
+type Tag struct {
+	ID   int32
+	Name string
 }
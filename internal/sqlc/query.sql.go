@@ -7,82 +7,3039 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
 )
 
-const createDeviceType = `-- name: CreateDeviceType :exec
-INSERT INTO device_type (name) VALUES (?)
+const addEquipmentTag = `-- name: AddEquipmentTag :exec
+INSERT INTO equipment_tags (equipment_id, tag_id) VALUES (?, ?)
+ON DUPLICATE KEY UPDATE tag_id = tag_id
 `
 
-func (q *Queries) CreateDeviceType(ctx context.Context, name string) error {
-	_, err := q.db.ExecContext(ctx, createDeviceType, name)
+type AddEquipmentTagParams struct {
+	EquipmentID int32
+	TagID       int32
+}
+
+func (q *Queries) AddEquipmentTag(ctx context.Context, arg AddEquipmentTagParams) error {
+	_, err := q.db.ExecContext(ctx, addEquipmentTag, arg.EquipmentID, arg.TagID)
 	return err
 }
 
-const createEquipment = `-- name: CreateEquipment :exec
-INSERT INTO serial_numbers (device_type_id, manufacturer_id, serial_number) VALUES (?, ?, ?)
+const applyCountSession = `-- name: ApplyCountSession :exec
+UPDATE count_sessions SET status = 'closed', closed_at = CURRENT_TIMESTAMP, applied_at = CURRENT_TIMESTAMP
+WHERE id = ?
 `
 
-type CreateEquipmentParams struct {
+func (q *Queries) ApplyCountSession(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, applyCountSession, id)
+	return err
+}
+
+const checkInAssignment = `-- name: CheckInAssignment :exec
+UPDATE equipment_assignments SET checked_in_at = CURRENT_TIMESTAMP, checkin_note = ?
+WHERE id = ?
+`
+
+type CheckInAssignmentParams struct {
+	CheckinNote string
+	ID          int32
+}
+
+func (q *Queries) CheckInAssignment(ctx context.Context, arg CheckInAssignmentParams) error {
+	_, err := q.db.ExecContext(ctx, checkInAssignment, arg.CheckinNote, arg.ID)
+	return err
+}
+
+const closeCountSession = `-- name: CloseCountSession :exec
+UPDATE count_sessions SET status = 'closed', closed_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+func (q *Queries) CloseCountSession(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, closeCountSession, id)
+	return err
+}
+
+const closeMaintenanceTicket = `-- name: CloseMaintenanceTicket :exec
+UPDATE maintenance_tickets SET closed_at = ?, outcome = ?, cost = ?
+WHERE id = ?
+`
+
+type CloseMaintenanceTicketParams struct {
+	ClosedAt sql.NullTime
+	Outcome  NullMaintenanceTicketsOutcome
+	Cost     sql.NullString
+	ID       int32
+}
+
+func (q *Queries) CloseMaintenanceTicket(ctx context.Context, arg CloseMaintenanceTicketParams) error {
+	_, err := q.db.ExecContext(ctx, closeMaintenanceTicket,
+		arg.ClosedAt,
+		arg.Outcome,
+		arg.Cost,
+		arg.ID,
+	)
+	return err
+}
+
+const countDeviceTypes = `-- name: CountDeviceTypes :one
+SELECT COUNT(*) FROM device_type
+WHERE (? IS NULL OR status = ?)
+AND (? IS NULL OR LOWER(name) LIKE LOWER(?))
+`
+
+type CountDeviceTypesParams struct {
+	Status NullDeviceTypeStatus
+	Name   sql.NullString
+}
+
+func (q *Queries) CountDeviceTypes(ctx context.Context, arg CountDeviceTypesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countDeviceTypes,
+		arg.Status,
+		arg.Status,
+		arg.Name,
+		arg.Name,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countEquipmentByDeviceType = `-- name: CountEquipmentByDeviceType :many
+SELECT device_type_id AS id,
+    CAST(SUM(status = 'active') AS SIGNED) AS active,
+    CAST(SUM(status = 'inactive') AS SIGNED) AS inactive
+FROM serial_numbers
+WHERE device_type_id IN (/*SLICE:ids*/?)
+GROUP BY device_type_id
+`
+
+type CountEquipmentByDeviceTypeRow struct {
+	ID       int32
+	Active   int64
+	Inactive int64
+}
+
+func (q *Queries) CountEquipmentByDeviceType(ctx context.Context, ids []int32) ([]CountEquipmentByDeviceTypeRow, error) {
+	query := countEquipmentByDeviceType
+	var queryParams []interface{}
+	if len(ids) > 0 {
+		for _, v := range ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountEquipmentByDeviceTypeRow
+	for rows.Next() {
+		var i CountEquipmentByDeviceTypeRow
+		if err := rows.Scan(&i.ID, &i.Active, &i.Inactive); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countEquipmentByDeviceTypeAndManufacturer = `-- name: CountEquipmentByDeviceTypeAndManufacturer :many
+SELECT serial_numbers.device_type_id, device_type.name AS device_type,
+    serial_numbers.manufacturer_id, manufacturer.name AS manufacturer,
+    CAST(SUM(serial_numbers.status = 'active') AS SIGNED) AS active,
+    CAST(SUM(serial_numbers.status = 'inactive') AS SIGNED) AS inactive
+FROM serial_numbers
+JOIN device_type ON device_type.id = serial_numbers.device_type_id
+JOIN manufacturer ON manufacturer.id = serial_numbers.manufacturer_id
+GROUP BY serial_numbers.device_type_id, device_type.name, serial_numbers.manufacturer_id, manufacturer.name
+ORDER BY serial_numbers.device_type_id, serial_numbers.manufacturer_id
+`
+
+type CountEquipmentByDeviceTypeAndManufacturerRow struct {
 	DeviceTypeID   int32
+	DeviceType     string
 	ManufacturerID int32
-	SerialNumber   string
+	Manufacturer   string
+	Active         int64
+	Inactive       int64
+}
+
+// STATS QUERIES
+func (q *Queries) CountEquipmentByDeviceTypeAndManufacturer(ctx context.Context) ([]CountEquipmentByDeviceTypeAndManufacturerRow, error) {
+	rows, err := q.db.QueryContext(ctx, countEquipmentByDeviceTypeAndManufacturer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountEquipmentByDeviceTypeAndManufacturerRow
+	for rows.Next() {
+		var i CountEquipmentByDeviceTypeAndManufacturerRow
+		if err := rows.Scan(
+			&i.DeviceTypeID,
+			&i.DeviceType,
+			&i.ManufacturerID,
+			&i.Manufacturer,
+			&i.Active,
+			&i.Inactive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countEquipmentByLocation = `-- name: CountEquipmentByLocation :many
+SELECT location_id, COUNT(*) AS total FROM serial_numbers
+WHERE location_id IS NOT NULL
+GROUP BY location_id
+`
+
+type CountEquipmentByLocationRow struct {
+	LocationID sql.NullInt32
+	Total      int64
+}
+
+func (q *Queries) CountEquipmentByLocation(ctx context.Context) ([]CountEquipmentByLocationRow, error) {
+	rows, err := q.db.QueryContext(ctx, countEquipmentByLocation)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountEquipmentByLocationRow
+	for rows.Next() {
+		var i CountEquipmentByLocationRow
+		if err := rows.Scan(&i.LocationID, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countEquipmentByManufacturer = `-- name: CountEquipmentByManufacturer :many
+SELECT manufacturer_id AS id,
+    CAST(SUM(status = 'active') AS SIGNED) AS active,
+    CAST(SUM(status = 'inactive') AS SIGNED) AS inactive
+FROM serial_numbers
+WHERE manufacturer_id IN (/*SLICE:ids*/?)
+GROUP BY manufacturer_id
+`
+
+type CountEquipmentByManufacturerRow struct {
+	ID       int32
+	Active   int64
+	Inactive int64
+}
+
+func (q *Queries) CountEquipmentByManufacturer(ctx context.Context, ids []int32) ([]CountEquipmentByManufacturerRow, error) {
+	query := countEquipmentByManufacturer
+	var queryParams []interface{}
+	if len(ids) > 0 {
+		for _, v := range ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountEquipmentByManufacturerRow
+	for rows.Next() {
+		var i CountEquipmentByManufacturerRow
+		if err := rows.Scan(&i.ID, &i.Active, &i.Inactive); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countEquipmentByProductModel = `-- name: CountEquipmentByProductModel :one
+SELECT COUNT(*) FROM serial_numbers
+WHERE product_model_id = ?
+`
+
+func (q *Queries) CountEquipmentByProductModel(ctx context.Context, productModelID sql.NullInt32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEquipmentByProductModel, productModelID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countEquipmentByStatusAndDeviceType = `-- name: CountEquipmentByStatusAndDeviceType :many
+SELECT serial_numbers.status, device_type.name AS device_type, COUNT(*) AS total
+FROM serial_numbers
+JOIN device_type ON device_type.id = serial_numbers.device_type_id
+GROUP BY serial_numbers.status, device_type.name
+`
+
+type CountEquipmentByStatusAndDeviceTypeRow struct {
+	Status     SerialNumbersStatus
+	DeviceType string
+	Total      int64
+}
+
+func (q *Queries) CountEquipmentByStatusAndDeviceType(ctx context.Context) ([]CountEquipmentByStatusAndDeviceTypeRow, error) {
+	rows, err := q.db.QueryContext(ctx, countEquipmentByStatusAndDeviceType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountEquipmentByStatusAndDeviceTypeRow
+	for rows.Next() {
+		var i CountEquipmentByStatusAndDeviceTypeRow
+		if err := rows.Scan(&i.Status, &i.DeviceType, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countEquipmentCreatedBefore = `-- name: CountEquipmentCreatedBefore :one
+SELECT COUNT(*) FROM serial_numbers
+WHERE created_at < ?
+`
+
+func (q *Queries) CountEquipmentCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEquipmentCreatedBefore, before)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countEquipmentCreatedByDay = `-- name: CountEquipmentCreatedByDay :many
+SELECT DATE_FORMAT(created_at, '%Y-%m-%d') AS day, COUNT(*) AS total
+FROM serial_numbers
+WHERE created_at >= ? AND created_at < ?
+GROUP BY day
+ORDER BY day
+`
+
+type CountEquipmentCreatedByDayParams struct {
+	From time.Time
+	To   time.Time
+}
+
+type CountEquipmentCreatedByDayRow struct {
+	Day   string
+	Total int64
+}
+
+func (q *Queries) CountEquipmentCreatedByDay(ctx context.Context, arg CountEquipmentCreatedByDayParams) ([]CountEquipmentCreatedByDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, countEquipmentCreatedByDay, arg.From, arg.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountEquipmentCreatedByDayRow
+	for rows.Next() {
+		var i CountEquipmentCreatedByDayRow
+		if err := rows.Scan(&i.Day, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countManufacturers = `-- name: CountManufacturers :one
+SELECT COUNT(*) FROM manufacturer
+WHERE (? IS NULL OR status = ?)
+AND (? IS NULL OR LOWER(name) LIKE LOWER(?))
+`
+
+type CountManufacturersParams struct {
+	Status NullManufacturerStatus
+	Name   sql.NullString
+}
+
+func (q *Queries) CountManufacturers(ctx context.Context, arg CountManufacturersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countManufacturers,
+		arg.Status,
+		arg.Status,
+		arg.Name,
+		arg.Name,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAssignee = `-- name: CreateAssignee :execlastid
+INSERT INTO assignees (name, kind, email) VALUES (?, ?, ?)
+`
+
+type CreateAssigneeParams struct {
+	Name  string
+	Kind  AssigneesKind
+	Email string
+}
+
+func (q *Queries) CreateAssignee(ctx context.Context, arg CreateAssigneeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createAssignee, arg.Name, arg.Kind, arg.Email)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createAssignment = `-- name: CreateAssignment :exec
+INSERT INTO equipment_assignments (equipment_id, assignee_id, note) VALUES (?, ?, ?)
+`
+
+type CreateAssignmentParams struct {
+	EquipmentID int32
+	AssigneeID  int32
+	Note        string
 }
 
-func (q *Queries) CreateEquipment(ctx context.Context, arg CreateEquipmentParams) error {
-	_, err := q.db.ExecContext(ctx, createEquipment, arg.DeviceTypeID, arg.ManufacturerID, arg.SerialNumber)
+func (q *Queries) CreateAssignment(ctx context.Context, arg CreateAssignmentParams) error {
+	_, err := q.db.ExecContext(ctx, createAssignment, arg.EquipmentID, arg.AssigneeID, arg.Note)
 	return err
 }
 
-const createManufacturer = `-- name: CreateManufacturer :exec
-INSERT INTO manufacturer (name) VALUES (?)
+const createAttachment = `-- name: CreateAttachment :execlastid
+INSERT INTO attachments (equipment_id, manufacturer_id, kind, filename, content_type, size, sha256, storage_key)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 `
 
-func (q *Queries) CreateManufacturer(ctx context.Context, name string) error {
-	_, err := q.db.ExecContext(ctx, createManufacturer, name)
+type CreateAttachmentParams struct {
+	EquipmentID    sql.NullInt32
+	ManufacturerID sql.NullInt32
+	Kind           AttachmentsKind
+	Filename       string
+	ContentType    string
+	Size           int64
+	Sha256         string
+	StorageKey     string
+}
+
+func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createAttachment,
+		arg.EquipmentID,
+		arg.ManufacturerID,
+		arg.Kind,
+		arg.Filename,
+		arg.ContentType,
+		arg.Size,
+		arg.Sha256,
+		arg.StorageKey,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createCountScan = `-- name: CreateCountScan :execlastid
+INSERT INTO count_scans (session_id, code, equipment_id, location_id) VALUES (?, ?, ?, ?)
+`
+
+type CreateCountScanParams struct {
+	SessionID   int32
+	Code        string
+	EquipmentID sql.NullInt32
+	LocationID  sql.NullInt32
+}
+
+func (q *Queries) CreateCountScan(ctx context.Context, arg CreateCountScanParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createCountScan,
+		arg.SessionID,
+		arg.Code,
+		arg.EquipmentID,
+		arg.LocationID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createCountSession = `-- name: CreateCountSession :execlastid
+INSERT INTO count_sessions (name, location_id, device_type_id) VALUES (?, ?, ?)
+`
+
+type CreateCountSessionParams struct {
+	Name         string
+	LocationID   sql.NullInt32
+	DeviceTypeID sql.NullInt32
+}
+
+func (q *Queries) CreateCountSession(ctx context.Context, arg CreateCountSessionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createCountSession, arg.Name, arg.LocationID, arg.DeviceTypeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createDeviceType = `-- name: CreateDeviceType :exec
+INSERT INTO device_type (name) VALUES (?)
+`
+
+func (q *Queries) CreateDeviceType(ctx context.Context, name string) error {
+	_, err := q.db.ExecContext(ctx, createDeviceType, name)
 	return err
 }
 
-const deleteDeviceType = `-- name: DeleteDeviceType :exec
-DELETE FROM device_type
-WHERE id = ?
+const createDeviceTypeAttribute = `-- name: CreateDeviceTypeAttribute :execlastid
+INSERT INTO device_type_attributes (device_type_id, name, type, required, enum_values, pattern) VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateDeviceTypeAttributeParams struct {
+	DeviceTypeID int32
+	Name         string
+	Type         DeviceTypeAttributesType
+	Required     bool
+	EnumValues   json.RawMessage
+	Pattern      string
+}
+
+func (q *Queries) CreateDeviceTypeAttribute(ctx context.Context, arg CreateDeviceTypeAttributeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createDeviceTypeAttribute,
+		arg.DeviceTypeID,
+		arg.Name,
+		arg.Type,
+		arg.Required,
+		arg.EnumValues,
+		arg.Pattern,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createEquipment = `-- name: CreateEquipment :execlastid
+INSERT INTO serial_numbers (device_type_id, manufacturer_id, serial_number, serial_canonical, product_model_id, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateEquipmentParams struct {
+	DeviceTypeID    int32
+	ManufacturerID  int32
+	SerialNumber    string
+	SerialCanonical string
+	ProductModelID  sql.NullInt32
+	PurchaseDate    sql.NullTime
+	Vendor          string
+	PurchaseOrder   string
+	Cost            sql.NullString
+	WarrantyStart   sql.NullTime
+	WarrantyEnd     sql.NullTime
+}
+
+func (q *Queries) CreateEquipment(ctx context.Context, arg CreateEquipmentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createEquipment,
+		arg.DeviceTypeID,
+		arg.ManufacturerID,
+		arg.SerialNumber,
+		arg.SerialCanonical,
+		arg.ProductModelID,
+		arg.PurchaseDate,
+		arg.Vendor,
+		arg.PurchaseOrder,
+		arg.Cost,
+		arg.WarrantyStart,
+		arg.WarrantyEnd,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createEquipmentAttribute = `-- name: CreateEquipmentAttribute :exec
+INSERT INTO equipment_attributes (equipment_id, attribute_id, value) VALUES (?, ?, ?)
+`
+
+type CreateEquipmentAttributeParams struct {
+	EquipmentID int32
+	AttributeID int32
+	Value       string
+}
+
+func (q *Queries) CreateEquipmentAttribute(ctx context.Context, arg CreateEquipmentAttributeParams) error {
+	_, err := q.db.ExecContext(ctx, createEquipmentAttribute, arg.EquipmentID, arg.AttributeID, arg.Value)
+	return err
+}
+
+const createLifecycleTransition = `-- name: CreateLifecycleTransition :exec
+INSERT INTO lifecycle_transitions (equipment_id, from_state, to_state, reason) VALUES (?, ?, ?, ?)
+`
+
+type CreateLifecycleTransitionParams struct {
+	EquipmentID int32
+	FromState   string
+	ToState     string
+	Reason      string
+}
+
+func (q *Queries) CreateLifecycleTransition(ctx context.Context, arg CreateLifecycleTransitionParams) error {
+	_, err := q.db.ExecContext(ctx, createLifecycleTransition,
+		arg.EquipmentID,
+		arg.FromState,
+		arg.ToState,
+		arg.Reason,
+	)
+	return err
+}
+
+const createLocation = `-- name: CreateLocation :execlastid
+INSERT INTO locations (parent_id, name, kind) VALUES (?, ?, ?)
+`
+
+type CreateLocationParams struct {
+	ParentID sql.NullInt32
+	Name     string
+	Kind     LocationsKind
+}
+
+func (q *Queries) CreateLocation(ctx context.Context, arg CreateLocationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createLocation, arg.ParentID, arg.Name, arg.Kind)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createLocationMove = `-- name: CreateLocationMove :exec
+INSERT INTO location_moves (equipment_id, from_location_id, to_location_id, reason) VALUES (?, ?, ?, ?)
+`
+
+type CreateLocationMoveParams struct {
+	EquipmentID    int32
+	FromLocationID sql.NullInt32
+	ToLocationID   sql.NullInt32
+	Reason         string
+}
+
+func (q *Queries) CreateLocationMove(ctx context.Context, arg CreateLocationMoveParams) error {
+	_, err := q.db.ExecContext(ctx, createLocationMove,
+		arg.EquipmentID,
+		arg.FromLocationID,
+		arg.ToLocationID,
+		arg.Reason,
+	)
+	return err
+}
+
+const createMaintenanceTicket = `-- name: CreateMaintenanceTicket :execlastid
+INSERT INTO maintenance_tickets (equipment_id, issue, vendor, opened_at, cost, return_state) VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateMaintenanceTicketParams struct {
+	EquipmentID int32
+	Issue       string
+	Vendor      string
+	OpenedAt    time.Time
+	Cost        sql.NullString
+	ReturnState string
+}
+
+func (q *Queries) CreateMaintenanceTicket(ctx context.Context, arg CreateMaintenanceTicketParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createMaintenanceTicket,
+		arg.EquipmentID,
+		arg.Issue,
+		arg.Vendor,
+		arg.OpenedAt,
+		arg.Cost,
+		arg.ReturnState,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createManufacturer = `-- name: CreateManufacturer :exec
+INSERT INTO manufacturer (name) VALUES (?)
+`
+
+func (q *Queries) CreateManufacturer(ctx context.Context, name string) error {
+	_, err := q.db.ExecContext(ctx, createManufacturer, name)
+	return err
+}
+
+const createProductModel = `-- name: CreateProductModel :execlastid
+INSERT INTO product_models (manufacturer_id, device_type_id, model_number, name, specs) VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateProductModelParams struct {
+	ManufacturerID int32
+	DeviceTypeID   int32
+	ModelNumber    string
+	Name           string
+	Specs          json.RawMessage
+}
+
+func (q *Queries) CreateProductModel(ctx context.Context, arg CreateProductModelParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createProductModel,
+		arg.ManufacturerID,
+		arg.DeviceTypeID,
+		arg.ModelNumber,
+		arg.Name,
+		arg.Specs,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createSerialNumberRule = `-- name: CreateSerialNumberRule :execlastid
+INSERT INTO serial_number_rules (name, manufacturer_id, device_type_id, pattern, min_length, max_length, prefix, checksum) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateSerialNumberRuleParams struct {
+	Name           string
+	ManufacturerID sql.NullInt32
+	DeviceTypeID   sql.NullInt32
+	Pattern        string
+	MinLength      int32
+	MaxLength      int32
+	Prefix         string
+	Checksum       SerialNumberRulesChecksum
+}
+
+func (q *Queries) CreateSerialNumberRule(ctx context.Context, arg CreateSerialNumberRuleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createSerialNumberRule,
+		arg.Name,
+		arg.ManufacturerID,
+		arg.DeviceTypeID,
+		arg.Pattern,
+		arg.MinLength,
+		arg.MaxLength,
+		arg.Prefix,
+		arg.Checksum,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createTag = `-- name: CreateTag :execlastid
+INSERT INTO tags (name) VALUES (?)
+`
+
+func (q *Queries) CreateTag(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createTag, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const deleteAttachment = `-- name: DeleteAttachment :exec
+DELETE FROM attachments
+WHERE id = ?
+`
+
+func (q *Queries) DeleteAttachment(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteAttachment, id)
+	return err
+}
+
+const deleteDepreciationSchedule = `-- name: DeleteDepreciationSchedule :exec
+DELETE FROM depreciation_schedules
+WHERE device_type_id = ?
+`
+
+func (q *Queries) DeleteDepreciationSchedule(ctx context.Context, deviceTypeID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteDepreciationSchedule, deviceTypeID)
+	return err
+}
+
+const deleteDeviceType = `-- name: DeleteDeviceType :exec
+DELETE FROM device_type
+WHERE id = ?
+`
+
+func (q *Queries) DeleteDeviceType(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteDeviceType, id)
+	return err
+}
+
+const deleteDeviceTypeAttribute = `-- name: DeleteDeviceTypeAttribute :exec
+DELETE FROM device_type_attributes
+WHERE id = ?
+`
+
+func (q *Queries) DeleteDeviceTypeAttribute(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteDeviceTypeAttribute, id)
+	return err
+}
+
+const deleteEquipmentAttributes = `-- name: DeleteEquipmentAttributes :exec
+DELETE FROM equipment_attributes
+WHERE equipment_id = ?
+`
+
+func (q *Queries) DeleteEquipmentAttributes(ctx context.Context, equipmentID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteEquipmentAttributes, equipmentID)
+	return err
+}
+
+const deleteManufacturer = `-- name: DeleteManufacturer :exec
+DELETE FROM manufacturer
+WHERE id = ?
+`
+
+func (q *Queries) DeleteManufacturer(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteManufacturer, id)
+	return err
+}
+
+const deleteProductModel = `-- name: DeleteProductModel :exec
+DELETE FROM product_models
+WHERE id = ?
+`
+
+func (q *Queries) DeleteProductModel(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteProductModel, id)
+	return err
+}
+
+const deleteSerialNumberRule = `-- name: DeleteSerialNumberRule :exec
+DELETE FROM serial_number_rules
+WHERE id = ?
+`
+
+func (q *Queries) DeleteSerialNumberRule(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteSerialNumberRule, id)
+	return err
+}
+
+const deleteTag = `-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE id = ?
+`
+
+func (q *Queries) DeleteTag(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteTag, id)
+	return err
+}
+
+const getAllEquipment = `-- name: GetAllEquipment :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+LIMIT 1000
+`
+
+// EQUIPMENT QUERIES
+func (q *Queries) GetAllEquipment(ctx context.Context) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getAllEquipment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAssigneeByID = `-- name: GetAssigneeByID :one
+SELECT id, name, kind, email, status FROM assignees
+WHERE id = ?
+`
+
+func (q *Queries) GetAssigneeByID(ctx context.Context, id int32) (Assignee, error) {
+	row := q.db.QueryRowContext(ctx, getAssigneeByID, id)
+	var i Assignee
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Email,
+		&i.Status,
+	)
+	return i, err
+}
+
+const getAssignees = `-- name: GetAssignees :many
+SELECT id, name, kind, email, status FROM assignees
+ORDER BY id
+`
+
+// ASSIGNEE QUERIES
+func (q *Queries) GetAssignees(ctx context.Context) ([]Assignee, error) {
+	rows, err := q.db.QueryContext(ctx, getAssignees)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Assignee
+	for rows.Next() {
+		var i Assignee
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.Email,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAssignmentsByAssignee = `-- name: GetAssignmentsByAssignee :many
+SELECT id, equipment_id, assignee_id, checked_out_at, checked_in_at, note, checkin_note FROM equipment_assignments
+WHERE assignee_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetAssignmentsByAssignee(ctx context.Context, assigneeID int32) ([]EquipmentAssignment, error) {
+	rows, err := q.db.QueryContext(ctx, getAssignmentsByAssignee, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EquipmentAssignment
+	for rows.Next() {
+		var i EquipmentAssignment
+		if err := rows.Scan(
+			&i.ID,
+			&i.EquipmentID,
+			&i.AssigneeID,
+			&i.CheckedOutAt,
+			&i.CheckedInAt,
+			&i.Note,
+			&i.CheckinNote,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAssignmentsByEquipment = `-- name: GetAssignmentsByEquipment :many
+SELECT id, equipment_id, assignee_id, checked_out_at, checked_in_at, note, checkin_note FROM equipment_assignments
+WHERE equipment_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetAssignmentsByEquipment(ctx context.Context, equipmentID int32) ([]EquipmentAssignment, error) {
+	rows, err := q.db.QueryContext(ctx, getAssignmentsByEquipment, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EquipmentAssignment
+	for rows.Next() {
+		var i EquipmentAssignment
+		if err := rows.Scan(
+			&i.ID,
+			&i.EquipmentID,
+			&i.AssigneeID,
+			&i.CheckedOutAt,
+			&i.CheckedInAt,
+			&i.Note,
+			&i.CheckinNote,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttachmentByID = `-- name: GetAttachmentByID :one
+SELECT id, equipment_id, manufacturer_id, kind, filename, content_type, size, sha256, storage_key, created_at FROM attachments
+WHERE id = ?
+`
+
+// ATTACHMENT QUERIES
+func (q *Queries) GetAttachmentByID(ctx context.Context, id int32) (Attachment, error) {
+	row := q.db.QueryRowContext(ctx, getAttachmentByID, id)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.EquipmentID,
+		&i.ManufacturerID,
+		&i.Kind,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.StorageKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAttributeNames = `-- name: GetAttributeNames :many
+SELECT DISTINCT name FROM device_type_attributes
+WHERE (? IS NULL OR device_type_id = ?)
+ORDER BY name
+`
+
+type GetAttributeNamesParams struct {
+	DeviceTypeID sql.NullInt32
+}
+
+func (q *Queries) GetAttributeNames(ctx context.Context, arg GetAttributeNamesParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getAttributeNames, arg.DeviceTypeID, arg.DeviceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCountScanByEquipment = `-- name: GetCountScanByEquipment :one
+SELECT id, session_id, code, equipment_id, location_id, scanned_at FROM count_scans
+WHERE session_id = ? AND equipment_id = ?
+`
+
+type GetCountScanByEquipmentParams struct {
+	SessionID   int32
+	EquipmentID sql.NullInt32
+}
+
+func (q *Queries) GetCountScanByEquipment(ctx context.Context, arg GetCountScanByEquipmentParams) (CountScan, error) {
+	row := q.db.QueryRowContext(ctx, getCountScanByEquipment, arg.SessionID, arg.EquipmentID)
+	var i CountScan
+	err := row.Scan(
+		&i.ID,
+		&i.SessionID,
+		&i.Code,
+		&i.EquipmentID,
+		&i.LocationID,
+		&i.ScannedAt,
+	)
+	return i, err
+}
+
+const getCountScans = `-- name: GetCountScans :many
+SELECT id, session_id, code, equipment_id, location_id, scanned_at FROM count_scans
+WHERE session_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetCountScans(ctx context.Context, sessionID int32) ([]CountScan, error) {
+	rows, err := q.db.QueryContext(ctx, getCountScans, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountScan
+	for rows.Next() {
+		var i CountScan
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.Code,
+			&i.EquipmentID,
+			&i.LocationID,
+			&i.ScannedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCountSessionByID = `-- name: GetCountSessionByID :one
+SELECT id, name, location_id, device_type_id, status, created_at, closed_at, applied_at FROM count_sessions
+WHERE id = ?
+`
+
+func (q *Queries) GetCountSessionByID(ctx context.Context, id int32) (CountSession, error) {
+	row := q.db.QueryRowContext(ctx, getCountSessionByID, id)
+	var i CountSession
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.LocationID,
+		&i.DeviceTypeID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.AppliedAt,
+	)
+	return i, err
+}
+
+const getCountSessionByIDForUpdate = `-- name: GetCountSessionByIDForUpdate :one
+SELECT id, name, location_id, device_type_id, status, created_at, closed_at, applied_at FROM count_sessions
+WHERE id = ?
+FOR UPDATE
+`
+
+func (q *Queries) GetCountSessionByIDForUpdate(ctx context.Context, id int32) (CountSession, error) {
+	row := q.db.QueryRowContext(ctx, getCountSessionByIDForUpdate, id)
+	var i CountSession
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.LocationID,
+		&i.DeviceTypeID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ClosedAt,
+		&i.AppliedAt,
+	)
+	return i, err
+}
+
+const getCountSessions = `-- name: GetCountSessions :many
+SELECT count_sessions.id, count_sessions.name, count_sessions.location_id, count_sessions.device_type_id, count_sessions.status, count_sessions.created_at, count_sessions.closed_at, count_sessions.applied_at, COUNT(count_scans.id) AS scans FROM count_sessions
+LEFT JOIN count_scans ON count_scans.session_id = count_sessions.id
+GROUP BY count_sessions.id
+ORDER BY count_sessions.created_at DESC, count_sessions.id DESC
+`
+
+type GetCountSessionsRow struct {
+	ID           int32
+	Name         string
+	LocationID   sql.NullInt32
+	DeviceTypeID sql.NullInt32
+	Status       CountSessionsStatus
+	CreatedAt    time.Time
+	ClosedAt     sql.NullTime
+	AppliedAt    sql.NullTime
+	Scans        int64
+}
+
+// COUNT QUERIES
+func (q *Queries) GetCountSessions(ctx context.Context) ([]GetCountSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCountSessions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCountSessionsRow
+	for rows.Next() {
+		var i GetCountSessionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.LocationID,
+			&i.DeviceTypeID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ClosedAt,
+			&i.AppliedAt,
+			&i.Scans,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDepreciationSchedule = `-- name: GetDepreciationSchedule :one
+SELECT device_type_id, method, life_months, salvage_percent, rate_percent FROM depreciation_schedules
+WHERE device_type_id = ?
+`
+
+func (q *Queries) GetDepreciationSchedule(ctx context.Context, deviceTypeID int32) (DepreciationSchedule, error) {
+	row := q.db.QueryRowContext(ctx, getDepreciationSchedule, deviceTypeID)
+	var i DepreciationSchedule
+	err := row.Scan(
+		&i.DeviceTypeID,
+		&i.Method,
+		&i.LifeMonths,
+		&i.SalvagePercent,
+		&i.RatePercent,
+	)
+	return i, err
+}
+
+const getDepreciationSchedules = `-- name: GetDepreciationSchedules :many
+SELECT device_type_id, method, life_months, salvage_percent, rate_percent FROM depreciation_schedules
+`
+
+// DEPRECIATION QUERIES
+func (q *Queries) GetDepreciationSchedules(ctx context.Context) ([]DepreciationSchedule, error) {
+	rows, err := q.db.QueryContext(ctx, getDepreciationSchedules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DepreciationSchedule
+	for rows.Next() {
+		var i DepreciationSchedule
+		if err := rows.Scan(
+			&i.DeviceTypeID,
+			&i.Method,
+			&i.LifeMonths,
+			&i.SalvagePercent,
+			&i.RatePercent,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeviceTypeAttributeByName = `-- name: GetDeviceTypeAttributeByName :one
+SELECT id, device_type_id, name, type, required, enum_values, pattern FROM device_type_attributes
+WHERE device_type_id = ? AND name = ?
+`
+
+type GetDeviceTypeAttributeByNameParams struct {
+	DeviceTypeID int32
+	Name         string
+}
+
+func (q *Queries) GetDeviceTypeAttributeByName(ctx context.Context, arg GetDeviceTypeAttributeByNameParams) (DeviceTypeAttribute, error) {
+	row := q.db.QueryRowContext(ctx, getDeviceTypeAttributeByName, arg.DeviceTypeID, arg.Name)
+	var i DeviceTypeAttribute
+	err := row.Scan(
+		&i.ID,
+		&i.DeviceTypeID,
+		&i.Name,
+		&i.Type,
+		&i.Required,
+		&i.EnumValues,
+		&i.Pattern,
+	)
+	return i, err
+}
+
+const getDeviceTypeAttributes = `-- name: GetDeviceTypeAttributes :many
+SELECT id, device_type_id, name, type, required, enum_values, pattern FROM device_type_attributes
+WHERE device_type_id = ?
+ORDER BY id
+`
+
+// ATTRIBUTE QUERIES
+func (q *Queries) GetDeviceTypeAttributes(ctx context.Context, deviceTypeID int32) ([]DeviceTypeAttribute, error) {
+	rows, err := q.db.QueryContext(ctx, getDeviceTypeAttributes, deviceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeviceTypeAttribute
+	for rows.Next() {
+		var i DeviceTypeAttribute
+		if err := rows.Scan(
+			&i.ID,
+			&i.DeviceTypeID,
+			&i.Name,
+			&i.Type,
+			&i.Required,
+			&i.EnumValues,
+			&i.Pattern,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeviceTypeAttributesByName = `-- name: GetDeviceTypeAttributesByName :many
+SELECT id, device_type_id, name, type, required, enum_values, pattern FROM device_type_attributes
+WHERE name IN (/*SLICE:names*/?)
+ORDER BY id
+`
+
+func (q *Queries) GetDeviceTypeAttributesByName(ctx context.Context, names []string) ([]DeviceTypeAttribute, error) {
+	query := getDeviceTypeAttributesByName
+	var queryParams []interface{}
+	if len(names) > 0 {
+		for _, v := range names {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:names*/?", strings.Repeat(",?", len(names))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:names*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeviceTypeAttribute
+	for rows.Next() {
+		var i DeviceTypeAttribute
+		if err := rows.Scan(
+			&i.ID,
+			&i.DeviceTypeID,
+			&i.Name,
+			&i.Type,
+			&i.Required,
+			&i.EnumValues,
+			&i.Pattern,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeviceTypeById = `-- name: GetDeviceTypeById :one
+SELECT id, name, status FROM device_type
+WHERE id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetDeviceTypeById(ctx context.Context, id int32) (DeviceType, error) {
+	row := q.db.QueryRowContext(ctx, getDeviceTypeById, id)
+	var i DeviceType
+	err := row.Scan(&i.ID, &i.Name, &i.Status)
+	return i, err
+}
+
+const getDeviceTypeByName = `-- name: GetDeviceTypeByName :one
+SELECT id, name, status FROM device_type
+WHERE name = ?
+ORDER BY id
+`
+
+func (q *Queries) GetDeviceTypeByName(ctx context.Context, name string) (DeviceType, error) {
+	row := q.db.QueryRowContext(ctx, getDeviceTypeByName, name)
+	var i DeviceType
+	err := row.Scan(&i.ID, &i.Name, &i.Status)
+	return i, err
+}
+
+const getDeviceTypes = `-- name: GetDeviceTypes :many
+SELECT id, name, status FROM device_type
+ORDER BY id
+`
+
+// DEVICETYPE QUERIES
+func (q *Queries) GetDeviceTypes(ctx context.Context) ([]DeviceType, error) {
+	rows, err := q.db.QueryContext(ctx, getDeviceTypes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeviceType
+	for rows.Next() {
+		var i DeviceType
+		if err := rows.Scan(&i.ID, &i.Name, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentAttachments = `-- name: GetEquipmentAttachments :many
+SELECT id, equipment_id, manufacturer_id, kind, filename, content_type, size, sha256, storage_key, created_at FROM attachments
+WHERE equipment_id = ?
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) GetEquipmentAttachments(ctx context.Context, equipmentID sql.NullInt32) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentAttachments, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.EquipmentID,
+			&i.ManufacturerID,
+			&i.Kind,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.StorageKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentAttributes = `-- name: GetEquipmentAttributes :many
+SELECT equipment_attributes.equipment_id, device_type_attributes.name, equipment_attributes.value
+FROM equipment_attributes
+JOIN device_type_attributes ON device_type_attributes.id = equipment_attributes.attribute_id
+JOIN serial_numbers ON serial_numbers.auto_id = equipment_attributes.equipment_id
+    AND serial_numbers.device_type_id = device_type_attributes.device_type_id
+WHERE equipment_attributes.equipment_id IN (/*SLICE:equipment_ids*/?)
+ORDER BY equipment_attributes.equipment_id, device_type_attributes.id
+`
+
+type GetEquipmentAttributesRow struct {
+	EquipmentID int32
+	Name        string
+	Value       string
+}
+
+func (q *Queries) GetEquipmentAttributes(ctx context.Context, equipmentIds []int32) ([]GetEquipmentAttributesRow, error) {
+	query := getEquipmentAttributes
+	var queryParams []interface{}
+	if len(equipmentIds) > 0 {
+		for _, v := range equipmentIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:equipment_ids*/?", strings.Repeat(",?", len(equipmentIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:equipment_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEquipmentAttributesRow
+	for rows.Next() {
+		var i GetEquipmentAttributesRow
+		if err := rows.Scan(&i.EquipmentID, &i.Name, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentByAutoID = `-- name: GetEquipmentByAutoID :one
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE auto_id = ?
+`
+
+func (q *Queries) GetEquipmentByAutoID(ctx context.Context, autoID int32) (SerialNumber, error) {
+	row := q.db.QueryRowContext(ctx, getEquipmentByAutoID, autoID)
+	var i SerialNumber
+	err := row.Scan(
+		&i.AutoID,
+		&i.DeviceTypeID,
+		&i.ManufacturerID,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LifecycleState,
+		&i.LocationID,
+		&i.ProductModelID,
+		&i.SerialCanonical,
+		&i.PurchaseDate,
+		&i.Vendor,
+		&i.PurchaseOrder,
+		&i.Cost,
+		&i.WarrantyStart,
+		&i.WarrantyEnd,
+		&i.LastSeenAt,
+		&i.LastSeenLocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getEquipmentByAutoIDForUpdate = `-- name: GetEquipmentByAutoIDForUpdate :one
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE auto_id = ?
+FOR UPDATE
+`
+
+func (q *Queries) GetEquipmentByAutoIDForUpdate(ctx context.Context, autoID int32) (SerialNumber, error) {
+	row := q.db.QueryRowContext(ctx, getEquipmentByAutoIDForUpdate, autoID)
+	var i SerialNumber
+	err := row.Scan(
+		&i.AutoID,
+		&i.DeviceTypeID,
+		&i.ManufacturerID,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LifecycleState,
+		&i.LocationID,
+		&i.ProductModelID,
+		&i.SerialCanonical,
+		&i.PurchaseDate,
+		&i.Vendor,
+		&i.PurchaseOrder,
+		&i.Cost,
+		&i.WarrantyStart,
+		&i.WarrantyEnd,
+		&i.LastSeenAt,
+		&i.LastSeenLocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getEquipmentByAutoIDs = `-- name: GetEquipmentByAutoIDs :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE auto_id IN (/*SLICE:auto_ids*/?)
+`
+
+func (q *Queries) GetEquipmentByAutoIDs(ctx context.Context, autoIds []int32) ([]SerialNumber, error) {
+	query := getEquipmentByAutoIDs
+	var queryParams []interface{}
+	if len(autoIds) > 0 {
+		for _, v := range autoIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:auto_ids*/?", strings.Repeat(",?", len(autoIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:auto_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentByDeviceType = `-- name: GetEquipmentByDeviceType :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE device_type_id = ?
+LIMIT 1000
+`
+
+func (q *Queries) GetEquipmentByDeviceType(ctx context.Context, deviceTypeID int32) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentByDeviceType, deviceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentByDeviceTypeAndManufacturer = `-- name: GetEquipmentByDeviceTypeAndManufacturer :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE device_type_id = ? AND manufacturer_id = ?
+LIMIT 1000
+`
+
+type GetEquipmentByDeviceTypeAndManufacturerParams struct {
+	DeviceTypeID   int32
+	ManufacturerID int32
+}
+
+func (q *Queries) GetEquipmentByDeviceTypeAndManufacturer(ctx context.Context, arg GetEquipmentByDeviceTypeAndManufacturerParams) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentByDeviceTypeAndManufacturer, arg.DeviceTypeID, arg.ManufacturerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentByDeviceTypeManufacturerAndSerialNumber = `-- name: GetEquipmentByDeviceTypeManufacturerAndSerialNumber :one
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE device_type_id = ? AND manufacturer_id = ? AND serial_canonical = ?
+`
+
+type GetEquipmentByDeviceTypeManufacturerAndSerialNumberParams struct {
+	DeviceTypeID    int32
+	ManufacturerID  int32
+	SerialCanonical string
+}
+
+func (q *Queries) GetEquipmentByDeviceTypeManufacturerAndSerialNumber(ctx context.Context, arg GetEquipmentByDeviceTypeManufacturerAndSerialNumberParams) (SerialNumber, error) {
+	row := q.db.QueryRowContext(ctx, getEquipmentByDeviceTypeManufacturerAndSerialNumber, arg.DeviceTypeID, arg.ManufacturerID, arg.SerialCanonical)
+	var i SerialNumber
+	err := row.Scan(
+		&i.AutoID,
+		&i.DeviceTypeID,
+		&i.ManufacturerID,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LifecycleState,
+		&i.LocationID,
+		&i.ProductModelID,
+		&i.SerialCanonical,
+		&i.PurchaseDate,
+		&i.Vendor,
+		&i.PurchaseOrder,
+		&i.Cost,
+		&i.WarrantyStart,
+		&i.WarrantyEnd,
+		&i.LastSeenAt,
+		&i.LastSeenLocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getEquipmentByDeviceTypeManufacturerLikeSerialNumber = `-- name: GetEquipmentByDeviceTypeManufacturerLikeSerialNumber :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE device_type_id = ? AND manufacturer_id = ?
+AND (serial_number LIKE ? OR serial_canonical LIKE ?) LIMIT 1000
+`
+
+type GetEquipmentByDeviceTypeManufacturerLikeSerialNumberParams struct {
+	DeviceTypeID    int32
+	ManufacturerID  int32
+	SerialNumber    string
+	SerialCanonical string
+}
+
+func (q *Queries) GetEquipmentByDeviceTypeManufacturerLikeSerialNumber(ctx context.Context, arg GetEquipmentByDeviceTypeManufacturerLikeSerialNumberParams) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentByDeviceTypeManufacturerLikeSerialNumber,
+		arg.DeviceTypeID,
+		arg.ManufacturerID,
+		arg.SerialNumber,
+		arg.SerialCanonical,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentByLifecycleState = `-- name: GetEquipmentByLifecycleState :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE lifecycle_state = ?
+ORDER BY auto_id
+LIMIT 1000
+`
+
+// LIFECYCLE QUERIES
+func (q *Queries) GetEquipmentByLifecycleState(ctx context.Context, lifecycleState SerialNumbersLifecycleState) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentByLifecycleState, lifecycleState)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentByLocations = `-- name: GetEquipmentByLocations :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE location_id IN (/*SLICE:location_ids*/?)
+ORDER BY auto_id
+LIMIT 1000
+`
+
+func (q *Queries) GetEquipmentByLocations(ctx context.Context, locationIds []sql.NullInt32) ([]SerialNumber, error) {
+	query := getEquipmentByLocations
+	var queryParams []interface{}
+	if len(locationIds) > 0 {
+		for _, v := range locationIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:location_ids*/?", strings.Repeat(",?", len(locationIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:location_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentByManufacturer = `-- name: GetEquipmentByManufacturer :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE manufacturer_id = ?
+LIMIT 1000
+`
+
+func (q *Queries) GetEquipmentByManufacturer(ctx context.Context, manufacturerID int32) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentByManufacturer, manufacturerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentByManufacturerAndSerialNumber = `-- name: GetEquipmentByManufacturerAndSerialNumber :one
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE manufacturer_id = ? AND serial_canonical = ?
+`
+
+type GetEquipmentByManufacturerAndSerialNumberParams struct {
+	ManufacturerID  int32
+	SerialCanonical string
+}
+
+func (q *Queries) GetEquipmentByManufacturerAndSerialNumber(ctx context.Context, arg GetEquipmentByManufacturerAndSerialNumberParams) (SerialNumber, error) {
+	row := q.db.QueryRowContext(ctx, getEquipmentByManufacturerAndSerialNumber, arg.ManufacturerID, arg.SerialCanonical)
+	var i SerialNumber
+	err := row.Scan(
+		&i.AutoID,
+		&i.DeviceTypeID,
+		&i.ManufacturerID,
+		&i.SerialNumber,
+		&i.Status,
+		&i.LifecycleState,
+		&i.LocationID,
+		&i.ProductModelID,
+		&i.SerialCanonical,
+		&i.PurchaseDate,
+		&i.Vendor,
+		&i.PurchaseOrder,
+		&i.Cost,
+		&i.WarrantyStart,
+		&i.WarrantyEnd,
+		&i.LastSeenAt,
+		&i.LastSeenLocationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getEquipmentBySerialCanonicals = `-- name: GetEquipmentBySerialCanonicals :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE serial_canonical IN (/*SLICE:canonicals*/?)
+ORDER BY auto_id
+`
+
+func (q *Queries) GetEquipmentBySerialCanonicals(ctx context.Context, canonicals []string) ([]SerialNumber, error) {
+	query := getEquipmentBySerialCanonicals
+	var queryParams []interface{}
+	if len(canonicals) > 0 {
+		for _, v := range canonicals {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:canonicals*/?", strings.Repeat(",?", len(canonicals))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:canonicals*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentCounts = `-- name: GetEquipmentCounts :many
+SELECT device_type_id, manufacturer_id, COUNT(*) AS count FROM serial_numbers
+GROUP BY device_type_id, manufacturer_id
+`
+
+type GetEquipmentCountsRow struct {
+	DeviceTypeID   int32
+	ManufacturerID int32
+	Count          int64
+}
+
+func (q *Queries) GetEquipmentCounts(ctx context.Context) ([]GetEquipmentCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentCounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEquipmentCountsRow
+	for rows.Next() {
+		var i GetEquipmentCountsRow
+		if err := rows.Scan(&i.DeviceTypeID, &i.ManufacturerID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentForCount = `-- name: GetEquipmentForCount :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE ? IS NULL OR device_type_id = ?
+ORDER BY auto_id
+`
+
+type GetEquipmentForCountParams struct {
+	DeviceTypeID sql.NullInt32
+}
+
+func (q *Queries) GetEquipmentForCount(ctx context.Context, arg GetEquipmentForCountParams) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentForCount, arg.DeviceTypeID, arg.DeviceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentHeldByAssignee = `-- name: GetEquipmentHeldByAssignee :many
+SELECT serial_numbers.auto_id, serial_numbers.device_type_id, serial_numbers.manufacturer_id, serial_numbers.serial_number, serial_numbers.status, serial_numbers.lifecycle_state, serial_numbers.location_id, serial_numbers.product_model_id, serial_numbers.serial_canonical, serial_numbers.purchase_date, serial_numbers.vendor, serial_numbers.purchase_order, serial_numbers.cost, serial_numbers.warranty_start, serial_numbers.warranty_end, serial_numbers.last_seen_at, serial_numbers.last_seen_location_id, serial_numbers.created_at FROM serial_numbers
+JOIN equipment_assignments ON equipment_assignments.equipment_id = serial_numbers.auto_id
+WHERE equipment_assignments.assignee_id = ? AND equipment_assignments.checked_in_at IS NULL
+ORDER BY serial_numbers.auto_id
+`
+
+func (q *Queries) GetEquipmentHeldByAssignee(ctx context.Context, assigneeID int32) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentHeldByAssignee, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentLikeSerialNumber = `-- name: GetEquipmentLikeSerialNumber :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE serial_number LIKE ? OR REGEXP_LIKE(serial_canonical, ?)
+LIMIT 1000
+`
+
+type GetEquipmentLikeSerialNumberParams struct {
+	SerialNumber     string
+	CanonicalPattern string
+}
+
+func (q *Queries) GetEquipmentLikeSerialNumber(ctx context.Context, arg GetEquipmentLikeSerialNumberParams) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentLikeSerialNumber, arg.SerialNumber, arg.CanonicalPattern)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentPurchasedBy = `-- name: GetEquipmentPurchasedBy :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE purchase_date <= ? AND lifecycle_state <> 'disposed'
+ORDER BY auto_id
+`
+
+func (q *Queries) GetEquipmentPurchasedBy(ctx context.Context, asOf sql.NullTime) ([]SerialNumber, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentPurchasedBy, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SerialNumber
+	for rows.Next() {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentRelations = `-- name: GetEquipmentRelations :many
+SELECT serial_numbers.auto_id, device_type.id, device_type.name, device_type.status, manufacturer.id, manufacturer.name, manufacturer.status
+FROM serial_numbers
+JOIN device_type ON device_type.id = serial_numbers.device_type_id
+JOIN manufacturer ON manufacturer.id = serial_numbers.manufacturer_id
+WHERE serial_numbers.auto_id IN (/*SLICE:auto_ids*/?)
+`
+
+type GetEquipmentRelationsRow struct {
+	AutoID       int32
+	DeviceType   DeviceType
+	Manufacturer Manufacturer
+}
+
+func (q *Queries) GetEquipmentRelations(ctx context.Context, autoIds []int32) ([]GetEquipmentRelationsRow, error) {
+	query := getEquipmentRelations
+	var queryParams []interface{}
+	if len(autoIds) > 0 {
+		for _, v := range autoIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:auto_ids*/?", strings.Repeat(",?", len(autoIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:auto_ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEquipmentRelationsRow
+	for rows.Next() {
+		var i GetEquipmentRelationsRow
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceType.ID,
+			&i.DeviceType.Name,
+			&i.DeviceType.Status,
+			&i.Manufacturer.ID,
+			&i.Manufacturer.Name,
+			&i.Manufacturer.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentTags = `-- name: GetEquipmentTags :many
+SELECT tags.id, tags.name FROM tags
+JOIN equipment_tags ON equipment_tags.tag_id = tags.id
+WHERE equipment_tags.equipment_id = ?
+ORDER BY tags.name
+`
+
+func (q *Queries) GetEquipmentTags(ctx context.Context, equipmentID int32) ([]Tag, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentTags, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tag
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEquipmentWarrantyExpiring = `-- name: GetEquipmentWarrantyExpiring :many
+SELECT serial_numbers.auto_id, serial_numbers.device_type_id, serial_numbers.manufacturer_id, serial_numbers.serial_number, serial_numbers.status, serial_numbers.lifecycle_state, serial_numbers.location_id, serial_numbers.product_model_id, serial_numbers.serial_canonical, serial_numbers.purchase_date, serial_numbers.vendor, serial_numbers.purchase_order, serial_numbers.cost, serial_numbers.warranty_start, serial_numbers.warranty_end, serial_numbers.last_seen_at, serial_numbers.last_seen_location_id, serial_numbers.created_at, manufacturer.name AS manufacturer_name FROM serial_numbers
+JOIN manufacturer ON manufacturer.id = serial_numbers.manufacturer_id
+WHERE serial_numbers.warranty_end >= ? AND serial_numbers.warranty_end <= ?
+ORDER BY serial_numbers.manufacturer_id, serial_numbers.warranty_end, serial_numbers.auto_id
+`
+
+type GetEquipmentWarrantyExpiringParams struct {
+	From sql.NullTime
+	To   sql.NullTime
+}
+
+type GetEquipmentWarrantyExpiringRow struct {
+	SerialNumber     SerialNumber
+	ManufacturerName string
+}
+
+func (q *Queries) GetEquipmentWarrantyExpiring(ctx context.Context, arg GetEquipmentWarrantyExpiringParams) ([]GetEquipmentWarrantyExpiringRow, error) {
+	rows, err := q.db.QueryContext(ctx, getEquipmentWarrantyExpiring, arg.From, arg.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEquipmentWarrantyExpiringRow
+	for rows.Next() {
+		var i GetEquipmentWarrantyExpiringRow
+		if err := rows.Scan(
+			&i.SerialNumber.AutoID,
+			&i.SerialNumber.DeviceTypeID,
+			&i.SerialNumber.ManufacturerID,
+			&i.SerialNumber.SerialNumber,
+			&i.SerialNumber.Status,
+			&i.SerialNumber.LifecycleState,
+			&i.SerialNumber.LocationID,
+			&i.SerialNumber.ProductModelID,
+			&i.SerialNumber.SerialCanonical,
+			&i.SerialNumber.PurchaseDate,
+			&i.SerialNumber.Vendor,
+			&i.SerialNumber.PurchaseOrder,
+			&i.SerialNumber.Cost,
+			&i.SerialNumber.WarrantyStart,
+			&i.SerialNumber.WarrantyEnd,
+			&i.SerialNumber.LastSeenAt,
+			&i.SerialNumber.LastSeenLocationID,
+			&i.SerialNumber.CreatedAt,
+			&i.ManufacturerName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFuzzySerialCandidates = `-- name: GetFuzzySerialCandidates :many
+SELECT auto_id, serial_canonical, manufacturer_id FROM serial_numbers
+WHERE CHAR_LENGTH(serial_canonical) >= CAST(? AS SIGNED) AND CHAR_LENGTH(serial_canonical) <= CAST(? AS SIGNED)
+AND (? IS NULL OR device_type_id = ?)
+AND (? IS NULL OR manufacturer_id = ?)
+AND (? IS NULL OR status = ?)
+AND (? IS NULL OR REGEXP_LIKE(serial_canonical, ?))
+`
+
+type GetFuzzySerialCandidatesParams struct {
+	MinLength      int64
+	MaxLength      int64
+	DeviceTypeID   sql.NullInt32
+	ManufacturerID sql.NullInt32
+	Status         NullSerialNumbersStatus
+	Pieces         sql.NullString
+}
+
+type GetFuzzySerialCandidatesRow struct {
+	AutoID          int32
+	SerialCanonical string
+	ManufacturerID  int32
+}
+
+// FUZZY SEARCH QUERIES
+func (q *Queries) GetFuzzySerialCandidates(ctx context.Context, arg GetFuzzySerialCandidatesParams) ([]GetFuzzySerialCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFuzzySerialCandidates,
+		arg.MinLength,
+		arg.MaxLength,
+		arg.DeviceTypeID,
+		arg.DeviceTypeID,
+		arg.ManufacturerID,
+		arg.ManufacturerID,
+		arg.Status,
+		arg.Status,
+		arg.Pieces,
+		arg.Pieces,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFuzzySerialCandidatesRow
+	for rows.Next() {
+		var i GetFuzzySerialCandidatesRow
+		if err := rows.Scan(&i.AutoID, &i.SerialCanonical, &i.ManufacturerID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLifecycleTransitions = `-- name: GetLifecycleTransitions :many
+SELECT id, equipment_id, from_state, to_state, reason, created_at FROM lifecycle_transitions
+WHERE equipment_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetLifecycleTransitions(ctx context.Context, equipmentID int32) ([]LifecycleTransition, error) {
+	rows, err := q.db.QueryContext(ctx, getLifecycleTransitions, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LifecycleTransition
+	for rows.Next() {
+		var i LifecycleTransition
+		if err := rows.Scan(
+			&i.ID,
+			&i.EquipmentID,
+			&i.FromState,
+			&i.ToState,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLocationByID = `-- name: GetLocationByID :one
+SELECT id, parent_id, name, kind FROM locations
+WHERE id = ?
+`
+
+func (q *Queries) GetLocationByID(ctx context.Context, id int32) (Location, error) {
+	row := q.db.QueryRowContext(ctx, getLocationByID, id)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.ParentID,
+		&i.Name,
+		&i.Kind,
+	)
+	return i, err
+}
+
+const getLocationMoves = `-- name: GetLocationMoves :many
+SELECT id, equipment_id, from_location_id, to_location_id, reason, created_at FROM location_moves
+WHERE equipment_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetLocationMoves(ctx context.Context, equipmentID int32) ([]LocationMove, error) {
+	rows, err := q.db.QueryContext(ctx, getLocationMoves, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LocationMove
+	for rows.Next() {
+		var i LocationMove
+		if err := rows.Scan(
+			&i.ID,
+			&i.EquipmentID,
+			&i.FromLocationID,
+			&i.ToLocationID,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLocations = `-- name: GetLocations :many
+SELECT id, parent_id, name, kind FROM locations
+ORDER BY id
+`
+
+// LOCATION QUERIES
+func (q *Queries) GetLocations(ctx context.Context) ([]Location, error) {
+	rows, err := q.db.QueryContext(ctx, getLocations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Location
+	for rows.Next() {
+		var i Location
+		if err := rows.Scan(
+			&i.ID,
+			&i.ParentID,
+			&i.Name,
+			&i.Kind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMaintenanceTicketByID = `-- name: GetMaintenanceTicketByID :one
+SELECT id, equipment_id, issue, vendor, opened_at, closed_at, cost, outcome, return_state, created_at FROM maintenance_tickets
+WHERE id = ?
+`
+
+func (q *Queries) GetMaintenanceTicketByID(ctx context.Context, id int32) (MaintenanceTicket, error) {
+	row := q.db.QueryRowContext(ctx, getMaintenanceTicketByID, id)
+	var i MaintenanceTicket
+	err := row.Scan(
+		&i.ID,
+		&i.EquipmentID,
+		&i.Issue,
+		&i.Vendor,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.Cost,
+		&i.Outcome,
+		&i.ReturnState,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMaintenanceTicketByIDForUpdate = `-- name: GetMaintenanceTicketByIDForUpdate :one
+SELECT id, equipment_id, issue, vendor, opened_at, closed_at, cost, outcome, return_state, created_at FROM maintenance_tickets
+WHERE id = ? FOR UPDATE
+`
+
+func (q *Queries) GetMaintenanceTicketByIDForUpdate(ctx context.Context, id int32) (MaintenanceTicket, error) {
+	row := q.db.QueryRowContext(ctx, getMaintenanceTicketByIDForUpdate, id)
+	var i MaintenanceTicket
+	err := row.Scan(
+		&i.ID,
+		&i.EquipmentID,
+		&i.Issue,
+		&i.Vendor,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.Cost,
+		&i.Outcome,
+		&i.ReturnState,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMaintenanceTickets = `-- name: GetMaintenanceTickets :many
+SELECT id, equipment_id, issue, vendor, opened_at, closed_at, cost, outcome, return_state, created_at FROM maintenance_tickets
+ORDER BY id
+`
+
+// MAINTENANCE QUERIES
+func (q *Queries) GetMaintenanceTickets(ctx context.Context) ([]MaintenanceTicket, error) {
+	rows, err := q.db.QueryContext(ctx, getMaintenanceTickets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MaintenanceTicket
+	for rows.Next() {
+		var i MaintenanceTicket
+		if err := rows.Scan(
+			&i.ID,
+			&i.EquipmentID,
+			&i.Issue,
+			&i.Vendor,
+			&i.OpenedAt,
+			&i.ClosedAt,
+			&i.Cost,
+			&i.Outcome,
+			&i.ReturnState,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMaintenanceTicketsByEquipment = `-- name: GetMaintenanceTicketsByEquipment :many
+SELECT id, equipment_id, issue, vendor, opened_at, closed_at, cost, outcome, return_state, created_at FROM maintenance_tickets
+WHERE equipment_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetMaintenanceTicketsByEquipment(ctx context.Context, equipmentID int32) ([]MaintenanceTicket, error) {
+	rows, err := q.db.QueryContext(ctx, getMaintenanceTicketsByEquipment, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MaintenanceTicket
+	for rows.Next() {
+		var i MaintenanceTicket
+		if err := rows.Scan(
+			&i.ID,
+			&i.EquipmentID,
+			&i.Issue,
+			&i.Vendor,
+			&i.OpenedAt,
+			&i.ClosedAt,
+			&i.Cost,
+			&i.Outcome,
+			&i.ReturnState,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMaintenanceTicketsOpenedBetween = `-- name: GetMaintenanceTicketsOpenedBetween :many
+SELECT maintenance_tickets.id, maintenance_tickets.equipment_id, maintenance_tickets.issue, maintenance_tickets.vendor, maintenance_tickets.opened_at, maintenance_tickets.closed_at, maintenance_tickets.cost, maintenance_tickets.outcome, maintenance_tickets.return_state, maintenance_tickets.created_at, serial_numbers.device_type_id, serial_numbers.manufacturer_id FROM maintenance_tickets
+JOIN serial_numbers ON serial_numbers.auto_id = maintenance_tickets.equipment_id
+WHERE maintenance_tickets.opened_at >= ? AND maintenance_tickets.opened_at <= ?
+`
+
+type GetMaintenanceTicketsOpenedBetweenParams struct {
+	From time.Time
+	To   time.Time
+}
+
+type GetMaintenanceTicketsOpenedBetweenRow struct {
+	MaintenanceTicket MaintenanceTicket
+	DeviceTypeID      int32
+	ManufacturerID    int32
+}
+
+func (q *Queries) GetMaintenanceTicketsOpenedBetween(ctx context.Context, arg GetMaintenanceTicketsOpenedBetweenParams) ([]GetMaintenanceTicketsOpenedBetweenRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMaintenanceTicketsOpenedBetween, arg.From, arg.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMaintenanceTicketsOpenedBetweenRow
+	for rows.Next() {
+		var i GetMaintenanceTicketsOpenedBetweenRow
+		if err := rows.Scan(
+			&i.MaintenanceTicket.ID,
+			&i.MaintenanceTicket.EquipmentID,
+			&i.MaintenanceTicket.Issue,
+			&i.MaintenanceTicket.Vendor,
+			&i.MaintenanceTicket.OpenedAt,
+			&i.MaintenanceTicket.ClosedAt,
+			&i.MaintenanceTicket.Cost,
+			&i.MaintenanceTicket.Outcome,
+			&i.MaintenanceTicket.ReturnState,
+			&i.MaintenanceTicket.CreatedAt,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getManufacturerAttachments = `-- name: GetManufacturerAttachments :many
+SELECT id, equipment_id, manufacturer_id, kind, filename, content_type, size, sha256, storage_key, created_at FROM attachments
+WHERE manufacturer_id = ?
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) GetManufacturerAttachments(ctx context.Context, manufacturerID sql.NullInt32) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, getManufacturerAttachments, manufacturerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.EquipmentID,
+			&i.ManufacturerID,
+			&i.Kind,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.StorageKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getManufacturerById = `-- name: GetManufacturerById :one
+SELECT id, name, status FROM manufacturer
+WHERE id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetManufacturerById(ctx context.Context, id int32) (Manufacturer, error) {
+	row := q.db.QueryRowContext(ctx, getManufacturerById, id)
+	var i Manufacturer
+	err := row.Scan(&i.ID, &i.Name, &i.Status)
+	return i, err
+}
+
+const getManufacturerByName = `-- name: GetManufacturerByName :one
+SELECT id, name, status FROM manufacturer
+WHERE name = ?
+ORDER BY id
+`
+
+func (q *Queries) GetManufacturerByName(ctx context.Context, name string) (Manufacturer, error) {
+	row := q.db.QueryRowContext(ctx, getManufacturerByName, name)
+	var i Manufacturer
+	err := row.Scan(&i.ID, &i.Name, &i.Status)
+	return i, err
+}
+
+const getManufacturers = `-- name: GetManufacturers :many
+SELECT id, name, status FROM manufacturer
+ORDER BY id
+`
+
+// MANUFACTURER QUERIES
+func (q *Queries) GetManufacturers(ctx context.Context) ([]Manufacturer, error) {
+	rows, err := q.db.QueryContext(ctx, getManufacturers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Manufacturer
+	for rows.Next() {
+		var i Manufacturer
+		if err := rows.Scan(&i.ID, &i.Name, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenAssignmentByEquipment = `-- name: GetOpenAssignmentByEquipment :one
+SELECT id, equipment_id, assignee_id, checked_out_at, checked_in_at, note, checkin_note FROM equipment_assignments
+WHERE equipment_id = ? AND checked_in_at IS NULL
 `
 
-func (q *Queries) DeleteDeviceType(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, deleteDeviceType, id)
-	return err
+// ASSIGNMENT QUERIES
+func (q *Queries) GetOpenAssignmentByEquipment(ctx context.Context, equipmentID int32) (EquipmentAssignment, error) {
+	row := q.db.QueryRowContext(ctx, getOpenAssignmentByEquipment, equipmentID)
+	var i EquipmentAssignment
+	err := row.Scan(
+		&i.ID,
+		&i.EquipmentID,
+		&i.AssigneeID,
+		&i.CheckedOutAt,
+		&i.CheckedInAt,
+		&i.Note,
+		&i.CheckinNote,
+	)
+	return i, err
 }
 
-const deleteManufacturer = `-- name: DeleteManufacturer :exec
-DELETE FROM manufacturer
-WHERE id = ?
+const getOpenMaintenanceTicketByEquipment = `-- name: GetOpenMaintenanceTicketByEquipment :one
+SELECT id, equipment_id, issue, vendor, opened_at, closed_at, cost, outcome, return_state, created_at FROM maintenance_tickets
+WHERE equipment_id = ? AND closed_at IS NULL
+LIMIT 1
 `
 
-func (q *Queries) DeleteManufacturer(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, deleteManufacturer, id)
-	return err
+func (q *Queries) GetOpenMaintenanceTicketByEquipment(ctx context.Context, equipmentID int32) (MaintenanceTicket, error) {
+	row := q.db.QueryRowContext(ctx, getOpenMaintenanceTicketByEquipment, equipmentID)
+	var i MaintenanceTicket
+	err := row.Scan(
+		&i.ID,
+		&i.EquipmentID,
+		&i.Issue,
+		&i.Vendor,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.Cost,
+		&i.Outcome,
+		&i.ReturnState,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
-const getAllEquipment = `-- name: GetAllEquipment :many
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-LIMIT 1000
+const getOpenMaintenanceTickets = `-- name: GetOpenMaintenanceTickets :many
+SELECT id, equipment_id, issue, vendor, opened_at, closed_at, cost, outcome, return_state, created_at FROM maintenance_tickets
+WHERE closed_at IS NULL
+ORDER BY id
 `
 
-// EQUIPMENT QUERIES
-func (q *Queries) GetAllEquipment(ctx context.Context) ([]SerialNumber, error) {
-	rows, err := q.db.QueryContext(ctx, getAllEquipment)
+func (q *Queries) GetOpenMaintenanceTickets(ctx context.Context) ([]MaintenanceTicket, error) {
+	rows, err := q.db.QueryContext(ctx, getOpenMaintenanceTickets)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SerialNumber
+	var items []MaintenanceTicket
 	for rows.Next() {
-		var i SerialNumber
+		var i MaintenanceTicket
 		if err := rows.Scan(
-			&i.AutoID,
-			&i.DeviceTypeID,
-			&i.ManufacturerID,
-			&i.SerialNumber,
-			&i.Status,
+			&i.ID,
+			&i.EquipmentID,
+			&i.Issue,
+			&i.Vendor,
+			&i.OpenedAt,
+			&i.ClosedAt,
+			&i.Cost,
+			&i.Outcome,
+			&i.ReturnState,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -97,48 +3054,84 @@ func (q *Queries) GetAllEquipment(ctx context.Context) ([]SerialNumber, error) {
 	return items, nil
 }
 
-const getDeviceTypeById = `-- name: GetDeviceTypeById :one
-SELECT id, name, status FROM device_type
+const getProductModelByID = `-- name: GetProductModelByID :one
+SELECT id, manufacturer_id, device_type_id, model_number, name, specs FROM product_models
 WHERE id = ?
-ORDER BY id
 `
 
-func (q *Queries) GetDeviceTypeById(ctx context.Context, id int32) (DeviceType, error) {
-	row := q.db.QueryRowContext(ctx, getDeviceTypeById, id)
-	var i DeviceType
-	err := row.Scan(&i.ID, &i.Name, &i.Status)
+func (q *Queries) GetProductModelByID(ctx context.Context, id int32) (ProductModel, error) {
+	row := q.db.QueryRowContext(ctx, getProductModelByID, id)
+	var i ProductModel
+	err := row.Scan(
+		&i.ID,
+		&i.ManufacturerID,
+		&i.DeviceTypeID,
+		&i.ModelNumber,
+		&i.Name,
+		&i.Specs,
+	)
 	return i, err
 }
 
-const getDeviceTypeByName = `-- name: GetDeviceTypeByName :one
-SELECT id, name, status FROM device_type
-WHERE name = ?
-ORDER BY id
+const getProductModelByModelNumber = `-- name: GetProductModelByModelNumber :one
+SELECT id, manufacturer_id, device_type_id, model_number, name, specs FROM product_models
+WHERE manufacturer_id = ? AND model_number = ?
 `
 
-func (q *Queries) GetDeviceTypeByName(ctx context.Context, name string) (DeviceType, error) {
-	row := q.db.QueryRowContext(ctx, getDeviceTypeByName, name)
-	var i DeviceType
-	err := row.Scan(&i.ID, &i.Name, &i.Status)
+type GetProductModelByModelNumberParams struct {
+	ManufacturerID int32
+	ModelNumber    string
+}
+
+func (q *Queries) GetProductModelByModelNumber(ctx context.Context, arg GetProductModelByModelNumberParams) (ProductModel, error) {
+	row := q.db.QueryRowContext(ctx, getProductModelByModelNumber, arg.ManufacturerID, arg.ModelNumber)
+	var i ProductModel
+	err := row.Scan(
+		&i.ID,
+		&i.ManufacturerID,
+		&i.DeviceTypeID,
+		&i.ModelNumber,
+		&i.Name,
+		&i.Specs,
+	)
 	return i, err
 }
 
-const getDeviceTypesActive = `-- name: GetDeviceTypesActive :many
-SELECT id, name, status FROM device_type
+const getProductModels = `-- name: GetProductModels :many
+SELECT id, manufacturer_id, device_type_id, model_number, name, specs FROM product_models
+WHERE (? IS NULL OR manufacturer_id = ?)
+AND (? IS NULL OR device_type_id = ?)
 ORDER BY id
 `
 
-// DEVICETYPE QUERIES
-func (q *Queries) GetDeviceTypesActive(ctx context.Context) ([]DeviceType, error) {
-	rows, err := q.db.QueryContext(ctx, getDeviceTypesActive)
+type GetProductModelsParams struct {
+	ManufacturerID sql.NullInt32
+	DeviceTypeID   sql.NullInt32
+}
+
+// PRODUCT MODEL QUERIES
+func (q *Queries) GetProductModels(ctx context.Context, arg GetProductModelsParams) ([]ProductModel, error) {
+	rows, err := q.db.QueryContext(ctx, getProductModels,
+		arg.ManufacturerID,
+		arg.ManufacturerID,
+		arg.DeviceTypeID,
+		arg.DeviceTypeID,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []DeviceType
+	var items []ProductModel
 	for rows.Next() {
-		var i DeviceType
-		if err := rows.Scan(&i.ID, &i.Name, &i.Status); err != nil {
+		var i ProductModel
+		if err := rows.Scan(
+			&i.ID,
+			&i.ManufacturerID,
+			&i.DeviceTypeID,
+			&i.ModelNumber,
+			&i.Name,
+			&i.Specs,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -152,45 +3145,42 @@ func (q *Queries) GetDeviceTypesActive(ctx context.Context) ([]DeviceType, error
 	return items, nil
 }
 
-const getEquipmentByAutoID = `-- name: GetEquipmentByAutoID :one
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE auto_id = ?
+const getSerialNormalization = `-- name: GetSerialNormalization :one
+SELECT manufacturer_id, trim, case_fold, separators FROM serial_normalizations
+WHERE manufacturer_id = ?
 `
 
-func (q *Queries) GetEquipmentByAutoID(ctx context.Context, autoID int32) (SerialNumber, error) {
-	row := q.db.QueryRowContext(ctx, getEquipmentByAutoID, autoID)
-	var i SerialNumber
+func (q *Queries) GetSerialNormalization(ctx context.Context, manufacturerID int32) (SerialNormalization, error) {
+	row := q.db.QueryRowContext(ctx, getSerialNormalization, manufacturerID)
+	var i SerialNormalization
 	err := row.Scan(
-		&i.AutoID,
-		&i.DeviceTypeID,
 		&i.ManufacturerID,
-		&i.SerialNumber,
-		&i.Status,
+		&i.Trim,
+		&i.CaseFold,
+		&i.Separators,
 	)
 	return i, err
 }
 
-const getEquipmentByDeviceType = `-- name: GetEquipmentByDeviceType :many
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE device_type_id = ?
-LIMIT 1000
+const getSerialNormalizations = `-- name: GetSerialNormalizations :many
+SELECT manufacturer_id, trim, case_fold, separators FROM serial_normalizations
 `
 
-func (q *Queries) GetEquipmentByDeviceType(ctx context.Context, deviceTypeID int32) ([]SerialNumber, error) {
-	rows, err := q.db.QueryContext(ctx, getEquipmentByDeviceType, deviceTypeID)
+// SERIAL NORMALIZATION QUERIES
+func (q *Queries) GetSerialNormalizations(ctx context.Context) ([]SerialNormalization, error) {
+	rows, err := q.db.QueryContext(ctx, getSerialNormalizations)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SerialNumber
+	var items []SerialNormalization
 	for rows.Next() {
-		var i SerialNumber
+		var i SerialNormalization
 		if err := rows.Scan(
-			&i.AutoID,
-			&i.DeviceTypeID,
 			&i.ManufacturerID,
-			&i.SerialNumber,
-			&i.Status,
+			&i.Trim,
+			&i.CaseFold,
+			&i.Separators,
 		); err != nil {
 			return nil, err
 		}
@@ -205,36 +3195,36 @@ func (q *Queries) GetEquipmentByDeviceType(ctx context.Context, deviceTypeID int
 	return items, nil
 }
 
-const getEquipmentByDeviceTypeAndManufacturer = `-- name: GetEquipmentByDeviceTypeAndManufacturer :many
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE device_type_id = ? AND manufacturer_id = ?
-LIMIT 1000
+const getSerialNumberBySerialNumber = `-- name: GetSerialNumberBySerialNumber :one
+SELECT serial_number FROM serial_numbers
+WHERE serial_number = ?
 `
 
-type GetEquipmentByDeviceTypeAndManufacturerParams struct {
-	DeviceTypeID   int32
-	ManufacturerID int32
+func (q *Queries) GetSerialNumberBySerialNumber(ctx context.Context, serialNumber string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getSerialNumberBySerialNumber, serialNumber)
+	var serial_number string
+	err := row.Scan(&serial_number)
+	return serial_number, err
 }
 
-func (q *Queries) GetEquipmentByDeviceTypeAndManufacturer(ctx context.Context, arg GetEquipmentByDeviceTypeAndManufacturerParams) ([]SerialNumber, error) {
-	rows, err := q.db.QueryContext(ctx, getEquipmentByDeviceTypeAndManufacturer, arg.DeviceTypeID, arg.ManufacturerID)
+const getSerialNumberLikeSerialNumber = `-- name: GetSerialNumberLikeSerialNumber :many
+SELECT serial_number FROM serial_numbers
+WHERE serial_number LIKE ?
+`
+
+func (q *Queries) GetSerialNumberLikeSerialNumber(ctx context.Context, serialNumber string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getSerialNumberLikeSerialNumber, serialNumber)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SerialNumber
+	var items []string
 	for rows.Next() {
-		var i SerialNumber
-		if err := rows.Scan(
-			&i.AutoID,
-			&i.DeviceTypeID,
-			&i.ManufacturerID,
-			&i.SerialNumber,
-			&i.Status,
-		); err != nil {
+		var serial_number string
+		if err := rows.Scan(&serial_number); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, serial_number)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -245,79 +3235,53 @@ func (q *Queries) GetEquipmentByDeviceTypeAndManufacturer(ctx context.Context, a
 	return items, nil
 }
 
-const getEquipmentByDeviceTypeAndSerialNumber = `-- name: GetEquipmentByDeviceTypeAndSerialNumber :one
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE device_type_id = ? AND serial_number = ?
+const getSerialNumberRuleByID = `-- name: GetSerialNumberRuleByID :one
+SELECT id, name, manufacturer_id, device_type_id, pattern, min_length, max_length, prefix, checksum FROM serial_number_rules
+WHERE id = ?
 `
 
-type GetEquipmentByDeviceTypeAndSerialNumberParams struct {
-	DeviceTypeID int32
-	SerialNumber string
-}
-
-func (q *Queries) GetEquipmentByDeviceTypeAndSerialNumber(ctx context.Context, arg GetEquipmentByDeviceTypeAndSerialNumberParams) (SerialNumber, error) {
-	row := q.db.QueryRowContext(ctx, getEquipmentByDeviceTypeAndSerialNumber, arg.DeviceTypeID, arg.SerialNumber)
-	var i SerialNumber
+func (q *Queries) GetSerialNumberRuleByID(ctx context.Context, id int32) (SerialNumberRule, error) {
+	row := q.db.QueryRowContext(ctx, getSerialNumberRuleByID, id)
+	var i SerialNumberRule
 	err := row.Scan(
-		&i.AutoID,
-		&i.DeviceTypeID,
+		&i.ID,
+		&i.Name,
 		&i.ManufacturerID,
-		&i.SerialNumber,
-		&i.Status,
-	)
-	return i, err
-}
-
-const getEquipmentByDeviceTypeManufacturerAndSerialNumber = `-- name: GetEquipmentByDeviceTypeManufacturerAndSerialNumber :one
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE device_type_id = ? AND manufacturer_id = ? AND serial_number = ?
-`
-
-type GetEquipmentByDeviceTypeManufacturerAndSerialNumberParams struct {
-	DeviceTypeID   int32
-	ManufacturerID int32
-	SerialNumber   string
-}
-
-func (q *Queries) GetEquipmentByDeviceTypeManufacturerAndSerialNumber(ctx context.Context, arg GetEquipmentByDeviceTypeManufacturerAndSerialNumberParams) (SerialNumber, error) {
-	row := q.db.QueryRowContext(ctx, getEquipmentByDeviceTypeManufacturerAndSerialNumber, arg.DeviceTypeID, arg.ManufacturerID, arg.SerialNumber)
-	var i SerialNumber
-	err := row.Scan(
-		&i.AutoID,
 		&i.DeviceTypeID,
-		&i.ManufacturerID,
-		&i.SerialNumber,
-		&i.Status,
+		&i.Pattern,
+		&i.MinLength,
+		&i.MaxLength,
+		&i.Prefix,
+		&i.Checksum,
 	)
 	return i, err
 }
 
-const getEquipmentByDeviceTypeManufacturerLikeSerialNumber = `-- name: GetEquipmentByDeviceTypeManufacturerLikeSerialNumber :many
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE device_type_id = ? AND manufacturer_id = ? AND serial_number LIKE ? LIMIT 1000
+const getSerialNumberRules = `-- name: GetSerialNumberRules :many
+SELECT id, name, manufacturer_id, device_type_id, pattern, min_length, max_length, prefix, checksum FROM serial_number_rules
+ORDER BY id
 `
 
-type GetEquipmentByDeviceTypeManufacturerLikeSerialNumberParams struct {
-	DeviceTypeID   int32
-	ManufacturerID int32
-	SerialNumber   string
-}
-
-func (q *Queries) GetEquipmentByDeviceTypeManufacturerLikeSerialNumber(ctx context.Context, arg GetEquipmentByDeviceTypeManufacturerLikeSerialNumberParams) ([]SerialNumber, error) {
-	rows, err := q.db.QueryContext(ctx, getEquipmentByDeviceTypeManufacturerLikeSerialNumber, arg.DeviceTypeID, arg.ManufacturerID, arg.SerialNumber)
+// SERIAL NUMBER RULE QUERIES
+func (q *Queries) GetSerialNumberRules(ctx context.Context) ([]SerialNumberRule, error) {
+	rows, err := q.db.QueryContext(ctx, getSerialNumberRules)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SerialNumber
+	var items []SerialNumberRule
 	for rows.Next() {
-		var i SerialNumber
+		var i SerialNumberRule
 		if err := rows.Scan(
-			&i.AutoID,
-			&i.DeviceTypeID,
+			&i.ID,
+			&i.Name,
 			&i.ManufacturerID,
-			&i.SerialNumber,
-			&i.Status,
+			&i.DeviceTypeID,
+			&i.Pattern,
+			&i.MinLength,
+			&i.MaxLength,
+			&i.Prefix,
+			&i.Checksum,
 		); err != nil {
 			return nil, err
 		}
@@ -332,31 +3296,30 @@ func (q *Queries) GetEquipmentByDeviceTypeManufacturerLikeSerialNumber(ctx conte
 	return items, nil
 }
 
-const getEquipmentByManufacturer = `-- name: GetEquipmentByManufacturer :many
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE manufacturer_id = ?
-LIMIT 1000
+const getSerialNumbers = `-- name: GetSerialNumbers :many
+SELECT serial_number FROM serial_numbers
+LIMIT ? OFFSET ?
 `
 
-func (q *Queries) GetEquipmentByManufacturer(ctx context.Context, manufacturerID int32) ([]SerialNumber, error) {
-	rows, err := q.db.QueryContext(ctx, getEquipmentByManufacturer, manufacturerID)
+type GetSerialNumbersParams struct {
+	Limit  int32
+	Offset int32
+}
+
+// SERIALNUMBER QUERIES
+func (q *Queries) GetSerialNumbers(ctx context.Context, arg GetSerialNumbersParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getSerialNumbers, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SerialNumber
+	var items []string
 	for rows.Next() {
-		var i SerialNumber
-		if err := rows.Scan(
-			&i.AutoID,
-			&i.DeviceTypeID,
-			&i.ManufacturerID,
-			&i.SerialNumber,
-			&i.Status,
-		); err != nil {
+		var serial_number string
+		if err := rows.Scan(&serial_number); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, serial_number)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -367,69 +3330,77 @@ func (q *Queries) GetEquipmentByManufacturer(ctx context.Context, manufacturerID
 	return items, nil
 }
 
-const getEquipmentByManufacturerAndSerialNumber = `-- name: GetEquipmentByManufacturerAndSerialNumber :one
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE manufacturer_id = ? AND serial_number = ?
+const getSerialNumbersByManufacturer = `-- name: GetSerialNumbersByManufacturer :many
+SELECT auto_id, serial_number, serial_canonical FROM serial_numbers
+WHERE manufacturer_id = ?
+ORDER BY auto_id
 `
 
-type GetEquipmentByManufacturerAndSerialNumberParams struct {
-	ManufacturerID int32
-	SerialNumber   string
-}
-
-func (q *Queries) GetEquipmentByManufacturerAndSerialNumber(ctx context.Context, arg GetEquipmentByManufacturerAndSerialNumberParams) (SerialNumber, error) {
-	row := q.db.QueryRowContext(ctx, getEquipmentByManufacturerAndSerialNumber, arg.ManufacturerID, arg.SerialNumber)
-	var i SerialNumber
-	err := row.Scan(
-		&i.AutoID,
-		&i.DeviceTypeID,
-		&i.ManufacturerID,
-		&i.SerialNumber,
-		&i.Status,
-	)
-	return i, err
+type GetSerialNumbersByManufacturerRow struct {
+	AutoID          int32
+	SerialNumber    string
+	SerialCanonical string
 }
 
-const getEquipmentBySerialNumber = `-- name: GetEquipmentBySerialNumber :one
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE serial_number = ?
-`
-
-func (q *Queries) GetEquipmentBySerialNumber(ctx context.Context, serialNumber string) (SerialNumber, error) {
-	row := q.db.QueryRowContext(ctx, getEquipmentBySerialNumber, serialNumber)
-	var i SerialNumber
-	err := row.Scan(
-		&i.AutoID,
-		&i.DeviceTypeID,
-		&i.ManufacturerID,
-		&i.SerialNumber,
-		&i.Status,
-	)
+func (q *Queries) GetSerialNumbersByManufacturer(ctx context.Context, manufacturerID int32) ([]GetSerialNumbersByManufacturerRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSerialNumbersByManufacturer, manufacturerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSerialNumbersByManufacturerRow
+	for rows.Next() {
+		var i GetSerialNumbersByManufacturerRow
+		if err := rows.Scan(&i.AutoID, &i.SerialNumber, &i.SerialCanonical); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTagByID = `-- name: GetTagByID :one
+SELECT id, name FROM tags
+WHERE id = ?
+`
+
+func (q *Queries) GetTagByID(ctx context.Context, id int32) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, getTagByID, id)
+	var i Tag
+	err := row.Scan(&i.ID, &i.Name)
 	return i, err
 }
 
-const getEquipmentLikeSerialNumber = `-- name: GetEquipmentLikeSerialNumber :many
-SELECT auto_id, device_type_id, manufacturer_id, serial_number, status FROM serial_numbers
-WHERE serial_number LIKE ?
-LIMIT 1000
+const getTags = `-- name: GetTags :many
+SELECT tags.id, tags.name, COUNT(equipment_tags.equipment_id) AS count FROM tags
+LEFT JOIN equipment_tags ON equipment_tags.tag_id = tags.id
+GROUP BY tags.id, tags.name
+ORDER BY tags.name
 `
 
-func (q *Queries) GetEquipmentLikeSerialNumber(ctx context.Context, serialNumber string) ([]SerialNumber, error) {
-	rows, err := q.db.QueryContext(ctx, getEquipmentLikeSerialNumber, serialNumber)
+type GetTagsRow struct {
+	ID    int32
+	Name  string
+	Count int64
+}
+
+// TAG QUERIES
+func (q *Queries) GetTags(ctx context.Context) ([]GetTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTags)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SerialNumber
+	var items []GetTagsRow
 	for rows.Next() {
-		var i SerialNumber
-		if err := rows.Scan(
-			&i.AutoID,
-			&i.DeviceTypeID,
-			&i.ManufacturerID,
-			&i.SerialNumber,
-			&i.Status,
-		); err != nil {
+		var i GetTagsRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Count); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -443,47 +3414,75 @@ func (q *Queries) GetEquipmentLikeSerialNumber(ctx context.Context, serialNumber
 	return items, nil
 }
 
-const getManufacturerById = `-- name: GetManufacturerById :one
-SELECT id, name, status FROM manufacturer
-WHERE id = ?
-ORDER BY id
+const getTagsByNames = `-- name: GetTagsByNames :many
+SELECT id, name FROM tags
+WHERE name IN (/*SLICE:names*/?)
 `
 
-func (q *Queries) GetManufacturerById(ctx context.Context, id int32) (Manufacturer, error) {
-	row := q.db.QueryRowContext(ctx, getManufacturerById, id)
-	var i Manufacturer
-	err := row.Scan(&i.ID, &i.Name, &i.Status)
-	return i, err
+func (q *Queries) GetTagsByNames(ctx context.Context, names []string) ([]Tag, error) {
+	query := getTagsByNames
+	var queryParams []interface{}
+	if len(names) > 0 {
+		for _, v := range names {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:names*/?", strings.Repeat(",?", len(names))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:names*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tag
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getManufacturerByName = `-- name: GetManufacturerByName :one
-SELECT id, name, status FROM manufacturer
-WHERE name = ?
+const listDeviceTypes = `-- name: ListDeviceTypes :many
+SELECT id, name, status FROM device_type
+WHERE (? IS NULL OR status = ?)
+AND (? IS NULL OR LOWER(name) LIKE LOWER(?))
 ORDER BY id
+LIMIT ? OFFSET ?
 `
 
-func (q *Queries) GetManufacturerByName(ctx context.Context, name string) (Manufacturer, error) {
-	row := q.db.QueryRowContext(ctx, getManufacturerByName, name)
-	var i Manufacturer
-	err := row.Scan(&i.ID, &i.Name, &i.Status)
-	return i, err
+type ListDeviceTypesParams struct {
+	Status NullDeviceTypeStatus
+	Name   sql.NullString
+	Limit  int32
+	Offset int32
 }
 
-const getManufacturersActive = `-- name: GetManufacturersActive :many
-SELECT id, name, status FROM manufacturer
-ORDER BY id
-`
-
-// MANUFACTURER QUERIES
-func (q *Queries) GetManufacturersActive(ctx context.Context) ([]Manufacturer, error) {
-	rows, err := q.db.QueryContext(ctx, getManufacturersActive)
+func (q *Queries) ListDeviceTypes(ctx context.Context, arg ListDeviceTypesParams) ([]DeviceType, error) {
+	rows, err := q.db.QueryContext(ctx, listDeviceTypes,
+		arg.Status,
+		arg.Status,
+		arg.Name,
+		arg.Name,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Manufacturer
+	var items []DeviceType
 	for rows.Next() {
-		var i Manufacturer
+		var i DeviceType
 		if err := rows.Scan(&i.ID, &i.Name, &i.Status); err != nil {
 			return nil, err
 		}
@@ -498,36 +3497,41 @@ func (q *Queries) GetManufacturersActive(ctx context.Context) ([]Manufacturer, e
 	return items, nil
 }
 
-const getSerialNumberBySerialNumber = `-- name: GetSerialNumberBySerialNumber :one
-SELECT serial_number FROM serial_numbers
-WHERE serial_number = ?
+const listManufacturers = `-- name: ListManufacturers :many
+SELECT id, name, status FROM manufacturer
+WHERE (? IS NULL OR status = ?)
+AND (? IS NULL OR LOWER(name) LIKE LOWER(?))
+ORDER BY id
+LIMIT ? OFFSET ?
 `
 
-func (q *Queries) GetSerialNumberBySerialNumber(ctx context.Context, serialNumber string) (string, error) {
-	row := q.db.QueryRowContext(ctx, getSerialNumberBySerialNumber, serialNumber)
-	var serial_number string
-	err := row.Scan(&serial_number)
-	return serial_number, err
+type ListManufacturersParams struct {
+	Status NullManufacturerStatus
+	Name   sql.NullString
+	Limit  int32
+	Offset int32
 }
 
-const getSerialNumberLikeSerialNumber = `-- name: GetSerialNumberLikeSerialNumber :many
-SELECT serial_number FROM serial_numbers
-WHERE serial_number LIKE ?
-`
-
-func (q *Queries) GetSerialNumberLikeSerialNumber(ctx context.Context, serialNumber string) ([]string, error) {
-	rows, err := q.db.QueryContext(ctx, getSerialNumberLikeSerialNumber, serialNumber)
+func (q *Queries) ListManufacturers(ctx context.Context, arg ListManufacturersParams) ([]Manufacturer, error) {
+	rows, err := q.db.QueryContext(ctx, listManufacturers,
+		arg.Status,
+		arg.Status,
+		arg.Name,
+		arg.Name,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []Manufacturer
 	for rows.Next() {
-		var serial_number string
-		if err := rows.Scan(&serial_number); err != nil {
+		var i Manufacturer
+		if err := rows.Scan(&i.ID, &i.Name, &i.Status); err != nil {
 			return nil, err
 		}
-		items = append(items, serial_number)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -538,30 +3542,150 @@ func (q *Queries) GetSerialNumberLikeSerialNumber(ctx context.Context, serialNum
 	return items, nil
 }
 
-const getSerialNumbers = `-- name: GetSerialNumbers :many
-SELECT serial_number FROM serial_numbers
-LIMIT ? OFFSET ?
+const removeEquipmentTag = `-- name: RemoveEquipmentTag :exec
+DELETE FROM equipment_tags
+WHERE equipment_id = ? AND tag_id = ?
 `
 
-type GetSerialNumbersParams struct {
-	Limit  int32
-	Offset int32
+type RemoveEquipmentTagParams struct {
+	EquipmentID int32
+	TagID       int32
 }
 
-// SERIALNUMBER QUERIES
-func (q *Queries) GetSerialNumbers(ctx context.Context, arg GetSerialNumbersParams) ([]string, error) {
-	rows, err := q.db.QueryContext(ctx, getSerialNumbers, arg.Limit, arg.Offset)
+func (q *Queries) RemoveEquipmentTag(ctx context.Context, arg RemoveEquipmentTagParams) error {
+	_, err := q.db.ExecContext(ctx, removeEquipmentTag, arg.EquipmentID, arg.TagID)
+	return err
+}
+
+const searchEquipment = `-- name: SearchEquipment :many
+SELECT auto_id, device_type_id, manufacturer_id, serial_number, status, lifecycle_state, location_id, product_model_id, serial_canonical, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end, last_seen_at, last_seen_location_id, created_at FROM serial_numbers
+WHERE (? IS NULL OR serial_numbers.device_type_id = ?)
+AND (? IS NULL OR serial_numbers.manufacturer_id = ?)
+AND (? IS NULL OR serial_numbers.status = ?)
+AND (? IS NULL OR serial_numbers.lifecycle_state = ?)
+AND (? IS NULL OR serial_numbers.location_id = ?)
+AND (? IS NULL OR serial_numbers.product_model_id = ?)
+AND (? IS NULL OR serial_numbers.serial_number LIKE ? OR REGEXP_LIKE(serial_numbers.serial_canonical, ?))
+AND (? IS NULL OR serial_numbers.auto_id IN (
+    SELECT equipment_attributes.equipment_id FROM equipment_attributes
+    WHERE equipment_attributes.attribute_id IN (/*SLICE:attribute_ids*/?)
+    AND equipment_attributes.value IN (/*SLICE:attribute_values*/?)
+    AND JSON_CONTAINS(?, JSON_QUOTE(CONCAT(equipment_attributes.attribute_id, '=', equipment_attributes.value)))
+    GROUP BY equipment_attributes.equipment_id
+    HAVING COUNT(*) = CAST(? AS SIGNED)
+))
+AND (? IS NULL OR serial_numbers.auto_id IN (
+    SELECT equipment_tags.equipment_id FROM equipment_tags
+    JOIN tags ON tags.id = equipment_tags.tag_id
+    WHERE tags.name IN (/*SLICE:tag_names*/?)
+    GROUP BY equipment_tags.equipment_id
+    HAVING COUNT(DISTINCT equipment_tags.tag_id) = ?
+))
+AND serial_numbers.auto_id > ?
+ORDER BY serial_numbers.auto_id
+LIMIT ?
+`
+
+type SearchEquipmentParams struct {
+	DeviceTypeID     sql.NullInt32
+	ManufacturerID   sql.NullInt32
+	Status           NullSerialNumbersStatus
+	LifecycleState   NullSerialNumbersLifecycleState
+	LocationID       sql.NullInt32
+	ProductModelID   sql.NullInt32
+	SerialNumber     sql.NullString
+	CanonicalPattern sql.NullString
+	AttributePairs   sql.NullString
+	AttributeIds     []int32
+	AttributeValues  []string
+	AttributeCount   int64
+	TagCount         sql.NullInt32
+	TagNames         []string
+	AfterID          int32
+	Limit            int32
+}
+
+// SEARCH QUERIES
+func (q *Queries) SearchEquipment(ctx context.Context, arg SearchEquipmentParams) ([]SerialNumber, error) {
+	query := searchEquipment
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.DeviceTypeID)
+	queryParams = append(queryParams, arg.DeviceTypeID)
+	queryParams = append(queryParams, arg.ManufacturerID)
+	queryParams = append(queryParams, arg.ManufacturerID)
+	queryParams = append(queryParams, arg.Status)
+	queryParams = append(queryParams, arg.Status)
+	queryParams = append(queryParams, arg.LifecycleState)
+	queryParams = append(queryParams, arg.LifecycleState)
+	queryParams = append(queryParams, arg.LocationID)
+	queryParams = append(queryParams, arg.LocationID)
+	queryParams = append(queryParams, arg.ProductModelID)
+	queryParams = append(queryParams, arg.ProductModelID)
+	queryParams = append(queryParams, arg.SerialNumber)
+	queryParams = append(queryParams, arg.SerialNumber)
+	queryParams = append(queryParams, arg.CanonicalPattern)
+	queryParams = append(queryParams, arg.AttributePairs)
+	if len(arg.AttributeIds) > 0 {
+		for _, v := range arg.AttributeIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:attribute_ids*/?", strings.Repeat(",?", len(arg.AttributeIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:attribute_ids*/?", "NULL", 1)
+	}
+	if len(arg.AttributeValues) > 0 {
+		for _, v := range arg.AttributeValues {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:attribute_values*/?", strings.Repeat(",?", len(arg.AttributeValues))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:attribute_values*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.AttributePairs)
+	queryParams = append(queryParams, arg.AttributeCount)
+	queryParams = append(queryParams, arg.TagCount)
+	if len(arg.TagNames) > 0 {
+		for _, v := range arg.TagNames {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:tag_names*/?", strings.Repeat(",?", len(arg.TagNames))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:tag_names*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.TagCount)
+	queryParams = append(queryParams, arg.AfterID)
+	queryParams = append(queryParams, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []SerialNumber
 	for rows.Next() {
-		var serial_number string
-		if err := rows.Scan(&serial_number); err != nil {
+		var i SerialNumber
+		if err := rows.Scan(
+			&i.AutoID,
+			&i.DeviceTypeID,
+			&i.ManufacturerID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.LifecycleState,
+			&i.LocationID,
+			&i.ProductModelID,
+			&i.SerialCanonical,
+			&i.PurchaseDate,
+			&i.Vendor,
+			&i.PurchaseOrder,
+			&i.Cost,
+			&i.WarrantyStart,
+			&i.WarrantyEnd,
+			&i.LastSeenAt,
+			&i.LastSeenLocationID,
+			&i.CreatedAt,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, serial_number)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -572,6 +3696,83 @@ func (q *Queries) GetSerialNumbers(ctx context.Context, arg GetSerialNumbersPara
 	return items, nil
 }
 
+const setDepreciationSchedule = `-- name: SetDepreciationSchedule :exec
+INSERT INTO depreciation_schedules (device_type_id, method, life_months, salvage_percent, rate_percent) VALUES (?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE method = VALUES(method), life_months = VALUES(life_months), salvage_percent = VALUES(salvage_percent), rate_percent = VALUES(rate_percent)
+`
+
+type SetDepreciationScheduleParams struct {
+	DeviceTypeID   int32
+	Method         DepreciationSchedulesMethod
+	LifeMonths     int32
+	SalvagePercent int32
+	RatePercent    int32
+}
+
+func (q *Queries) SetDepreciationSchedule(ctx context.Context, arg SetDepreciationScheduleParams) error {
+	_, err := q.db.ExecContext(ctx, setDepreciationSchedule,
+		arg.DeviceTypeID,
+		arg.Method,
+		arg.LifeMonths,
+		arg.SalvagePercent,
+		arg.RatePercent,
+	)
+	return err
+}
+
+const setSerialNormalization = `-- name: SetSerialNormalization :exec
+INSERT INTO serial_normalizations (manufacturer_id, ` + "`" + `trim` + "`" + `, case_fold, separators) VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE ` + "`" + `trim` + "`" + ` = VALUES(` + "`" + `trim` + "`" + `), case_fold = VALUES(case_fold), separators = VALUES(separators)
+`
+
+type SetSerialNormalizationParams struct {
+	ManufacturerID int32
+	Trim           bool
+	CaseFold       bool
+	Separators     string
+}
+
+func (q *Queries) SetSerialNormalization(ctx context.Context, arg SetSerialNormalizationParams) error {
+	_, err := q.db.ExecContext(ctx, setSerialNormalization,
+		arg.ManufacturerID,
+		arg.Trim,
+		arg.CaseFold,
+		arg.Separators,
+	)
+	return err
+}
+
+const updateAssigneeStatus = `-- name: UpdateAssigneeStatus :exec
+UPDATE assignees SET status = ?
+WHERE id = ?
+`
+
+type UpdateAssigneeStatusParams struct {
+	Status AssigneesStatus
+	ID     int32
+}
+
+func (q *Queries) UpdateAssigneeStatus(ctx context.Context, arg UpdateAssigneeStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateAssigneeStatus, arg.Status, arg.ID)
+	return err
+}
+
+const updateCountScan = `-- name: UpdateCountScan :exec
+UPDATE count_scans SET code = ?, location_id = ?, scanned_at = CURRENT_TIMESTAMP
+WHERE id = ?
+`
+
+type UpdateCountScanParams struct {
+	Code       string
+	LocationID sql.NullInt32
+	ID         int32
+}
+
+func (q *Queries) UpdateCountScan(ctx context.Context, arg UpdateCountScanParams) error {
+	_, err := q.db.ExecContext(ctx, updateCountScan, arg.Code, arg.LocationID, arg.ID)
+	return err
+}
+
 const updateDeviceType = `-- name: UpdateDeviceType :exec
 UPDATE device_type SET name = ?
 WHERE id = ?
@@ -603,15 +3804,16 @@ func (q *Queries) UpdateDeviceTypeStatus(ctx context.Context, arg UpdateDeviceTy
 }
 
 const updateEquipment = `-- name: UpdateEquipment :exec
-UPDATE serial_numbers SET device_type_id = ?, manufacturer_id = ?, serial_number = ?
+UPDATE serial_numbers SET device_type_id = ?, manufacturer_id = ?, serial_number = ?, serial_canonical = ?
 WHERE auto_id = ?
 `
 
 type UpdateEquipmentParams struct {
-	DeviceTypeID   int32
-	ManufacturerID int32
-	SerialNumber   string
-	AutoID         int32
+	DeviceTypeID    int32
+	ManufacturerID  int32
+	SerialNumber    string
+	SerialCanonical string
+	AutoID          int32
 }
 
 func (q *Queries) UpdateEquipment(ctx context.Context, arg UpdateEquipmentParams) error {
@@ -619,6 +3821,99 @@ func (q *Queries) UpdateEquipment(ctx context.Context, arg UpdateEquipmentParams
 		arg.DeviceTypeID,
 		arg.ManufacturerID,
 		arg.SerialNumber,
+		arg.SerialCanonical,
+		arg.AutoID,
+	)
+	return err
+}
+
+const updateEquipmentLastSeen = `-- name: UpdateEquipmentLastSeen :exec
+UPDATE serial_numbers SET last_seen_at = CURRENT_TIMESTAMP,
+  last_seen_location_id = COALESCE(?, last_seen_location_id)
+WHERE auto_id = ?
+`
+
+type UpdateEquipmentLastSeenParams struct {
+	LocationID sql.NullInt32
+	AutoID     int32
+}
+
+// SCAN QUERIES
+func (q *Queries) UpdateEquipmentLastSeen(ctx context.Context, arg UpdateEquipmentLastSeenParams) error {
+	_, err := q.db.ExecContext(ctx, updateEquipmentLastSeen, arg.LocationID, arg.AutoID)
+	return err
+}
+
+const updateEquipmentLifecycle = `-- name: UpdateEquipmentLifecycle :exec
+UPDATE serial_numbers SET lifecycle_state = ?, status = ?
+WHERE auto_id = ?
+`
+
+type UpdateEquipmentLifecycleParams struct {
+	LifecycleState SerialNumbersLifecycleState
+	Status         SerialNumbersStatus
+	AutoID         int32
+}
+
+func (q *Queries) UpdateEquipmentLifecycle(ctx context.Context, arg UpdateEquipmentLifecycleParams) error {
+	_, err := q.db.ExecContext(ctx, updateEquipmentLifecycle, arg.LifecycleState, arg.Status, arg.AutoID)
+	return err
+}
+
+const updateEquipmentLocation = `-- name: UpdateEquipmentLocation :exec
+UPDATE serial_numbers SET location_id = ?
+WHERE auto_id = ?
+`
+
+type UpdateEquipmentLocationParams struct {
+	LocationID sql.NullInt32
+	AutoID     int32
+}
+
+func (q *Queries) UpdateEquipmentLocation(ctx context.Context, arg UpdateEquipmentLocationParams) error {
+	_, err := q.db.ExecContext(ctx, updateEquipmentLocation, arg.LocationID, arg.AutoID)
+	return err
+}
+
+const updateEquipmentProductModel = `-- name: UpdateEquipmentProductModel :exec
+UPDATE serial_numbers SET product_model_id = ?
+WHERE auto_id = ?
+`
+
+type UpdateEquipmentProductModelParams struct {
+	ProductModelID sql.NullInt32
+	AutoID         int32
+}
+
+func (q *Queries) UpdateEquipmentProductModel(ctx context.Context, arg UpdateEquipmentProductModelParams) error {
+	_, err := q.db.ExecContext(ctx, updateEquipmentProductModel, arg.ProductModelID, arg.AutoID)
+	return err
+}
+
+const updateEquipmentPurchase = `-- name: UpdateEquipmentPurchase :exec
+UPDATE serial_numbers SET purchase_date = ?, vendor = ?, purchase_order = ?, cost = ?, warranty_start = ?, warranty_end = ?
+WHERE auto_id = ?
+`
+
+type UpdateEquipmentPurchaseParams struct {
+	PurchaseDate  sql.NullTime
+	Vendor        string
+	PurchaseOrder string
+	Cost          sql.NullString
+	WarrantyStart sql.NullTime
+	WarrantyEnd   sql.NullTime
+	AutoID        int32
+}
+
+// PURCHASE QUERIES
+func (q *Queries) UpdateEquipmentPurchase(ctx context.Context, arg UpdateEquipmentPurchaseParams) error {
+	_, err := q.db.ExecContext(ctx, updateEquipmentPurchase,
+		arg.PurchaseDate,
+		arg.Vendor,
+		arg.PurchaseOrder,
+		arg.Cost,
+		arg.WarrantyStart,
+		arg.WarrantyEnd,
 		arg.AutoID,
 	)
 	return err
@@ -639,6 +3934,28 @@ func (q *Queries) UpdateEquipmentStatus(ctx context.Context, arg UpdateEquipment
 	return err
 }
 
+const updateMaintenanceTicket = `-- name: UpdateMaintenanceTicket :exec
+UPDATE maintenance_tickets SET issue = ?, vendor = ?, cost = ?
+WHERE id = ?
+`
+
+type UpdateMaintenanceTicketParams struct {
+	Issue  string
+	Vendor string
+	Cost   sql.NullString
+	ID     int32
+}
+
+func (q *Queries) UpdateMaintenanceTicket(ctx context.Context, arg UpdateMaintenanceTicketParams) error {
+	_, err := q.db.ExecContext(ctx, updateMaintenanceTicket,
+		arg.Issue,
+		arg.Vendor,
+		arg.Cost,
+		arg.ID,
+	)
+	return err
+}
+
 const updateManufacturer = `-- name: UpdateManufacturer :exec
 UPDATE manufacturer SET name = ?
 WHERE id = ?
@@ -669,17 +3986,70 @@ func (q *Queries) UpdateManufacturerStatus(ctx context.Context, arg UpdateManufa
 	return err
 }
 
+const updateProductModel = `-- name: UpdateProductModel :exec
+UPDATE product_models SET model_number = ?, name = ?, specs = ?
+WHERE id = ?
+`
+
+type UpdateProductModelParams struct {
+	ModelNumber string
+	Name        string
+	Specs       json.RawMessage
+	ID          int32
+}
+
+func (q *Queries) UpdateProductModel(ctx context.Context, arg UpdateProductModelParams) error {
+	_, err := q.db.ExecContext(ctx, updateProductModel,
+		arg.ModelNumber,
+		arg.Name,
+		arg.Specs,
+		arg.ID,
+	)
+	return err
+}
+
+const updateSerialCanonical = `-- name: UpdateSerialCanonical :exec
+UPDATE serial_numbers SET serial_canonical = ?
+WHERE auto_id = ?
+`
+
+type UpdateSerialCanonicalParams struct {
+	SerialCanonical string
+	AutoID          int32
+}
+
+func (q *Queries) UpdateSerialCanonical(ctx context.Context, arg UpdateSerialCanonicalParams) error {
+	_, err := q.db.ExecContext(ctx, updateSerialCanonical, arg.SerialCanonical, arg.AutoID)
+	return err
+}
+
 const updateSerialNumber = `-- name: UpdateSerialNumber :exec
-UPDATE serial_numbers SET serial_number = ?
+UPDATE serial_numbers SET serial_number = ?, serial_canonical = ?
 WHERE auto_id = ?
 `
 
 type UpdateSerialNumberParams struct {
-	SerialNumber string
-	AutoID       int32
+	SerialNumber    string
+	SerialCanonical string
+	AutoID          int32
 }
 
 func (q *Queries) UpdateSerialNumber(ctx context.Context, arg UpdateSerialNumberParams) error {
-	_, err := q.db.ExecContext(ctx, updateSerialNumber, arg.SerialNumber, arg.AutoID)
+	_, err := q.db.ExecContext(ctx, updateSerialNumber, arg.SerialNumber, arg.SerialCanonical, arg.AutoID)
+	return err
+}
+
+const updateTag = `-- name: UpdateTag :exec
+UPDATE tags SET name = ?
+WHERE id = ?
+`
+
+type UpdateTagParams struct {
+	Name string
+	ID   int32
+}
+
+func (q *Queries) UpdateTag(ctx context.Context, arg UpdateTagParams) error {
+	_, err := q.db.ExecContext(ctx, updateTag, arg.Name, arg.ID)
 	return err
 }
@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/coltonmosier/api-v1/internal/blobstore"
+	"github.com/coltonmosier/api-v1/internal/config"
+	"github.com/coltonmosier/api-v1/internal/database"
 	"github.com/coltonmosier/api-v1/internal/handlers"
-	"github.com/coltonmosier/api-v1/internal/helpers"
+	"github.com/coltonmosier/api-v1/internal/lifecycle"
+	"github.com/coltonmosier/api-v1/internal/logging"
+	"github.com/coltonmosier/api-v1/internal/metrics"
 	"github.com/coltonmosier/api-v1/internal/middleware"
-	"github.com/joho/godotenv"
+	"github.com/coltonmosier/api-v1/internal/tracing"
 	"github.com/swaggo/http-swagger/v2"
     _ "github.com/coltonmosier/api-v1/docs"
 )
@@ -19,23 +30,55 @@ import (
 //	@termsOfService	http://swagger.io/terms/
 //	@basePath		/api/v1
 func main() {
-	err := godotenv.Load(".env")
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(configCommand(os.Args[2:]))
+	}
+
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal("invalid configuration: ", err)
+	}
+
+	logger, logFile, err := logging.Setup(cfg.Log)
+	if err != nil {
+		log.Fatal("could not set up logging: ", err)
+	}
+	defer logFile.Close()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		log.Fatal("Error loading .env file", err)
+		log.Fatal("could not set up tracing: ", err)
 	}
 
-	devices := handlers.DeviceHandler{}
-	manufactuerers := handlers.ManufactuerHandler{}
-    equipment := handlers.EquipmentHandler{}
+	database.Configure(cfg.Database)
+
+	if cfg.Database.MigrateOnStart {
+		// NOTE: a failed migration doesn't stop the server, /readyz reports it until fixed
+		if _, err := database.Migrate(context.Background()); err != nil {
+			logger.Error("Error applying migrations", slog.String("error", err.Error()))
+		}
+	}
+
+	devices := handlers.DeviceHandler{BaseURL: cfg.Server.BaseURL}
+	manufactuerers := handlers.ManufactuerHandler{BaseURL: cfg.Server.BaseURL}
+	machine, err := lifecycle.New(cfg.Lifecycle.Transitions)
+	if err != nil {
+		log.Fatal("invalid lifecycle: ", err)
+	}
+    equipment := handlers.EquipmentHandler{BaseURL: cfg.Server.BaseURL, Lifecycle: machine}
 
 	r := http.NewServeMux()
 
-    r.HandleFunc("GET /api/v1/swagger/*", httpSwagger.Handler(
-            httpSwagger.URL("http://localhost:8081/api/v1/swagger/doc.json"),
-            //httpSwagger.URL("https://ec2-3-129-26-111.us-east-2.compute.amazonaws.com:8080/api/v1/swagger/doc.json"),
+    r.HandleFunc("GET /api/v1/swagger/", httpSwagger.Handler(
+            httpSwagger.URL(cfg.Server.SwaggerURL),
         ))
 
-	r.HandleFunc("GET /api/v1/health", HealthHandler)
+	health := &handlers.HealthHandler{}
+	r.HandleFunc("GET /livez", health.Livez)
+	r.HandleFunc("GET /readyz", health.Readyz)
+	r.HandleFunc("GET /api/v1/health", health.Health)
+	r.Handle("GET /metrics", metrics.Handler())
+	metrics.RegisterInventory(inventoryCounts)
 
 	// NOTE: Device Type routes
 	r.HandleFunc("GET /api/v1/device", devices.GetDeviceTypes)
@@ -49,6 +92,8 @@ func main() {
     r.HandleFunc("PATCH /api/v1/manufacturer/{id}/name", manufactuerers.UpdateManufacturerName)
     r.HandleFunc("PATCH /api/v1/manufacturer/{id}/status", manufactuerers.UpdateManufacturerStatus)
     r.HandleFunc("POST /api/v1/manufacturer", manufactuerers.CreateManufacturer)
+	r.HandleFunc("GET /api/v1/manufacturer/{id}/serial-normalization", manufactuerers.GetSerialNormalization)
+	r.HandleFunc("PATCH /api/v1/manufacturer/{id}/serial-normalization", manufactuerers.UpdateSerialNormalization)
 
 	// NOTE: Equipment routes
     r.HandleFunc("GET /api/v1/equipment", equipment.GetEquipments)
@@ -67,6 +112,132 @@ func main() {
     r.HandleFunc("PATCH /api/v1/equipment/{id}/status", equipment.UpdateEquipmentStatus)
     r.HandleFunc("POST /api/v1/equipment", equipment.CreateEquipment)
 
+	// NOTE: Lifecycle routes
+	r.HandleFunc("GET /api/v1/lifecycle", equipment.GetLifecycle)
+	// NOTE: GET /api/v1/equipment/{id}/... would conflict with /api/v1/equipment/sn-like/{sn}
+	r.HandleFunc("GET /api/v1/lifecycle/equipment", equipment.GetEquipmentByLifecycleState)
+	r.HandleFunc("GET /api/v1/lifecycle/equipment/{id}", equipment.GetEquipmentLifecycleHistory)
+	r.HandleFunc("PATCH /api/v1/equipment/{id}/lifecycle", equipment.TransitionEquipment)
+
+	// NOTE: Assignment routes
+	assignees := handlers.AssigneeHandler{}
+	r.HandleFunc("GET /api/v1/assignee", assignees.GetAssignees)
+	r.HandleFunc("GET /api/v1/assignee/{id}", assignees.GetAssigneeByID)
+	r.HandleFunc("GET /api/v1/assignee/{id}/equipment", assignees.GetAssigneeEquipment)
+	r.HandleFunc("GET /api/v1/assignee/{id}/assignments", assignees.GetAssigneeAssignments)
+	r.HandleFunc("PATCH /api/v1/assignee/{id}/status", assignees.UpdateAssigneeStatus)
+	r.HandleFunc("POST /api/v1/assignee", assignees.CreateAssignee)
+	r.HandleFunc("GET /api/v1/assignment/equipment/{id}", equipment.GetEquipmentAssignments)
+	r.HandleFunc("POST /api/v1/equipment/{id}/checkout", equipment.CheckOutEquipment)
+	r.HandleFunc("POST /api/v1/equipment/{id}/checkin", equipment.CheckInEquipment)
+
+	// NOTE: Location routes
+	locations := handlers.LocationHandler{}
+	r.HandleFunc("GET /api/v1/location", locations.GetLocations)
+	r.HandleFunc("GET /api/v1/location/counts", locations.GetLocationCounts)
+	r.HandleFunc("GET /api/v1/location/{id}", locations.GetLocationByID)
+	r.HandleFunc("POST /api/v1/location", locations.CreateLocation)
+	r.HandleFunc("GET /api/v1/location/equipment/{id}", equipment.GetEquipmentLocationMoves)
+	r.HandleFunc("GET /api/v1/equipment/location/{id}", equipment.GetEquipmentByLocation)
+	r.HandleFunc("PATCH /api/v1/equipment/{id}/location", equipment.MoveEquipment)
+
+	// NOTE: Product model routes
+	productModels := handlers.ProductModelHandler{}
+	r.HandleFunc("GET /api/v1/product-model", productModels.GetProductModels)
+	r.HandleFunc("GET /api/v1/product-model/{id}", productModels.GetProductModelByID)
+	r.HandleFunc("POST /api/v1/product-model", productModels.CreateProductModel)
+	r.HandleFunc("PATCH /api/v1/product-model/{id}", productModels.UpdateProductModel)
+	r.HandleFunc("DELETE /api/v1/product-model/{id}", productModels.DeleteProductModel)
+	r.HandleFunc("PATCH /api/v1/equipment/{id}/product-model", equipment.UpdateEquipmentProductModel)
+
+	// NOTE: Attribute routes
+	r.HandleFunc("GET /api/v1/device/{id}/attributes", devices.GetDeviceTypeAttributes)
+	r.HandleFunc("POST /api/v1/device/{id}/attributes", devices.CreateDeviceTypeAttribute)
+	r.HandleFunc("DELETE /api/v1/device/{id}/attributes/{name}", devices.DeleteDeviceTypeAttribute)
+	r.HandleFunc("GET /api/v1/attributes/equipment/{id}", equipment.GetEquipmentAttributes)
+	r.HandleFunc("PATCH /api/v1/equipment/{id}/attributes", equipment.UpdateEquipmentAttributes)
+
+	// NOTE: Purchase and warranty routes
+	r.HandleFunc("PATCH /api/v1/equipment/{id}/purchase", equipment.UpdateEquipmentPurchase)
+	r.HandleFunc("GET /api/v1/warranty/expiring", equipment.GetWarrantyExpiring)
+
+	// NOTE: Depreciation routes
+	r.HandleFunc("GET /api/v1/device/{id}/depreciation", devices.GetDepreciationSchedule)
+	r.HandleFunc("PATCH /api/v1/device/{id}/depreciation", devices.UpdateDepreciationSchedule)
+	r.HandleFunc("DELETE /api/v1/device/{id}/depreciation", devices.DeleteDepreciationSchedule)
+	r.HandleFunc("GET /api/v1/valuation", equipment.GetValuationReport)
+
+	// NOTE: Maintenance routes
+	r.HandleFunc("GET /api/v1/maintenance", equipment.GetMaintenanceTickets)
+	r.HandleFunc("GET /api/v1/maintenance/report", equipment.GetMaintenanceReport)
+	r.HandleFunc("GET /api/v1/maintenance/{id}", equipment.GetMaintenanceTicket)
+	r.HandleFunc("PATCH /api/v1/maintenance/{id}", equipment.UpdateMaintenanceTicket)
+	r.HandleFunc("POST /api/v1/maintenance/{id}/close", equipment.CloseMaintenanceTicket)
+	r.HandleFunc("GET /api/v1/maintenance/equipment/{id}", equipment.GetEquipmentMaintenance)
+	r.HandleFunc("POST /api/v1/equipment/{id}/maintenance", equipment.OpenMaintenanceTicket)
+
+	// NOTE: Tag routes
+	tags := handlers.TagHandler{}
+	r.HandleFunc("GET /api/v1/tag", tags.GetTags)
+	r.HandleFunc("POST /api/v1/tag", tags.CreateTag)
+	r.HandleFunc("PATCH /api/v1/tag/{id}", tags.UpdateTag)
+	r.HandleFunc("DELETE /api/v1/tag/{id}", tags.DeleteTag)
+	r.HandleFunc("GET /api/v1/tag/equipment/{id}", equipment.GetEquipmentTags)
+	r.HandleFunc("POST /api/v1/equipment/{id}/tags", equipment.AddEquipmentTags)
+	r.HandleFunc("DELETE /api/v1/equipment/{id}/tags/{tag}", equipment.RemoveEquipmentTag)
+	r.HandleFunc("POST /api/v1/equipment/tags", equipment.BulkTagEquipment)
+	r.HandleFunc("DELETE /api/v1/equipment/tags", equipment.BulkUntagEquipment)
+
+	// NOTE: Attachment routes
+	store, err := blobstore.New(cfg.Attachments)
+	if err != nil {
+		log.Fatal("could not set up attachment storage: ", err)
+	}
+	attachments := handlers.AttachmentHandler{
+		Store:        store,
+		MaxSize:      int64(cfg.Attachments.MaxSize),
+		AllowedTypes: cfg.Attachments.AllowedTypes,
+	}
+	r.HandleFunc("GET /api/v1/attachment/equipment/{id}", attachments.GetEquipmentAttachments)
+	r.HandleFunc("GET /api/v1/attachment/manufacturer/{id}", attachments.GetManufacturerAttachments)
+	r.HandleFunc("GET /api/v1/attachment/{id}", attachments.DownloadAttachment)
+	r.HandleFunc("DELETE /api/v1/attachment/{id}", attachments.DeleteAttachment)
+	r.HandleFunc("POST /api/v1/equipment/{id}/attachments", attachments.UploadEquipmentAttachment)
+	r.HandleFunc("POST /api/v1/manufacturer/{id}/attachments", attachments.UploadManufacturerAttachment)
+
+	// NOTE: Label routes
+	r.HandleFunc("GET /api/v1/label/equipment/{id}", equipment.GetEquipmentLabel)
+	r.HandleFunc("GET /api/v1/label/sheet", equipment.GetLabelSheet)
+
+	// NOTE: Scan routes
+	r.HandleFunc("POST /api/v1/scan", equipment.Scan)
+
+	// NOTE: Count routes
+	r.HandleFunc("GET /api/v1/count", equipment.GetCountSessions)
+	r.HandleFunc("POST /api/v1/count", equipment.CreateCountSession)
+	r.HandleFunc("GET /api/v1/count/{id}", equipment.GetCountSession)
+	r.HandleFunc("GET /api/v1/count/{id}/scans", equipment.GetCountScans)
+	r.HandleFunc("POST /api/v1/count/{id}/scans", equipment.RecordCountScans)
+	r.HandleFunc("GET /api/v1/count/{id}/reconciliation", equipment.GetCountReconciliation)
+	r.HandleFunc("POST /api/v1/count/{id}/apply", equipment.ApplyCountSession)
+	r.HandleFunc("POST /api/v1/count/{id}/close", equipment.CloseCountSession)
+
+	// NOTE: Stats routes
+	r.HandleFunc("GET /api/v1/stats", equipment.GetStats)
+
+	// NOTE: Search routes
+	r.HandleFunc("GET /api/v1/equipment/search", equipment.SearchEquipment)
+	r.HandleFunc("GET /api/v1/equipment/export", equipment.ExportEquipment)
+	r.HandleFunc("GET /api/v1/equipment/fuzzy", equipment.FuzzySearchEquipment)
+	r.HandleFunc("POST /api/v1/equipment/import", equipment.ImportEquipment)
+
+	// NOTE: Serial number rule routes
+	serialRules := handlers.SerialRuleHandler{}
+	r.HandleFunc("GET /api/v1/serial-rule", serialRules.GetSerialNumberRules)
+	r.HandleFunc("POST /api/v1/serial-rule", serialRules.CreateSerialNumberRule)
+	r.HandleFunc("DELETE /api/v1/serial-rule/{id}", serialRules.DeleteSerialNumberRule)
+	r.HandleFunc("GET /api/v1/serial-rule/validate", serialRules.ValidateSerialNumber)
+
     // NOTE: Serial number routes
 
 
@@ -75,15 +246,63 @@ func main() {
 
 	http.Handle("/", r)
 
+	cors := middleware.CorsOptions{
+		AllowedOrigins:   cfg.Cors.AllowedOrigins,
+		AllowedMethods:   cfg.Cors.AllowedMethods,
+		AllowedHeaders:   cfg.Cors.AllowedHeaders,
+		AllowCredentials: cfg.Cors.AllowCredentials,
+		MaxAge:           time.Duration(cfg.Cors.MaxAge),
+	}
+
 	s := &http.Server{
-		Addr:         ":8081",
-		Handler:      middleware.LoggingMiddleware(r),
-		ReadTimeout:  1 * time.Minute,
-		WriteTimeout: 1 * time.Minute,
+		Addr:         cfg.Server.Addr,
+		Handler:      middleware.RequestIDMiddleware(middleware.TracingMiddleware(r, middleware.LoggingMiddleware(middleware.MetricsMiddleware(r, middleware.CorsMiddleware(r, cors))))),
+		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout),
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout),
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		logger.Info("listening", slog.String("addr", s.Addr))
+		if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server stopped", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	logger.Info("shutting down, draining in-flight requests")
+	health.Drain()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout))
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Error draining requests", slog.String("error", err.Error()))
+	}
+	if err := database.Close(); err != nil {
+		logger.Error("Error closing database", slog.String("error", err.Error()))
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Error flushing traces", slog.String("error", err.Error()))
 	}
-	log.Fatal(s.ListenAndServe())
 }
 
-func HealthHandler(w http.ResponseWriter, r *http.Request) {
-    helpers.JsonResponseSuccess(w, http.StatusOK, "API is healthy")
+// inventoryCounts feeds the equipment_inventory gauges on /metrics
+func inventoryCounts(ctx context.Context) ([]metrics.InventoryCount, error) {
+	q, err := database.InitEquipmentDatabase()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.CountEquipmentByStatusAndDeviceType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []metrics.InventoryCount
+	for _, v := range rows {
+		out = append(out, metrics.InventoryCount{Status: string(v.Status), DeviceType: v.DeviceType, Total: v.Total})
+	}
+	return out, nil
 }
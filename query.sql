@@ -1,8 +1,28 @@
 -- DEVICETYPE QUERIES
--- name: GetDeviceTypesActive :many
+-- name: GetDeviceTypes :many
 SELECT id, name, status FROM device_type
 ORDER BY id;
 
+-- name: ListDeviceTypes :many
+SELECT id, name, status FROM device_type
+WHERE (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+AND (sqlc.narg('name') IS NULL OR LOWER(name) LIKE LOWER(sqlc.narg('name')))
+ORDER BY id
+LIMIT ? OFFSET ?;
+
+-- name: CountDeviceTypes :one
+SELECT COUNT(*) FROM device_type
+WHERE (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+AND (sqlc.narg('name') IS NULL OR LOWER(name) LIKE LOWER(sqlc.narg('name')));
+
+-- name: CountEquipmentByDeviceType :many
+SELECT device_type_id AS id,
+    CAST(SUM(status = 'active') AS SIGNED) AS active,
+    CAST(SUM(status = 'inactive') AS SIGNED) AS inactive
+FROM serial_numbers
+WHERE device_type_id IN (sqlc.slice('ids'))
+GROUP BY device_type_id;
+
 -- name: GetDeviceTypeByName :one
 SELECT id, name, status FROM device_type
 WHERE name = ?
@@ -32,10 +52,30 @@ WHERE id = ?;
 
 
 -- MANUFACTURER QUERIES
--- name: GetManufacturersActive :many
+-- name: GetManufacturers :many
 SELECT id, name, status FROM manufacturer
 ORDER BY id;
 
+-- name: ListManufacturers :many
+SELECT id, name, status FROM manufacturer
+WHERE (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+AND (sqlc.narg('name') IS NULL OR LOWER(name) LIKE LOWER(sqlc.narg('name')))
+ORDER BY id
+LIMIT ? OFFSET ?;
+
+-- name: CountManufacturers :one
+SELECT COUNT(*) FROM manufacturer
+WHERE (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+AND (sqlc.narg('name') IS NULL OR LOWER(name) LIKE LOWER(sqlc.narg('name')));
+
+-- name: CountEquipmentByManufacturer :many
+SELECT manufacturer_id AS id,
+    CAST(SUM(status = 'active') AS SIGNED) AS active,
+    CAST(SUM(status = 'inactive') AS SIGNED) AS inactive
+FROM serial_numbers
+WHERE manufacturer_id IN (sqlc.slice('ids'))
+GROUP BY manufacturer_id;
+
 -- name: GetManufacturerByName :one
 SELECT id, name, status FROM manufacturer
 WHERE name = ?
@@ -78,7 +118,7 @@ SELECT serial_number FROM serial_numbers
 WHERE serial_number LIKE ?;
 
 -- name: UpdateSerialNumber :exec
-UPDATE serial_numbers SET serial_number = ?
+UPDATE serial_numbers SET serial_number = ?, serial_canonical = ?
 WHERE auto_id = ?;
 
 
@@ -99,9 +139,10 @@ SELECT * FROM serial_numbers
 WHERE manufacturer_id = ?
 LIMIT 1000;
 
--- name: GetEquipmentBySerialNumber :one
+-- name: GetEquipmentBySerialCanonicals :many
 SELECT * FROM serial_numbers
-WHERE serial_number = ?;
+WHERE serial_canonical IN (sqlc.slice('canonicals'))
+ORDER BY auto_id;
 
 -- name: GetEquipmentByAutoID :one
 SELECT * FROM serial_numbers
@@ -109,7 +150,7 @@ WHERE auto_id = ?;
 
 -- name: GetEquipmentLikeSerialNumber :many
 SELECT * FROM serial_numbers
-WHERE serial_number LIKE ?
+WHERE serial_number LIKE sqlc.arg('serial_number') OR REGEXP_LIKE(serial_canonical, sqlc.arg('canonical_pattern'))
 LIMIT 1000;
 
 -- name: GetEquipmentByDeviceTypeAndManufacturer :many
@@ -117,29 +158,573 @@ SELECT * FROM serial_numbers
 WHERE device_type_id = ? AND manufacturer_id = ?
 LIMIT 1000;
 
--- name: GetEquipmentByDeviceTypeAndSerialNumber :one
-SELECT * FROM serial_numbers
-WHERE device_type_id = ? AND serial_number = ?;
-
 -- name: GetEquipmentByManufacturerAndSerialNumber :one
 SELECT * FROM serial_numbers
-WHERE manufacturer_id = ? AND serial_number = ?;
+WHERE manufacturer_id = ? AND serial_canonical = ?;
 
 -- name: GetEquipmentByDeviceTypeManufacturerAndSerialNumber :one
 SELECT * FROM serial_numbers
-WHERE device_type_id = ? AND manufacturer_id = ? AND serial_number = ?;
+WHERE device_type_id = ? AND manufacturer_id = ? AND serial_canonical = ?;
 
 -- name: GetEquipmentByDeviceTypeManufacturerLikeSerialNumber :many
 SELECT * FROM serial_numbers
-WHERE device_type_id = ? AND manufacturer_id = ? AND serial_number LIKE ? LIMIT 1000;
+WHERE device_type_id = sqlc.arg('device_type_id') AND manufacturer_id = sqlc.arg('manufacturer_id')
+AND (serial_number LIKE sqlc.arg('serial_number') OR serial_canonical LIKE sqlc.arg('serial_canonical')) LIMIT 1000;
 
 -- name: UpdateEquipment :exec
-UPDATE serial_numbers SET device_type_id = ?, manufacturer_id = ?, serial_number = ?
-WHERE serial_number = ?;
+UPDATE serial_numbers SET device_type_id = ?, manufacturer_id = ?, serial_number = ?, serial_canonical = ?
+WHERE auto_id = ?;
 
 -- name: UpdateEquipmentStatus :exec
 UPDATE serial_numbers SET status = ?
 WHERE auto_id = ?;
 
--- name: CreateEquipment :exec
-INSERT INTO serial_numbers (device_type_id, manufacturer_id, serial_number) VALUES (?, ?, ?);
+-- name: CreateEquipment :execlastid
+INSERT INTO serial_numbers (device_type_id, manufacturer_id, serial_number, serial_canonical, product_model_id, purchase_date, vendor, purchase_order, cost, warranty_start, warranty_end) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CountEquipmentByStatusAndDeviceType :many
+SELECT serial_numbers.status, device_type.name AS device_type, COUNT(*) AS total
+FROM serial_numbers
+JOIN device_type ON device_type.id = serial_numbers.device_type_id
+GROUP BY serial_numbers.status, device_type.name;
+
+-- name: GetEquipmentRelations :many
+SELECT serial_numbers.auto_id, sqlc.embed(device_type), sqlc.embed(manufacturer)
+FROM serial_numbers
+JOIN device_type ON device_type.id = serial_numbers.device_type_id
+JOIN manufacturer ON manufacturer.id = serial_numbers.manufacturer_id
+WHERE serial_numbers.auto_id IN (sqlc.slice('auto_ids'));
+
+
+
+
+-- LIFECYCLE QUERIES
+-- name: GetEquipmentByLifecycleState :many
+SELECT * FROM serial_numbers
+WHERE lifecycle_state = ?
+ORDER BY auto_id
+LIMIT 1000;
+
+-- name: GetEquipmentByAutoIDForUpdate :one
+SELECT * FROM serial_numbers
+WHERE auto_id = ?
+FOR UPDATE;
+
+-- name: UpdateEquipmentLifecycle :exec
+UPDATE serial_numbers SET lifecycle_state = ?, status = ?
+WHERE auto_id = ?;
+
+-- name: CreateLifecycleTransition :exec
+INSERT INTO lifecycle_transitions (equipment_id, from_state, to_state, reason) VALUES (?, ?, ?, ?);
+
+-- name: GetLifecycleTransitions :many
+SELECT * FROM lifecycle_transitions
+WHERE equipment_id = ?
+ORDER BY id;
+
+
+
+
+-- ASSIGNEE QUERIES
+-- name: GetAssignees :many
+SELECT * FROM assignees
+ORDER BY id;
+
+-- name: GetAssigneeByID :one
+SELECT * FROM assignees
+WHERE id = ?;
+
+-- name: CreateAssignee :execlastid
+INSERT INTO assignees (name, kind, email) VALUES (?, ?, ?);
+
+-- name: UpdateAssigneeStatus :exec
+UPDATE assignees SET status = ?
+WHERE id = ?;
+
+
+
+
+-- ASSIGNMENT QUERIES
+-- name: GetOpenAssignmentByEquipment :one
+SELECT * FROM equipment_assignments
+WHERE equipment_id = ? AND checked_in_at IS NULL;
+
+-- name: GetAssignmentsByEquipment :many
+SELECT * FROM equipment_assignments
+WHERE equipment_id = ?
+ORDER BY id;
+
+-- name: GetAssignmentsByAssignee :many
+SELECT * FROM equipment_assignments
+WHERE assignee_id = ?
+ORDER BY id;
+
+-- name: GetEquipmentHeldByAssignee :many
+SELECT serial_numbers.* FROM serial_numbers
+JOIN equipment_assignments ON equipment_assignments.equipment_id = serial_numbers.auto_id
+WHERE equipment_assignments.assignee_id = ? AND equipment_assignments.checked_in_at IS NULL
+ORDER BY serial_numbers.auto_id;
+
+-- name: CreateAssignment :exec
+INSERT INTO equipment_assignments (equipment_id, assignee_id, note) VALUES (?, ?, ?);
+
+-- name: CheckInAssignment :exec
+UPDATE equipment_assignments SET checked_in_at = CURRENT_TIMESTAMP, checkin_note = ?
+WHERE id = ?;
+
+
+
+
+-- LOCATION QUERIES
+-- name: GetLocations :many
+SELECT * FROM locations
+ORDER BY id;
+
+-- name: GetLocationByID :one
+SELECT * FROM locations
+WHERE id = ?;
+
+-- name: CreateLocation :execlastid
+INSERT INTO locations (parent_id, name, kind) VALUES (?, ?, ?);
+
+-- name: GetEquipmentByLocations :many
+SELECT * FROM serial_numbers
+WHERE location_id IN (sqlc.slice('location_ids'))
+ORDER BY auto_id
+LIMIT 1000;
+
+-- name: CountEquipmentByLocation :many
+SELECT location_id, COUNT(*) AS total FROM serial_numbers
+WHERE location_id IS NOT NULL
+GROUP BY location_id;
+
+-- name: UpdateEquipmentLocation :exec
+UPDATE serial_numbers SET location_id = ?
+WHERE auto_id = ?;
+
+-- name: CreateLocationMove :exec
+INSERT INTO location_moves (equipment_id, from_location_id, to_location_id, reason) VALUES (?, ?, ?, ?);
+
+-- name: GetLocationMoves :many
+SELECT * FROM location_moves
+WHERE equipment_id = ?
+ORDER BY id;
+
+
+
+
+-- PRODUCT MODEL QUERIES
+-- name: GetProductModels :many
+SELECT * FROM product_models
+WHERE (sqlc.narg('manufacturer_id') IS NULL OR manufacturer_id = sqlc.narg('manufacturer_id'))
+AND (sqlc.narg('device_type_id') IS NULL OR device_type_id = sqlc.narg('device_type_id'))
+ORDER BY id;
+
+-- name: GetProductModelByID :one
+SELECT * FROM product_models
+WHERE id = ?;
+
+-- name: GetProductModelByModelNumber :one
+SELECT * FROM product_models
+WHERE manufacturer_id = ? AND model_number = ?;
+
+-- name: CreateProductModel :execlastid
+INSERT INTO product_models (manufacturer_id, device_type_id, model_number, name, specs) VALUES (?, ?, ?, ?, ?);
+
+-- name: UpdateProductModel :exec
+UPDATE product_models SET model_number = ?, name = ?, specs = ?
+WHERE id = ?;
+
+-- name: DeleteProductModel :exec
+DELETE FROM product_models
+WHERE id = ?;
+
+-- name: CountEquipmentByProductModel :one
+SELECT COUNT(*) FROM serial_numbers
+WHERE product_model_id = ?;
+
+-- name: UpdateEquipmentProductModel :exec
+UPDATE serial_numbers SET product_model_id = ?
+WHERE auto_id = ?;
+
+
+
+
+-- ATTRIBUTE QUERIES
+-- name: GetDeviceTypeAttributes :many
+SELECT * FROM device_type_attributes
+WHERE device_type_id = ?
+ORDER BY id;
+
+-- name: GetDeviceTypeAttributeByName :one
+SELECT * FROM device_type_attributes
+WHERE device_type_id = ? AND name = ?;
+
+-- name: CreateDeviceTypeAttribute :execlastid
+INSERT INTO device_type_attributes (device_type_id, name, type, required, enum_values, pattern) VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: DeleteDeviceTypeAttribute :exec
+DELETE FROM device_type_attributes
+WHERE id = ?;
+
+-- name: GetDeviceTypeAttributesByName :many
+SELECT * FROM device_type_attributes
+WHERE name IN (sqlc.slice('names'))
+ORDER BY id;
+
+-- name: GetAttributeNames :many
+SELECT DISTINCT name FROM device_type_attributes
+WHERE (sqlc.narg('device_type_id') IS NULL OR device_type_id = sqlc.narg('device_type_id'))
+ORDER BY name;
+
+-- name: GetEquipmentAttributes :many
+SELECT equipment_attributes.equipment_id, device_type_attributes.name, equipment_attributes.value
+FROM equipment_attributes
+JOIN device_type_attributes ON device_type_attributes.id = equipment_attributes.attribute_id
+JOIN serial_numbers ON serial_numbers.auto_id = equipment_attributes.equipment_id
+    AND serial_numbers.device_type_id = device_type_attributes.device_type_id
+WHERE equipment_attributes.equipment_id IN (sqlc.slice('equipment_ids'))
+ORDER BY equipment_attributes.equipment_id, device_type_attributes.id;
+
+-- name: DeleteEquipmentAttributes :exec
+DELETE FROM equipment_attributes
+WHERE equipment_id = ?;
+
+-- name: CreateEquipmentAttribute :exec
+INSERT INTO equipment_attributes (equipment_id, attribute_id, value) VALUES (?, ?, ?);
+
+
+
+
+-- SEARCH QUERIES
+-- name: SearchEquipment :many
+SELECT * FROM serial_numbers
+WHERE (sqlc.narg('device_type_id') IS NULL OR serial_numbers.device_type_id = sqlc.narg('device_type_id'))
+AND (sqlc.narg('manufacturer_id') IS NULL OR serial_numbers.manufacturer_id = sqlc.narg('manufacturer_id'))
+AND (sqlc.narg('status') IS NULL OR serial_numbers.status = sqlc.narg('status'))
+AND (sqlc.narg('lifecycle_state') IS NULL OR serial_numbers.lifecycle_state = sqlc.narg('lifecycle_state'))
+AND (sqlc.narg('location_id') IS NULL OR serial_numbers.location_id = sqlc.narg('location_id'))
+AND (sqlc.narg('product_model_id') IS NULL OR serial_numbers.product_model_id = sqlc.narg('product_model_id'))
+AND (sqlc.narg('serial_number') IS NULL OR serial_numbers.serial_number LIKE sqlc.narg('serial_number') OR REGEXP_LIKE(serial_numbers.serial_canonical, sqlc.narg('canonical_pattern')))
+AND (sqlc.narg('attribute_pairs') IS NULL OR serial_numbers.auto_id IN (
+    SELECT equipment_attributes.equipment_id FROM equipment_attributes
+    WHERE equipment_attributes.attribute_id IN (sqlc.slice('attribute_ids'))
+    AND equipment_attributes.value IN (sqlc.slice('attribute_values'))
+    AND JSON_CONTAINS(sqlc.narg('attribute_pairs'), JSON_QUOTE(CONCAT(equipment_attributes.attribute_id, '=', equipment_attributes.value)))
+    GROUP BY equipment_attributes.equipment_id
+    HAVING COUNT(*) = CAST(sqlc.arg('attribute_count') AS SIGNED)
+))
+AND (sqlc.narg('tag_count') IS NULL OR serial_numbers.auto_id IN (
+    SELECT equipment_tags.equipment_id FROM equipment_tags
+    JOIN tags ON tags.id = equipment_tags.tag_id
+    WHERE tags.name IN (sqlc.slice('tag_names'))
+    GROUP BY equipment_tags.equipment_id
+    HAVING COUNT(DISTINCT equipment_tags.tag_id) = sqlc.narg('tag_count')
+))
+AND serial_numbers.auto_id > sqlc.arg('after_id')
+ORDER BY serial_numbers.auto_id
+LIMIT ?;
+
+
+
+
+-- SERIAL NUMBER RULE QUERIES
+-- name: GetSerialNumberRules :many
+SELECT * FROM serial_number_rules
+ORDER BY id;
+
+-- name: GetSerialNumberRuleByID :one
+SELECT * FROM serial_number_rules
+WHERE id = ?;
+
+-- name: CreateSerialNumberRule :execlastid
+INSERT INTO serial_number_rules (name, manufacturer_id, device_type_id, pattern, min_length, max_length, prefix, checksum) VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteSerialNumberRule :exec
+DELETE FROM serial_number_rules
+WHERE id = ?;
+
+
+
+
+-- SERIAL NORMALIZATION QUERIES
+-- name: GetSerialNormalizations :many
+SELECT * FROM serial_normalizations;
+
+-- name: GetSerialNormalization :one
+SELECT * FROM serial_normalizations
+WHERE manufacturer_id = ?;
+
+-- name: SetSerialNormalization :exec
+INSERT INTO serial_normalizations (manufacturer_id, `trim`, case_fold, separators) VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE `trim` = VALUES(`trim`), case_fold = VALUES(case_fold), separators = VALUES(separators);
+
+-- name: GetSerialNumbersByManufacturer :many
+SELECT auto_id, serial_number, serial_canonical FROM serial_numbers
+WHERE manufacturer_id = ?
+ORDER BY auto_id;
+
+-- name: UpdateSerialCanonical :exec
+UPDATE serial_numbers SET serial_canonical = ?
+WHERE auto_id = ?;
+
+
+
+
+-- FUZZY SEARCH QUERIES
+-- name: GetFuzzySerialCandidates :many
+SELECT auto_id, serial_canonical, manufacturer_id FROM serial_numbers
+WHERE CHAR_LENGTH(serial_canonical) >= CAST(sqlc.arg('min_length') AS SIGNED) AND CHAR_LENGTH(serial_canonical) <= CAST(sqlc.arg('max_length') AS SIGNED)
+AND (sqlc.narg('device_type_id') IS NULL OR device_type_id = sqlc.narg('device_type_id'))
+AND (sqlc.narg('manufacturer_id') IS NULL OR manufacturer_id = sqlc.narg('manufacturer_id'))
+AND (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+AND (sqlc.narg('pieces') IS NULL OR REGEXP_LIKE(serial_canonical, sqlc.narg('pieces')));
+
+-- name: GetEquipmentByAutoIDs :many
+SELECT * FROM serial_numbers
+WHERE auto_id IN (sqlc.slice('auto_ids'));
+
+
+
+
+-- PURCHASE QUERIES
+-- name: UpdateEquipmentPurchase :exec
+UPDATE serial_numbers SET purchase_date = ?, vendor = ?, purchase_order = ?, cost = ?, warranty_start = ?, warranty_end = ?
+WHERE auto_id = ?;
+
+-- name: GetEquipmentWarrantyExpiring :many
+SELECT sqlc.embed(serial_numbers), manufacturer.name AS manufacturer_name FROM serial_numbers
+JOIN manufacturer ON manufacturer.id = serial_numbers.manufacturer_id
+WHERE serial_numbers.warranty_end >= sqlc.arg('from') AND serial_numbers.warranty_end <= sqlc.arg('to')
+ORDER BY serial_numbers.manufacturer_id, serial_numbers.warranty_end, serial_numbers.auto_id;
+
+
+
+
+-- MAINTENANCE QUERIES
+-- name: GetMaintenanceTickets :many
+SELECT * FROM maintenance_tickets
+ORDER BY id;
+
+-- name: GetOpenMaintenanceTickets :many
+SELECT * FROM maintenance_tickets
+WHERE closed_at IS NULL
+ORDER BY id;
+
+-- name: GetMaintenanceTicketByID :one
+SELECT * FROM maintenance_tickets
+WHERE id = ?;
+
+-- name: GetMaintenanceTicketByIDForUpdate :one
+SELECT * FROM maintenance_tickets
+WHERE id = ? FOR UPDATE;
+
+-- name: GetMaintenanceTicketsByEquipment :many
+SELECT * FROM maintenance_tickets
+WHERE equipment_id = ?
+ORDER BY id;
+
+-- name: GetOpenMaintenanceTicketByEquipment :one
+SELECT * FROM maintenance_tickets
+WHERE equipment_id = ? AND closed_at IS NULL
+LIMIT 1;
+
+-- name: CreateMaintenanceTicket :execlastid
+INSERT INTO maintenance_tickets (equipment_id, issue, vendor, opened_at, cost, return_state) VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateMaintenanceTicket :exec
+UPDATE maintenance_tickets SET issue = ?, vendor = ?, cost = ?
+WHERE id = ?;
+
+-- name: CloseMaintenanceTicket :exec
+UPDATE maintenance_tickets SET closed_at = ?, outcome = ?, cost = ?
+WHERE id = ?;
+
+-- name: GetMaintenanceTicketsOpenedBetween :many
+SELECT sqlc.embed(maintenance_tickets), serial_numbers.device_type_id, serial_numbers.manufacturer_id FROM maintenance_tickets
+JOIN serial_numbers ON serial_numbers.auto_id = maintenance_tickets.equipment_id
+WHERE maintenance_tickets.opened_at >= sqlc.arg('from') AND maintenance_tickets.opened_at <= sqlc.arg('to');
+
+-- name: GetEquipmentCounts :many
+SELECT device_type_id, manufacturer_id, COUNT(*) AS count FROM serial_numbers
+GROUP BY device_type_id, manufacturer_id;
+
+
+
+
+-- DEPRECIATION QUERIES
+-- name: GetDepreciationSchedules :many
+SELECT * FROM depreciation_schedules;
+
+-- name: GetDepreciationSchedule :one
+SELECT * FROM depreciation_schedules
+WHERE device_type_id = ?;
+
+-- name: SetDepreciationSchedule :exec
+INSERT INTO depreciation_schedules (device_type_id, method, life_months, salvage_percent, rate_percent) VALUES (?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE method = VALUES(method), life_months = VALUES(life_months), salvage_percent = VALUES(salvage_percent), rate_percent = VALUES(rate_percent);
+
+-- name: DeleteDepreciationSchedule :exec
+DELETE FROM depreciation_schedules
+WHERE device_type_id = ?;
+
+-- name: GetEquipmentPurchasedBy :many
+SELECT * FROM serial_numbers
+WHERE purchase_date <= sqlc.arg('as_of') AND lifecycle_state <> 'disposed'
+ORDER BY auto_id;
+
+
+
+
+-- TAG QUERIES
+-- name: GetTags :many
+SELECT tags.id, tags.name, COUNT(equipment_tags.equipment_id) AS count FROM tags
+LEFT JOIN equipment_tags ON equipment_tags.tag_id = tags.id
+GROUP BY tags.id, tags.name
+ORDER BY tags.name;
+
+-- name: GetTagByID :one
+SELECT * FROM tags
+WHERE id = ?;
+
+-- name: GetTagsByNames :many
+SELECT * FROM tags
+WHERE name IN (sqlc.slice('names'));
+
+-- name: CreateTag :execlastid
+INSERT INTO tags (name) VALUES (?);
+
+-- name: UpdateTag :exec
+UPDATE tags SET name = ?
+WHERE id = ?;
+
+-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE id = ?;
+
+-- name: GetEquipmentTags :many
+SELECT tags.* FROM tags
+JOIN equipment_tags ON equipment_tags.tag_id = tags.id
+WHERE equipment_tags.equipment_id = ?
+ORDER BY tags.name;
+
+-- name: AddEquipmentTag :exec
+INSERT INTO equipment_tags (equipment_id, tag_id) VALUES (?, ?)
+ON DUPLICATE KEY UPDATE tag_id = tag_id;
+
+-- name: RemoveEquipmentTag :exec
+DELETE FROM equipment_tags
+WHERE equipment_id = ? AND tag_id = ?;
+
+
+
+
+
+-- ATTACHMENT QUERIES
+-- name: GetAttachmentByID :one
+SELECT * FROM attachments
+WHERE id = ?;
+
+-- name: GetEquipmentAttachments :many
+SELECT * FROM attachments
+WHERE equipment_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetManufacturerAttachments :many
+SELECT * FROM attachments
+WHERE manufacturer_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: CreateAttachment :execlastid
+INSERT INTO attachments (equipment_id, manufacturer_id, kind, filename, content_type, size, sha256, storage_key)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: DeleteAttachment :exec
+DELETE FROM attachments
+WHERE id = ?;
+
+
+
+
+-- SCAN QUERIES
+-- name: UpdateEquipmentLastSeen :exec
+UPDATE serial_numbers SET last_seen_at = CURRENT_TIMESTAMP,
+  last_seen_location_id = COALESCE(sqlc.narg('location_id'), last_seen_location_id)
+WHERE auto_id = sqlc.arg('auto_id');
+
+
+
+
+-- COUNT QUERIES
+-- name: GetCountSessions :many
+SELECT count_sessions.*, COUNT(count_scans.id) AS scans FROM count_sessions
+LEFT JOIN count_scans ON count_scans.session_id = count_sessions.id
+GROUP BY count_sessions.id
+ORDER BY count_sessions.created_at DESC, count_sessions.id DESC;
+
+-- name: GetCountSessionByID :one
+SELECT * FROM count_sessions
+WHERE id = ?;
+
+-- name: GetCountSessionByIDForUpdate :one
+SELECT * FROM count_sessions
+WHERE id = ?
+FOR UPDATE;
+
+-- name: CreateCountSession :execlastid
+INSERT INTO count_sessions (name, location_id, device_type_id) VALUES (?, ?, ?);
+
+-- name: CloseCountSession :exec
+UPDATE count_sessions SET status = 'closed', closed_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: ApplyCountSession :exec
+UPDATE count_sessions SET status = 'closed', closed_at = CURRENT_TIMESTAMP, applied_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetCountScans :many
+SELECT * FROM count_scans
+WHERE session_id = ?
+ORDER BY id;
+
+-- name: GetCountScanByEquipment :one
+SELECT * FROM count_scans
+WHERE session_id = ? AND equipment_id = ?;
+
+-- name: CreateCountScan :execlastid
+INSERT INTO count_scans (session_id, code, equipment_id, location_id) VALUES (?, ?, ?, ?);
+
+-- name: UpdateCountScan :exec
+UPDATE count_scans SET code = ?, location_id = ?, scanned_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: GetEquipmentForCount :many
+SELECT * FROM serial_numbers
+WHERE sqlc.narg('device_type_id') IS NULL OR device_type_id = sqlc.narg('device_type_id')
+ORDER BY auto_id;
+
+
+
+
+-- STATS QUERIES
+-- name: CountEquipmentByDeviceTypeAndManufacturer :many
+SELECT serial_numbers.device_type_id, device_type.name AS device_type,
+    serial_numbers.manufacturer_id, manufacturer.name AS manufacturer,
+    CAST(SUM(serial_numbers.status = 'active') AS SIGNED) AS active,
+    CAST(SUM(serial_numbers.status = 'inactive') AS SIGNED) AS inactive
+FROM serial_numbers
+JOIN device_type ON device_type.id = serial_numbers.device_type_id
+JOIN manufacturer ON manufacturer.id = serial_numbers.manufacturer_id
+GROUP BY serial_numbers.device_type_id, device_type.name, serial_numbers.manufacturer_id, manufacturer.name
+ORDER BY serial_numbers.device_type_id, serial_numbers.manufacturer_id;
+
+-- name: CountEquipmentCreatedBefore :one
+SELECT COUNT(*) FROM serial_numbers
+WHERE created_at < sqlc.arg('before');
+
+-- name: CountEquipmentCreatedByDay :many
+SELECT DATE_FORMAT(created_at, '%Y-%m-%d') AS day, COUNT(*) AS total
+FROM serial_numbers
+WHERE created_at >= sqlc.arg('from') AND created_at < sqlc.arg('to')
+GROUP BY day
+ORDER BY day;